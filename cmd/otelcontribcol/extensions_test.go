@@ -33,8 +33,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
@@ -69,6 +71,18 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "runtime_settings",
+			getConfigFn: func() component.Config {
+				cfg := extFactories["runtime_settings"].CreateDefaultConfig().(*runtimesettingsextension.Config)
+				// No need to clean up, t.TempDir will be deleted entirely.
+				fileName := filepath.Join(t.TempDir(), "settings.yaml")
+				require.NoError(t, os.WriteFile(fileName, []byte("{}"), 0600))
+
+				cfg.File = &runtimesettingsextension.FileSettings{Path: fileName}
+				return cfg
+			},
+		},
 		{
 			extension: "sigv4auth",
 			getConfigFn: func() component.Config {
@@ -107,6 +121,9 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "mtlsauth",
+		},
 		{
 			extension: "memory_ballast",
 			getConfigFn: func() component.Config {
@@ -188,6 +205,10 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension:     "redis_storage",
+			skipLifecycle: true, // Requires a running Redis server
+		},
 		{
 			extension: "host_observer",
 			getConfigFn: func() component.Config {
@@ -195,6 +216,14 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "file_observer",
+			getConfigFn: func() component.Config {
+				cfg := extFactories["file_observer"].CreateDefaultConfig().(*fileobserver.Config)
+				cfg.Include = []string{filepath.Join(t.TempDir(), "*.log")}
+				return cfg
+			},
+		},
 		{
 			extension:     "k8s_observer",
 			skipLifecycle: true, // Requires a K8s api to interfact with and validate
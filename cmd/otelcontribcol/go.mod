@@ -6,6 +6,8 @@ go 1.19
 
 require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/connector/countconnector v0.80.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector v0.80.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/alibabacloudlogserviceexporter v0.80.0
@@ -61,14 +63,17 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling v0.80.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/dockerobserver v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecsobserver v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver v0.80.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension v0.80.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.80.0
@@ -89,6 +94,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor v0.80.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor v0.80.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanprocessor v0.80.0
@@ -513,6 +519,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/proxy v0.80.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray v0.80.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker v0.80.0 // indirect
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload v0.80.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter v0.80.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig v0.80.0 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/kubelet v0.80.0 // indirect
@@ -775,6 +782,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsec
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension => ../../extension/oidcauthextension
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension => ../../extension/mtlsauthextension
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerthrifthttpexporter => ../../exporter/jaegerthrifthttpexporter
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter => ../../exporter/awskinesisexporter
@@ -849,6 +858,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tcplo
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension => ../../extension/pprofextension
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension => ../../extension/runtimesettingsextension
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki => ../../pkg/translator/loki
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/metrics => ../../internal/aws/metrics
@@ -959,6 +970,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/span
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor => ../../processor/routingprocessor
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor => ../../processor/samplingthroughputprocessor
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor => ../../processor/deltatorateprocessor
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter => ../../exporter/datadogexporter
@@ -1077,6 +1090,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parqu
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver => ../../extension/observer/ecstaskobserver
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver => ../../extension/observer/fileobserver
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/lokireceiver => ../../receiver/lokireceiver
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filestatsreceiver => ../../receiver/filestatsreceiver
@@ -1107,6 +1122,10 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/connector/countconnector => ../../connector/countconnector
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector => ../../connector/exceptionsconnector
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector => ../../connector/logmetricsconnector
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector => ../../connector/servicegraphconnector
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector => ../../connector/spanmetricsconnector
@@ -1117,3 +1136,5 @@ replace github.com/outcaste-io/ristretto v0.2.0 => github.com/outcaste-io/ristre
 
 // ambiguous import: found package cloud.google.com/go/compute/metadata in multiple modules
 replace cloud.google.com/go => cloud.google.com/go v0.110.2
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload => ../../internal/filereload
@@ -109,6 +109,10 @@ func TestDefaultProcessors(t *testing.T) {
 			processor:     "routing",
 			skipLifecycle: true, // Requires external exporters to be configured to route data
 		},
+		{
+			processor:     "sampling_throughput",
+			skipLifecycle: true, // Requires the jaegerremotesampling extension to be configured
+		},
 		{
 			processor: "span",
 			getConfigFn: func() component.Config {
@@ -30,6 +30,16 @@ const (
 	defaultMetricDescLogs = "The number of log records observed."
 )
 
+// The types of the emitted metrics.
+const (
+	metricTypeSum       = "sum"
+	metricTypeHistogram = "histogram"
+	metricTypeGaugeLast = "gauge_last"
+)
+
+// defaultHistogramBuckets are the default explicit bucket boundaries of histograms.
+var defaultHistogramBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
 // Config for the connector
 type Config struct {
 	Spans      map[string]MetricInfo `mapstructure:"spans"`
@@ -44,6 +54,20 @@ type MetricInfo struct {
 	Description string            `mapstructure:"description"`
 	Conditions  []string          `mapstructure:"conditions"`
 	Attributes  []AttributeConfig `mapstructure:"attributes"`
+	// AdjustedCountAttribute is the optional name of a numeric attribute, such as the one set by the
+	// probabilistic sampler, that holds the number of items represented by each counted item.
+	// If set, items are weighted by its value and the count is emitted as a double.
+	AdjustedCountAttribute string `mapstructure:"adjusted_count_attribute"`
+	// Value is the optional OTTL expression, such as `attributes["http.response.body.size"]`, computing the
+	// numeric value recorded for each matching item. Items for which it evaluates to nil are not recorded.
+	// If not set, the items are counted.
+	Value string `mapstructure:"value"`
+	// Type is the type of the metric: "sum" (default) of the values, "histogram" of the values,
+	// or "gauge_last" for a gauge of the last value. Types other than "sum" require a value.
+	Type string `mapstructure:"type"`
+	// Buckets are the explicit bucket boundaries of a histogram metric.
+	// Optional. See defaultHistogramBuckets in config.go for the default value.
+	Buckets []float64 `mapstructure:"buckets"`
 }
 
 type AttributeConfig struct {
@@ -62,6 +86,9 @@ func (c *Config) Validate() error {
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spans attributes: metric %q: %w", name, err)
 		}
+		if err := validateValue(&info, newSpanValue); err != nil {
+			return fmt.Errorf("spans value: metric %q: %w", name, err)
+		}
 	}
 	for name, info := range c.SpanEvents {
 		if name == "" {
@@ -73,6 +100,9 @@ func (c *Config) Validate() error {
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spanevents attributes: metric %q: %w", name, err)
 		}
+		if err := validateValue(&info, newSpanEventValue); err != nil {
+			return fmt.Errorf("spanevents value: metric %q: %w", name, err)
+		}
 	}
 	for name, info := range c.Metrics {
 		if name == "" {
@@ -84,6 +114,12 @@ func (c *Config) Validate() error {
 		if len(info.Attributes) > 0 {
 			return fmt.Errorf("metrics attributes not supported: metric %q", name)
 		}
+		if info.AdjustedCountAttribute != "" {
+			return fmt.Errorf("metrics adjusted count attribute not supported: metric %q", name)
+		}
+		if info.Value != "" || info.Type != "" || len(info.Buckets) > 0 {
+			return fmt.Errorf("metrics value not supported: metric %q", name)
+		}
 	}
 
 	for name, info := range c.DataPoints {
@@ -96,6 +132,9 @@ func (c *Config) Validate() error {
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("spans attributes: metric %q: %w", name, err)
 		}
+		if err := validateValue(&info, newDataPointValue); err != nil {
+			return fmt.Errorf("datapoints value: metric %q: %w", name, err)
+		}
 	}
 	for name, info := range c.Logs {
 		if name == "" {
@@ -107,6 +146,9 @@ func (c *Config) Validate() error {
 		if err := info.validateAttributes(); err != nil {
 			return fmt.Errorf("logs attributes: metric %q: %w", name, err)
 		}
+		if err := validateValue(&info, newLogValue); err != nil {
+			return fmt.Errorf("logs value: metric %q: %w", name, err)
+		}
 	}
 	return nil
 }
@@ -120,6 +162,50 @@ func (i *MetricInfo) validateAttributes() error {
 	return nil
 }
 
+// validateValue checks the type of the metric and its options, and that the value expression
+// can be parsed by the given function.
+func validateValue[K any](i *MetricInfo, parse func(string, component.TelemetrySettings) (*ottl.Statement[K], error)) error {
+	switch i.Type {
+	case "", metricTypeSum:
+	case metricTypeHistogram, metricTypeGaugeLast:
+		if i.Value == "" {
+			return fmt.Errorf("type %q requires a value", i.Type)
+		}
+		if i.AdjustedCountAttribute != "" {
+			return fmt.Errorf("adjusted count attribute not supported by type %q", i.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type %q, must be one of %q, %q or %q", i.Type, metricTypeSum, metricTypeHistogram, metricTypeGaugeLast)
+	}
+	if len(i.Buckets) > 0 {
+		if i.Type != metricTypeHistogram {
+			return fmt.Errorf("buckets are only supported by type %q", metricTypeHistogram)
+		}
+		for j := 1; j < len(i.Buckets); j++ {
+			if i.Buckets[j] <= i.Buckets[j-1] {
+				return fmt.Errorf("buckets must be in increasing order")
+			}
+		}
+	}
+	if i.Value != "" {
+		if _, err := parse(i.Value, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// histogramBuckets returns the bucket boundaries of a histogram metric.
+func (i *MetricInfo) histogramBuckets() []float64 {
+	if i.Type != metricTypeHistogram {
+		return nil
+	}
+	if len(i.Buckets) > 0 {
+		return i.Buckets
+	}
+	return defaultHistogramBuckets
+}
+
 var _ confmap.Unmarshaler = (*Config)(nil)
 
 // Unmarshal with custom logic to set default values.
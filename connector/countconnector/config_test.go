@@ -463,6 +463,108 @@ func TestConfigErrors(t *testing.T) {
 			},
 			expect: fmt.Sprintf("logs condition: metric %q: unable to parse OTTL statement", defaultMetricNameLogs),
 		},
+		{
+			name: "adjusted_count_attribute_metric",
+			input: &Config{
+				Metrics: map[string]MetricInfo{
+					defaultMetricNameMetrics: {
+						Description:            defaultMetricDescMetrics,
+						AdjustedCountAttribute: "sampling.adjusted_count",
+					},
+				},
+			},
+			expect: fmt.Sprintf("metrics adjusted count attribute not supported: metric %q", defaultMetricNameMetrics),
+		},
+		{
+			name: "value_metric",
+			input: &Config{
+				Metrics: map[string]MetricInfo{
+					defaultMetricNameMetrics: {
+						Description: defaultMetricDescMetrics,
+						Value:       "1",
+					},
+				},
+			},
+			expect: fmt.Sprintf("metrics value not supported: metric %q", defaultMetricNameMetrics),
+		},
+		{
+			name: "invalid_value_span",
+			input: &Config{
+				Spans: map[string]MetricInfo{
+					defaultMetricNameSpans: {
+						Description: defaultMetricDescSpans,
+						Value:       "invalid value",
+					},
+				},
+			},
+			expect: fmt.Sprintf("spans value: metric %q: unable to parse OTTL statement", defaultMetricNameSpans),
+		},
+		{
+			name: "unknown_type_spanevent",
+			input: &Config{
+				SpanEvents: map[string]MetricInfo{
+					defaultMetricNameSpanEvents: {
+						Description: defaultMetricDescSpanEvents,
+						Value:       `attributes["size"]`,
+						Type:        "gauge",
+					},
+				},
+			},
+			expect: fmt.Sprintf(`spanevents value: metric %q: unknown type "gauge"`, defaultMetricNameSpanEvents),
+		},
+		{
+			name: "histogram_without_value_datapoint",
+			input: &Config{
+				DataPoints: map[string]MetricInfo{
+					defaultMetricNameDataPoints: {
+						Description: defaultMetricDescDataPoints,
+						Type:        metricTypeHistogram,
+					},
+				},
+			},
+			expect: fmt.Sprintf(`datapoints value: metric %q: type "histogram" requires a value`, defaultMetricNameDataPoints),
+		},
+		{
+			name: "adjusted_count_attribute_gauge_last_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						Description:            defaultMetricDescLogs,
+						Value:                  `attributes["size"]`,
+						Type:                   metricTypeGaugeLast,
+						AdjustedCountAttribute: "sampling.adjusted_count",
+					},
+				},
+			},
+			expect: fmt.Sprintf(`logs value: metric %q: adjusted count attribute not supported by type "gauge_last"`, defaultMetricNameLogs),
+		},
+		{
+			name: "buckets_sum_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						Description: defaultMetricDescLogs,
+						Value:       `attributes["size"]`,
+						Buckets:     []float64{1, 2},
+					},
+				},
+			},
+			expect: fmt.Sprintf(`logs value: metric %q: buckets are only supported by type "histogram"`, defaultMetricNameLogs),
+		},
+		{
+			name: "unsorted_buckets_log",
+			input: &Config{
+				Logs: map[string]MetricInfo{
+					defaultMetricNameLogs: {
+						Description: defaultMetricDescLogs,
+						Value:       `attributes["size"]`,
+						Type:        metricTypeHistogram,
+						Buckets:     []float64{2, 1},
+					},
+				},
+			},
+			expect: fmt.Sprintf(`logs value: metric %q: buckets must be in increasing order`, defaultMetricNameLogs),
+		},
 	}
 
 	for _, tc := range testCases {
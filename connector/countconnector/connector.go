@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
@@ -28,6 +29,7 @@ const scopeName = "otelcol/countconnector"
 // and emit the counts onto a metrics pipeline.
 type count struct {
 	metricsConsumer consumer.Metrics
+	logger          *zap.Logger
 	component.StartFunc
 	component.ShutdownFunc
 
@@ -48,8 +50,8 @@ func (c *count) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	countMetrics.ResourceMetrics().EnsureCapacity(td.ResourceSpans().Len())
 	for i := 0; i < td.ResourceSpans().Len(); i++ {
 		resourceSpan := td.ResourceSpans().At(i)
-		spansCounter := newCounter[ottlspan.TransformContext](c.spansMetricDefs)
-		spanEventsCounter := newCounter[ottlspanevent.TransformContext](c.spanEventsMetricDefs)
+		spansCounter := newCounter[ottlspan.TransformContext](c.spansMetricDefs, c.logger)
+		spanEventsCounter := newCounter[ottlspanevent.TransformContext](c.spanEventsMetricDefs, c.logger)
 
 		for j := 0; j < resourceSpan.ScopeSpans().Len(); j++ {
 			scopeSpan := resourceSpan.ScopeSpans().At(j)
@@ -57,12 +59,12 @@ func (c *count) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 			for k := 0; k < scopeSpan.Spans().Len(); k++ {
 				span := scopeSpan.Spans().At(k)
 				sCtx := ottlspan.NewTransformContext(span, scopeSpan.Scope(), resourceSpan.Resource())
-				errors = multierr.Append(errors, spansCounter.update(ctx, span.Attributes(), sCtx))
+				errors = multierr.Append(errors, spansCounter.update(ctx, span.Attributes(), span.Attributes(), sCtx))
 
 				for l := 0; l < span.Events().Len(); l++ {
 					event := span.Events().At(l)
 					eCtx := ottlspanevent.NewTransformContext(event, span, scopeSpan.Scope(), resourceSpan.Resource())
-					errors = multierr.Append(errors, spanEventsCounter.update(ctx, event.Attributes(), eCtx))
+					errors = multierr.Append(errors, spanEventsCounter.update(ctx, event.Attributes(), span.Attributes(), eCtx))
 				}
 			}
 		}
@@ -93,8 +95,8 @@ func (c *count) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	countMetrics.ResourceMetrics().EnsureCapacity(md.ResourceMetrics().Len())
 	for i := 0; i < md.ResourceMetrics().Len(); i++ {
 		resourceMetric := md.ResourceMetrics().At(i)
-		metricsCounter := newCounter[ottlmetric.TransformContext](c.metricsMetricDefs)
-		dataPointsCounter := newCounter[ottldatapoint.TransformContext](c.dataPointsMetricDefs)
+		metricsCounter := newCounter[ottlmetric.TransformContext](c.metricsMetricDefs, c.logger)
+		dataPointsCounter := newCounter[ottldatapoint.TransformContext](c.dataPointsMetricDefs, c.logger)
 
 		for j := 0; j < resourceMetric.ScopeMetrics().Len(); j++ {
 			scopeMetrics := resourceMetric.ScopeMetrics().At(j)
@@ -102,38 +104,38 @@ func (c *count) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 			for k := 0; k < scopeMetrics.Metrics().Len(); k++ {
 				metric := scopeMetrics.Metrics().At(k)
 				mCtx := ottlmetric.NewTransformContext(metric, scopeMetrics.Scope(), resourceMetric.Resource())
-				errors = multierr.Append(errors, metricsCounter.update(ctx, pcommon.NewMap(), mCtx))
+				errors = multierr.Append(errors, metricsCounter.update(ctx, pcommon.NewMap(), pcommon.NewMap(), mCtx))
 
 				switch metric.Type() {
 				case pmetric.MetricTypeGauge:
 					dps := metric.Gauge().DataPoints()
 					for i := 0; i < dps.Len(); i++ {
 						dCtx := ottldatapoint.NewTransformContext(dps.At(i), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource())
-						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dCtx))
+						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dps.At(i).Attributes(), dCtx))
 					}
 				case pmetric.MetricTypeSum:
 					dps := metric.Sum().DataPoints()
 					for i := 0; i < dps.Len(); i++ {
 						dCtx := ottldatapoint.NewTransformContext(dps.At(i), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource())
-						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dCtx))
+						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dps.At(i).Attributes(), dCtx))
 					}
 				case pmetric.MetricTypeSummary:
 					dps := metric.Summary().DataPoints()
 					for i := 0; i < dps.Len(); i++ {
 						dCtx := ottldatapoint.NewTransformContext(dps.At(i), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource())
-						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dCtx))
+						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dps.At(i).Attributes(), dCtx))
 					}
 				case pmetric.MetricTypeHistogram:
 					dps := metric.Histogram().DataPoints()
 					for i := 0; i < dps.Len(); i++ {
 						dCtx := ottldatapoint.NewTransformContext(dps.At(i), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource())
-						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dCtx))
+						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dps.At(i).Attributes(), dCtx))
 					}
 				case pmetric.MetricTypeExponentialHistogram:
 					dps := metric.ExponentialHistogram().DataPoints()
 					for i := 0; i < dps.Len(); i++ {
 						dCtx := ottldatapoint.NewTransformContext(dps.At(i), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource())
-						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dCtx))
+						errors = multierr.Append(errors, dataPointsCounter.update(ctx, dps.At(i).Attributes(), dps.At(i).Attributes(), dCtx))
 					}
 				case pmetric.MetricTypeEmpty:
 					errors = multierr.Append(errors, fmt.Errorf("metric %q: invalid metric type: %v", metric.Name(), metric.Type()))
@@ -167,7 +169,7 @@ func (c *count) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	countMetrics.ResourceMetrics().EnsureCapacity(ld.ResourceLogs().Len())
 	for i := 0; i < ld.ResourceLogs().Len(); i++ {
 		resourceLog := ld.ResourceLogs().At(i)
-		counter := newCounter[ottllog.TransformContext](c.logsMetricDefs)
+		counter := newCounter[ottllog.TransformContext](c.logsMetricDefs, c.logger)
 
 		for j := 0; j < resourceLog.ScopeLogs().Len(); j++ {
 			scopeLogs := resourceLog.ScopeLogs().At(j)
@@ -176,7 +178,7 @@ func (c *count) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 				logRecord := scopeLogs.LogRecords().At(k)
 
 				lCtx := ottllog.NewTransformContext(logRecord, scopeLogs.Scope(), resourceLog.Resource())
-				errors = multierr.Append(errors, counter.update(ctx, logRecord.Attributes(), lCtx))
+				errors = multierr.Append(errors, counter.update(ctx, logRecord.Attributes(), logRecord.Attributes(), lCtx))
 			}
 		}
 
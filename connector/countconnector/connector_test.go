@@ -5,6 +5,8 @@ package countconnector
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"path/filepath"
 	"testing"
 
@@ -13,6 +15,9 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
@@ -658,3 +663,182 @@ func TestLogsToMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestAdjustedCount(t *testing.T) {
+	cfg := &Config{
+		Spans: map[string]MetricInfo{
+			"span.count": {
+				Description:            "Adjusted span count",
+				AdjustedCountAttribute: "sampling.adjusted_count",
+			},
+		},
+		SpanEvents: map[string]MetricInfo{
+			"spanevent.count": {
+				Description:            "Adjusted span event count",
+				AdjustedCountAttribute: "sampling.adjusted_count",
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	factory := NewFactory()
+	sink := &consumertest.MetricsSink{}
+	conn, err := factory.CreateTracesToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().Attributes().PutDouble("sampling.adjusted_count", 4)
+	spans.AppendEmpty().Attributes().PutInt("sampling.adjusted_count", 2)
+	span := spans.AppendEmpty()
+	span.Attributes().PutDouble("sampling.adjusted_count", 2.5)
+	span.Events().AppendEmpty()
+	span.Events().AppendEmpty()
+	// Spans without a valid adjusted count are counted once.
+	spans.AppendEmpty()
+	spans.AppendEmpty().Attributes().PutStr("sampling.adjusted_count", "ten")
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		require.Equal(t, 1, metric.Sum().DataPoints().Len())
+		switch metric.Name() {
+		case "span.count":
+			assert.Equal(t, 10.5, metric.Sum().DataPoints().At(0).DoubleValue())
+		case "spanevent.count":
+			assert.Equal(t, 5.0, metric.Sum().DataPoints().At(0).DoubleValue())
+		default:
+			t.Errorf("unexpected metric %q", metric.Name())
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	cfg := &Config{
+		Logs: map[string]MetricInfo{
+			"http.response.body.size.total": {
+				Description: "Total response size",
+				Value:       `attributes["http.response.body.size"]`,
+				Attributes:  []AttributeConfig{{Key: "http.route"}},
+			},
+			"http.response.body.size.distribution": {
+				Description: "Response size distribution",
+				Value:       `attributes["http.response.body.size"]`,
+				Type:        metricTypeHistogram,
+				Buckets:     []float64{100, 1000},
+			},
+			"http.response.body.size.last": {
+				Description: "Last response size",
+				Value:       `Int(attributes["size"])`,
+				Type:        metricTypeGaugeLast,
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	factory := NewFactory()
+	sink := &consumertest.MetricsSink{}
+	conn, err := factory.CreateLogsToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, record := range []struct {
+		route string
+		size  any
+	}{
+		{route: "/a", size: int64(50)},
+		{route: "/a", size: 500.5},
+		{route: "/b", size: int64(5000)},
+		// Records without a value are not recorded.
+		{route: "/b"},
+	} {
+		logRecord := logs.AppendEmpty()
+		logRecord.Attributes().PutStr("http.route", record.route)
+		if record.size != nil {
+			require.NoError(t, logRecord.Attributes().PutEmpty("http.response.body.size").FromRaw(record.size))
+			logRecord.Attributes().PutStr("size", fmt.Sprint(record.size))
+		}
+	}
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		switch metric.Name() {
+		case "http.response.body.size.total":
+			require.Equal(t, pmetric.MetricTypeSum, metric.Type())
+			assert.False(t, metric.Sum().IsMonotonic())
+			sums := map[string]float64{}
+			for j := 0; j < metric.Sum().DataPoints().Len(); j++ {
+				dp := metric.Sum().DataPoints().At(j)
+				route, _ := dp.Attributes().Get("http.route")
+				sums[route.Str()] = dp.DoubleValue()
+			}
+			assert.Equal(t, map[string]float64{"/a": 550.5, "/b": 5000}, sums)
+		case "http.response.body.size.distribution":
+			require.Equal(t, pmetric.MetricTypeHistogram, metric.Type())
+			assert.Equal(t, pmetric.AggregationTemporalityDelta, metric.Histogram().AggregationTemporality())
+			require.Equal(t, 1, metric.Histogram().DataPoints().Len())
+			dp := metric.Histogram().DataPoints().At(0)
+			assert.Equal(t, []float64{100, 1000}, dp.ExplicitBounds().AsRaw())
+			assert.Equal(t, []uint64{1, 1, 1}, dp.BucketCounts().AsRaw())
+			assert.Equal(t, uint64(3), dp.Count())
+			assert.Equal(t, 5550.5, dp.Sum())
+			assert.Equal(t, 50.0, dp.Min())
+			assert.Equal(t, 5000.0, dp.Max())
+		case "http.response.body.size.last":
+			require.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+			require.Equal(t, 1, metric.Gauge().DataPoints().Len())
+			assert.Equal(t, 5000.0, metric.Gauge().DataPoints().At(0).DoubleValue())
+		default:
+			t.Errorf("unexpected metric %q", metric.Name())
+		}
+	}
+}
+
+func TestValueNotNumeric(t *testing.T) {
+	cfg := &Config{
+		Spans: map[string]MetricInfo{
+			"http.response.body.size": {
+				Description: "Strings holding numbers are parsed",
+				Value:       `attributes["http.response.body.size"]`,
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	factory := NewFactory()
+	sink := &consumertest.MetricsSink{}
+	conn, err := factory.CreateTracesToMetrics(context.Background(),
+		connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().Attributes().PutStr("http.response.body.size", "100")
+	spans.AppendEmpty().Attributes().PutStr("http.response.body.size", " 2.5 ")
+	// items with a non-numeric value are skipped without failing the others
+	spans.AppendEmpty().Attributes().PutStr("http.response.body.size", "unknown")
+	spans.AppendEmpty().Attributes().PutBool("http.response.body.size", true)
+	// as well as the NaN and infinite values
+	spans.AppendEmpty().Attributes().PutStr("http.response.body.size", "NaN")
+	spans.AppendEmpty().Attributes().PutStr("http.response.body.size", "+Inf")
+	spans.AppendEmpty().Attributes().PutDouble("http.response.body.size", math.Inf(-1))
+	spans.AppendEmpty().Attributes().PutInt("http.response.body.size", 10)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	metric := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, 1, metric.Sum().DataPoints().Len())
+	assert.Equal(t, 112.5, metric.Sum().DataPoints().At(0).DoubleValue())
+}
@@ -5,19 +5,24 @@ package countconnector // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/adjustedcount"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
 
 var noAttributes = [16]byte{}
 
-func newCounter[K any](metricDefs map[string]metricDef[K]) *counter[K] {
+func newCounter[K any](metricDefs map[string]metricDef[K], logger *zap.Logger) *counter[K] {
 	return &counter[K]{
+		logger:     logger,
 		metricDefs: metricDefs,
 		counts:     make(map[string]map[[16]byte]*attrCounter, len(metricDefs)),
 		timestamp:  time.Now(),
@@ -25,6 +30,7 @@ func newCounter[K any](metricDefs map[string]metricDef[K]) *counter[K] {
 }
 
 type counter[K any] struct {
+	logger     *zap.Logger
 	metricDefs map[string]metricDef[K]
 	counts     map[string]map[[16]byte]*attrCounter
 	timestamp  time.Time
@@ -32,42 +38,101 @@ type counter[K any] struct {
 
 type attrCounter struct {
 	attrs pcommon.Map
-	count uint64
+	// count is the count of the items, or the sum of their values, of a sum metric.
+	count float64
+
+	// The aggregated values of a histogram metric.
+	bucketCounts []uint64
+	observations uint64
+	sum          float64
+	min          float64
+	max          float64
+
+	// last is the last value of a gauge_last metric.
+	last float64
 }
 
-func (c *counter[K]) update(ctx context.Context, attrs pcommon.Map, tCtx K) error {
+// update counts the item described by tCtx, or records its value, for every matching metric. The metric
+// attributes are read from attrs, while the adjusted count, if configured, is read from countAttrs.
+func (c *counter[K]) update(ctx context.Context, attrs pcommon.Map, countAttrs pcommon.Map, tCtx K) error {
 	var errors error
 	for name, md := range c.metricDefs {
-		countAttrs := pcommon.NewMap()
+		metricAttrs := pcommon.NewMap()
 		for _, attr := range md.attrs {
 			if attrVal, ok := attrs.Get(attr.Key); ok {
-				countAttrs.PutStr(attr.Key, attrVal.Str())
+				metricAttrs.PutStr(attr.Key, attrVal.Str())
 			} else if attr.DefaultValue != "" {
-				countAttrs.PutStr(attr.Key, attr.DefaultValue)
+				metricAttrs.PutStr(attr.Key, attr.DefaultValue)
 			}
 		}
 
 		// Missing necessary attributes to be counted
-		if countAttrs.Len() != len(md.attrs) {
+		if metricAttrs.Len() != len(md.attrs) {
 			continue
 		}
 
-		// No conditions, so match all.
-		if md.condition == nil {
-			errors = multierr.Append(errors, c.increment(name, countAttrs))
+		// Without conditions, all items match.
+		if md.condition != nil {
+			match, err := md.condition.Eval(ctx, tCtx)
+			if err != nil {
+				errors = multierr.Append(errors, err)
+				continue
+			}
+			if !match {
+				continue
+			}
+		}
+
+		weight := adjustedcount.FromAttributes(countAttrs, md.adjustedCountAttr)
+		if md.value == nil {
+			errors = multierr.Append(errors, c.increment(name, metricAttrs, weight))
 			continue
 		}
 
-		if match, err := md.condition.Eval(ctx, tCtx); err != nil {
-			errors = multierr.Append(errors, err)
-		} else if match {
-			errors = multierr.Append(errors, c.increment(name, countAttrs))
+		value, ok, err := evalValue(ctx, md.value, tCtx)
+		switch {
+		case isNotNumeric(err):
+			c.logger.Debug("Skipping item with a non-numeric value", zap.String("metric", name), zap.Error(err))
+		case err != nil:
+			errors = multierr.Append(errors, fmt.Errorf("metric %q: %w", name, err))
+		case ok:
+			c.observe(name, md, metricAttrs, value, weight)
 		}
 	}
 	return errors
 }
 
-func (c *counter[K]) increment(metricName string, attrs pcommon.Map) error {
+func (c *counter[K]) increment(metricName string, attrs pcommon.Map, weight float64) error {
+	c.getOrCreate(metricName, attrs).count += weight
+	return nil
+}
+
+// observe records the value of an item in the metric, according to its type.
+func (c *counter[K]) observe(metricName string, md metricDef[K], attrs pcommon.Map, value float64, weight float64) {
+	ac := c.getOrCreate(metricName, attrs)
+	switch md.metricType {
+	case metricTypeHistogram:
+		if ac.bucketCounts == nil {
+			ac.bucketCounts = make([]uint64, len(md.buckets)+1)
+			ac.min, ac.max = value, value
+		}
+		ac.bucketCounts[sort.SearchFloat64s(md.buckets, value)]++
+		ac.observations++
+		ac.sum += value
+		if value < ac.min {
+			ac.min = value
+		}
+		if value > ac.max {
+			ac.max = value
+		}
+	case metricTypeGaugeLast:
+		ac.last = value
+	default:
+		ac.count += value * weight
+	}
+}
+
+func (c *counter[K]) getOrCreate(metricName string, attrs pcommon.Map) *attrCounter {
 	if _, ok := c.counts[metricName]; !ok {
 		c.counts[metricName] = make(map[[16]byte]*attrCounter)
 	}
@@ -80,9 +145,7 @@ func (c *counter[K]) increment(metricName string, attrs pcommon.Map) error {
 	if _, ok := c.counts[metricName][key]; !ok {
 		c.counts[metricName][key] = &attrCounter{attrs: attrs}
 	}
-
-	c.counts[metricName][key].count++
-	return nil
+	return c.counts[metricName][key]
 }
 
 func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
@@ -93,16 +156,48 @@ func (c *counter[K]) appendMetricsTo(metricSlice pmetric.MetricSlice) {
 		countMetric := metricSlice.AppendEmpty()
 		countMetric.SetName(name)
 		countMetric.SetDescription(md.desc)
+		timestamp := pcommon.NewTimestampFromTime(c.timestamp)
+		switch md.metricType {
+		case metricTypeHistogram:
+			histogram := countMetric.SetEmptyHistogram()
+			histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			for _, dpCount := range c.counts[name] {
+				dp := histogram.DataPoints().AppendEmpty()
+				dpCount.attrs.CopyTo(dp.Attributes())
+				dp.ExplicitBounds().FromRaw(md.buckets)
+				dp.BucketCounts().FromRaw(dpCount.bucketCounts)
+				dp.SetCount(dpCount.observations)
+				dp.SetSum(dpCount.sum)
+				dp.SetMin(dpCount.min)
+				dp.SetMax(dpCount.max)
+				dp.SetTimestamp(timestamp)
+			}
+			continue
+		case metricTypeGaugeLast:
+			gauge := countMetric.SetEmptyGauge()
+			for _, dpCount := range c.counts[name] {
+				dp := gauge.DataPoints().AppendEmpty()
+				dpCount.attrs.CopyTo(dp.Attributes())
+				dp.SetDoubleValue(dpCount.last)
+				dp.SetTimestamp(timestamp)
+			}
+			continue
+		}
 		sum := countMetric.SetEmptySum()
-		// The delta value is always positive, so a value accumulated downstream is monotonic
-		sum.SetIsMonotonic(true)
+		// The delta count is always positive, so a value accumulated downstream is monotonic.
+		// The values summed may be negative.
+		sum.SetIsMonotonic(md.value == nil)
 		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 		for _, dpCount := range c.counts[name] {
 			dp := sum.DataPoints().AppendEmpty()
 			dpCount.attrs.CopyTo(dp.Attributes())
-			dp.SetIntValue(int64(dpCount.count))
+			if md.adjustedCountAttr != "" || md.value != nil {
+				dp.SetDoubleValue(dpCount.count)
+			} else {
+				dp.SetIntValue(int64(dpCount.count))
+			}
 			// TODO determine appropriate start time
-			dp.SetTimestamp(pcommon.NewTimestampFromTime(c.timestamp))
+			dp.SetTimestamp(timestamp)
 		}
 	}
 }
@@ -51,8 +51,15 @@ func createTracesToMetrics(
 	spanMetricDefs := make(map[string]metricDef[ottlspan.TransformContext], len(c.Spans))
 	for name, info := range c.Spans {
 		md := metricDef[ottlspan.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:              info.Description,
+			attrs:             info.Attributes,
+			adjustedCountAttr: info.AdjustedCountAttribute,
+			metricType:        info.Type,
+			buckets:           info.histogramBuckets(),
+		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newSpanValue(info.Value, set.TelemetrySettings)
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
@@ -65,8 +72,15 @@ func createTracesToMetrics(
 	spanEventMetricDefs := make(map[string]metricDef[ottlspanevent.TransformContext], len(c.SpanEvents))
 	for name, info := range c.SpanEvents {
 		md := metricDef[ottlspanevent.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:              info.Description,
+			attrs:             info.Attributes,
+			adjustedCountAttr: info.AdjustedCountAttribute,
+			metricType:        info.Type,
+			buckets:           info.histogramBuckets(),
+		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newSpanEventValue(info.Value, set.TelemetrySettings)
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
@@ -78,6 +92,7 @@ func createTracesToMetrics(
 
 	return &count{
 		metricsConsumer:      nextConsumer,
+		logger:               set.Logger,
 		spansMetricDefs:      spanMetricDefs,
 		spanEventsMetricDefs: spanEventMetricDefs,
 	}, nil
@@ -108,8 +123,15 @@ func createMetricsToMetrics(
 	dataPointMetricDefs := make(map[string]metricDef[ottldatapoint.TransformContext], len(c.DataPoints))
 	for name, info := range c.DataPoints {
 		md := metricDef[ottldatapoint.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:              info.Description,
+			attrs:             info.Attributes,
+			adjustedCountAttr: info.AdjustedCountAttribute,
+			metricType:        info.Type,
+			buckets:           info.histogramBuckets(),
+		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newDataPointValue(info.Value, set.TelemetrySettings)
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
@@ -121,6 +143,7 @@ func createMetricsToMetrics(
 
 	return &count{
 		metricsConsumer:      nextConsumer,
+		logger:               set.Logger,
 		metricsMetricDefs:    metricMetricDefs,
 		dataPointsMetricDefs: dataPointMetricDefs,
 	}, nil
@@ -138,8 +161,15 @@ func createLogsToMetrics(
 	metricDefs := make(map[string]metricDef[ottllog.TransformContext], len(c.Logs))
 	for name, info := range c.Logs {
 		md := metricDef[ottllog.TransformContext]{
-			desc:  info.Description,
-			attrs: info.Attributes,
+			desc:              info.Description,
+			attrs:             info.Attributes,
+			adjustedCountAttr: info.AdjustedCountAttribute,
+			metricType:        info.Type,
+			buckets:           info.histogramBuckets(),
+		}
+		if info.Value != "" {
+			// Error checked in Config.Validate()
+			md.value, _ = newLogValue(info.Value, set.TelemetrySettings)
 		}
 		if len(info.Conditions) > 0 {
 			// Error checked in Config.Validate()
@@ -151,12 +181,17 @@ func createLogsToMetrics(
 
 	return &count{
 		metricsConsumer: nextConsumer,
+		logger:          set.Logger,
 		logsMetricDefs:  metricDefs,
 	}, nil
 }
 
 type metricDef[K any] struct {
-	condition expr.BoolExpr[K]
-	desc      string
-	attrs     []AttributeConfig
+	condition         expr.BoolExpr[K]
+	desc              string
+	attrs             []AttributeConfig
+	adjustedCountAttr string
+	value             *ottl.Statement[K]
+	metricType        string
+	buckets           []float64
 }
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/countconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlexpr"
+)
+
+func newSpanValue(value string, set component.TelemetrySettings) (*ottl.Statement[ottlspan.TransformContext], error) {
+	parser, err := ottlspan.NewParser(ottlexpr.Functions[ottlspan.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return ottlexpr.Parse(parser, value)
+}
+
+func newSpanEventValue(value string, set component.TelemetrySettings) (*ottl.Statement[ottlspanevent.TransformContext], error) {
+	parser, err := ottlspanevent.NewParser(ottlexpr.Functions[ottlspanevent.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return ottlexpr.Parse(parser, value)
+}
+
+func newDataPointValue(value string, set component.TelemetrySettings) (*ottl.Statement[ottldatapoint.TransformContext], error) {
+	parser, err := ottldatapoint.NewParser(ottlexpr.Functions[ottldatapoint.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return ottlexpr.Parse(parser, value)
+}
+
+func newLogValue(value string, set component.TelemetrySettings) (*ottl.Statement[ottllog.TransformContext], error) {
+	parser, err := ottllog.NewParser(ottlexpr.Functions[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return ottlexpr.Parse(parser, value)
+}
+
+// errNotNumeric is returned for the items whose value is not a number, they are skipped
+// without failing the other items.
+var errNotNumeric = errors.New("value is not numeric")
+
+// evalValue returns the numeric value of the expression for the item described by tCtx.
+// Strings holding a number are parsed, as numeric attributes are often recorded as strings.
+// The ok flag is false if the expression evaluates to nil, in which case the item is not recorded.
+func evalValue[K any](ctx context.Context, statement *ottl.Statement[K], tCtx K) (value float64, ok bool, err error) {
+	result, _, err := statement.Execute(ctx, tCtx)
+	if err != nil {
+		return 0, false, err
+	}
+	switch v := result.(type) {
+	case nil:
+		return 0, false, nil
+	case int64:
+		return float64(v), true, nil
+	case float64:
+		return finite(v)
+	case string:
+		return parseNumber(v)
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeInt:
+			return float64(v.Int()), true, nil
+		case pcommon.ValueTypeDouble:
+			return finite(v.Double())
+		case pcommon.ValueTypeStr:
+			return parseNumber(v.Str())
+		case pcommon.ValueTypeEmpty:
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("%w: value of type %s", errNotNumeric, v.Type())
+	}
+	return 0, false, fmt.Errorf("%w: value of type %T", errNotNumeric, result)
+}
+
+func parseNumber(s string) (float64, bool, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%w: %q", errNotNumeric, s)
+	}
+	return finite(value)
+}
+
+// finite rejects the NaN and infinite values, which would poison the sums they are added to.
+func finite(value float64) (float64, bool, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false, fmt.Errorf("%w: %v", errNotNumeric, value)
+	}
+	return value, true, nil
+}
+
+func isNotNumeric(err error) bool {
+	return errors.Is(err, errNotNumeric)
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// Config for the connector
+type Config struct {
+	// Conditions are the OTTL conditions selecting the spans from which log records are extracted.
+	// A span is selected if any of the conditions match. If none is set, all spans are selected.
+	Conditions []string `mapstructure:"conditions"`
+	// ErrorMode determines how errors returned by the conditions are handled.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if len(c.Conditions) == 0 {
+		return nil
+	}
+	if _, err := filterottl.NewBoolExprForSpan(c.Conditions, filterottl.StandardSpanFuncs(), c.ErrorMode, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		return fmt.Errorf("conditions: %w", err)
+	}
+	return nil
+}
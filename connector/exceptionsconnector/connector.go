@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+)
+
+const (
+	// exceptionEventName is the name of the span events recording exceptions.
+	exceptionEventName = "exception"
+
+	spanNameKey = "span.name"
+	spanKindKey = "span.kind"
+)
+
+// exceptions turns the exception events of spans, and the spans with an error
+// status, into log records and emits them onto a logs pipeline.
+type exceptions struct {
+	logsConsumer consumer.Logs
+	component.StartFunc
+	component.ShutdownFunc
+
+	// condition is nil if all spans are selected.
+	condition expr.BoolExpr[ottlspan.TransformContext]
+}
+
+func (e *exceptions) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *exceptions) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	var errors error
+	logs := plog.NewLogs()
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		resourceSpan := td.ResourceSpans().At(i)
+		resourceLog := plog.NewResourceLogs()
+
+		for j := 0; j < resourceSpan.ScopeSpans().Len(); j++ {
+			scopeSpan := resourceSpan.ScopeSpans().At(j)
+			scopeLog := plog.NewScopeLogs()
+
+			for k := 0; k < scopeSpan.Spans().Len(); k++ {
+				span := scopeSpan.Spans().At(k)
+				if !hasException(span) {
+					continue
+				}
+				if e.condition != nil {
+					sCtx := ottlspan.NewTransformContext(span, scopeSpan.Scope(), resourceSpan.Resource())
+					match, err := e.condition.Eval(ctx, sCtx)
+					if err != nil {
+						errors = multierr.Append(errors, err)
+						continue
+					}
+					if !match {
+						continue
+					}
+				}
+				appendLogRecords(span, scopeLog.LogRecords())
+			}
+
+			if scopeLog.LogRecords().Len() == 0 {
+				continue // don't add an empty scope
+			}
+			scopeSpan.Scope().CopyTo(scopeLog.Scope())
+			scopeLog.SetSchemaUrl(scopeSpan.SchemaUrl())
+			scopeLog.MoveTo(resourceLog.ScopeLogs().AppendEmpty())
+		}
+
+		if resourceLog.ScopeLogs().Len() == 0 {
+			continue // don't add an empty resource
+		}
+		resourceSpan.Resource().CopyTo(resourceLog.Resource())
+		resourceLog.SetSchemaUrl(resourceSpan.SchemaUrl())
+		resourceLog.MoveTo(logs.ResourceLogs().AppendEmpty())
+	}
+	if errors != nil {
+		return errors
+	}
+	if logs.LogRecordCount() == 0 {
+		return nil
+	}
+	return e.logsConsumer.ConsumeLogs(ctx, logs)
+}
+
+// hasException returns true if the span has an error status or records an exception.
+func hasException(span ptrace.Span) bool {
+	if span.Status().Code() == ptrace.StatusCodeError {
+		return true
+	}
+	for i := 0; i < span.Events().Len(); i++ {
+		if span.Events().At(i).Name() == exceptionEventName {
+			return true
+		}
+	}
+	return false
+}
+
+// appendLogRecords appends a log record for each exception event of the span. If the span
+// has an error status but no exception event, a single log record built from its status
+// is appended instead.
+func appendLogRecords(span ptrace.Span, records plog.LogRecordSlice) {
+	var exceptionCount int
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		if event.Name() != exceptionEventName {
+			continue
+		}
+		exceptionCount++
+
+		record := newLogRecord(span, records)
+		record.SetTimestamp(event.Timestamp())
+		event.Attributes().CopyTo(record.Attributes())
+		setSpanAttributes(span, record)
+		if message, ok := event.Attributes().Get(conventions.AttributeExceptionMessage); ok {
+			record.Body().SetStr(message.AsString())
+		} else if exceptionType, ok := event.Attributes().Get(conventions.AttributeExceptionType); ok {
+			record.Body().SetStr(exceptionType.AsString())
+		}
+	}
+
+	if exceptionCount == 0 && span.Status().Code() == ptrace.StatusCodeError {
+		record := newLogRecord(span, records)
+		record.SetTimestamp(span.EndTimestamp())
+		setSpanAttributes(span, record)
+		if message := span.Status().Message(); message != "" {
+			record.Body().SetStr(message)
+		}
+	}
+}
+
+func newLogRecord(span ptrace.Span, records plog.LogRecordSlice) plog.LogRecord {
+	record := records.AppendEmpty()
+	record.SetTraceID(span.TraceID())
+	record.SetSpanID(span.SpanID())
+	record.SetSeverityNumber(plog.SeverityNumberError)
+	record.SetSeverityText("ERROR")
+	return record
+}
+
+func setSpanAttributes(span ptrace.Span, record plog.LogRecord) {
+	record.Attributes().PutStr(spanNameKey, span.Name())
+	record.Attributes().PutStr(spanKindKey, traceutil.SpanKindStr(span.Kind()))
+}
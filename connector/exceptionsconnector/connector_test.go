@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+)
+
+// The test input file has the following structure:
+// - The checkout resource has four spans:
+//   - an error span recording an exception, along with another event
+//   - an error span recording no exception
+//   - a span recording two exceptions, without an error status
+//   - a span with an ok status and no events
+//
+// - The frontend resource has a single span, with no error and no events.
+// - The cart resource has a single error span recording no exception, with an error status code.
+func TestTracesToLogs(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  *Config
+	}{
+		{
+			name: "all_spans",
+			cfg: &Config{
+				ErrorMode: ottl.PropagateError,
+			},
+		},
+		{
+			name: "one_condition",
+			cfg: &Config{
+				Conditions: []string{`resource.attributes["service.name"] == "checkout"`},
+				ErrorMode:  ottl.PropagateError,
+			},
+		},
+		{
+			name: "multiple_conditions",
+			cfg: &Config{
+				Conditions: []string{
+					`kind == SPAN_KIND_CLIENT`,
+					`attributes["http.status_code"] == 503`,
+				},
+				ErrorMode: ottl.PropagateError,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.cfg.Validate())
+			factory := NewFactory()
+			sink := &consumertest.LogsSink{}
+			conn, err := factory.CreateTracesToLogs(context.Background(),
+				connectortest.NewNopCreateSettings(), tc.cfg, sink)
+			require.NoError(t, err)
+			require.NotNil(t, conn)
+			assert.False(t, conn.Capabilities().MutatesData)
+
+			require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				assert.NoError(t, conn.Shutdown(context.Background()))
+			}()
+
+			testSpans, err := golden.ReadTraces(filepath.Join("testdata", "traces", "input.yaml"))
+			assert.NoError(t, err)
+			assert.NoError(t, conn.ConsumeTraces(context.Background(), testSpans))
+
+			allLogs := sink.AllLogs()
+			assert.Equal(t, 1, len(allLogs))
+
+			// golden.WriteLogs(t, filepath.Join("testdata", "traces", tc.name+".yaml"), allLogs[0])
+			expected, err := golden.ReadLogs(filepath.Join("testdata", "traces", tc.name+".yaml"))
+			assert.NoError(t, err)
+			assert.NoError(t, plogtest.CompareLogs(expected, allLogs[0]))
+		})
+	}
+}
+
+func TestNoExceptions(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	conn, err := NewFactory().CreateTracesToLogs(context.Background(),
+		connectortest.NewNopCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("no-exception")
+	span.Events().AppendEmpty().SetName("not-an-exception")
+	span.Status().SetCode(ptrace.StatusCodeOk)
+
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), traces))
+	assert.Empty(t, sink.AllLogs())
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	delta      = "AGGREGATION_TEMPORALITY_DELTA"
+	cumulative = "AGGREGATION_TEMPORALITY_CUMULATIVE"
+)
+
+// The types of the metrics built by the rules.
+const (
+	metricTypeCounter   = "counter"
+	metricTypeGauge     = "gauge"
+	metricTypeHistogram = "histogram"
+)
+
+// defaultHistogramBuckets are the bucket boundaries of the histogram rules without buckets, suited to
+// durations in milliseconds such as those of access logs.
+var defaultHistogramBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// Config defines the configuration options for logmetricsconnector.
+type Config struct {
+	// Rules defines the metrics extracted from the log records.
+	Rules []Rule `mapstructure:"rules"`
+
+	// AggregationTemporality is the aggregation temporality of the counters and histograms, either
+	// AGGREGATION_TEMPORALITY_CUMULATIVE (default) or AGGREGATION_TEMPORALITY_DELTA.
+	AggregationTemporality string `mapstructure:"aggregation_temporality"`
+
+	// MetricsFlushInterval is the time period between when the aggregated metrics are emitted.
+	MetricsFlushInterval time.Duration `mapstructure:"metrics_flush_interval"`
+
+	// ErrorMode determines how errors returned by the OTTL conditions and expressions are handled.
+	// With "propagate", the batch of logs is rejected; with "ignore", the log record is skipped.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+
+	// AggregationCardinalityLimit caps the number of attribute sets recorded by each rule for a resource.
+	// Once a rule has that many series, the log records with new attributes are all recorded in the
+	// series whose only attribute is otel.metric.overflow. Zero disables the cap.
+	AggregationCardinalityLimit int `mapstructure:"aggregation_cardinality_limit"`
+
+	// MetricsExpiration is the time after which a series that hasn't been updated is no longer emitted
+	// and its state is dropped, with the cumulative temporality. Zero means that series never expire.
+	MetricsExpiration time.Duration `mapstructure:"metrics_expiration"`
+}
+
+// Rule defines a metric built from the log records matching its conditions.
+type Rule struct {
+	// Name is the name of the metric.
+	Name string `mapstructure:"name"`
+	// Description is the description of the metric.
+	Description string `mapstructure:"description"`
+	// Unit is the unit of the metric.
+	Unit string `mapstructure:"unit"`
+	// Type is the type of the metric: "counter", "gauge" or "histogram".
+	Type string `mapstructure:"type"`
+	// Conditions are the OTTL conditions a log record must match to be recorded. The record is
+	// recorded if any of the conditions match. If no condition is set, all log records are recorded.
+	Conditions []string `mapstructure:"conditions"`
+	// Value is the OTTL expression computing the numeric value recorded for each log record, such as
+	// `attributes["http.duration_ms"]`. Log records for which it evaluates to nil are not recorded.
+	// It is required, except for counters, which count the log records if it is not set.
+	Value string `mapstructure:"value"`
+	// Attributes are the attributes of the data points, computed by OTTL expressions.
+	Attributes []AttributeRule `mapstructure:"attributes"`
+	// Buckets are the explicit bucket boundaries of a histogram rule, in increasing order.
+	// Defaults to defaultHistogramBuckets.
+	Buckets []float64 `mapstructure:"buckets"`
+}
+
+// AttributeRule defines a data point attribute computed by an OTTL expression.
+type AttributeRule struct {
+	// Key is the name of the attribute.
+	Key string `mapstructure:"key"`
+	// Value is the OTTL expression computing the value of the attribute, such as `attributes["http.route"]`.
+	// If it evaluates to nil, the attribute is omitted.
+	Value string `mapstructure:"value"`
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid
+func (c *Config) Validate() error {
+	if len(c.Rules) == 0 {
+		return errors.New("at least one rule must be configured")
+	}
+	if c.AggregationTemporality != delta && c.AggregationTemporality != cumulative {
+		return fmt.Errorf("invalid aggregation_temporality %q, must be %q or %q", c.AggregationTemporality, cumulative, delta)
+	}
+	if c.MetricsFlushInterval <= 0 {
+		return errors.New("metrics_flush_interval must be positive")
+	}
+	if c.AggregationCardinalityLimit < 0 {
+		return errors.New("aggregation_cardinality_limit must not be negative")
+	}
+	if c.MetricsExpiration < 0 {
+		return errors.New("metrics_expiration must not be negative")
+	}
+
+	names := make(map[string]struct{}, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d: metric name missing", i)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("duplicate rule for metric %q", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rule for metric %q: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) validate() error {
+	switch r.Type {
+	case metricTypeCounter:
+	case metricTypeGauge, metricTypeHistogram:
+		if r.Value == "" {
+			return fmt.Errorf("type %q requires a value", r.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type %q, must be one of %q, %q or %q", r.Type, metricTypeCounter, metricTypeGauge, metricTypeHistogram)
+	}
+
+	if len(r.Buckets) > 0 {
+		if r.Type != metricTypeHistogram {
+			return fmt.Errorf("buckets are only supported by type %q", metricTypeHistogram)
+		}
+		for i := 1; i < len(r.Buckets); i++ {
+			if r.Buckets[i] <= r.Buckets[i-1] {
+				return errors.New("buckets must be in increasing order")
+			}
+		}
+	}
+
+	keys := make(map[string]struct{}, len(r.Attributes))
+	for _, attr := range r.Attributes {
+		if attr.Key == "" {
+			return errors.New("attribute key missing")
+		}
+		if attr.Value == "" {
+			return fmt.Errorf("attribute %q: value missing", attr.Key)
+		}
+		if _, ok := keys[attr.Key]; ok {
+			return fmt.Errorf("duplicate attribute %q", attr.Key)
+		}
+		keys[attr.Key] = struct{}{}
+	}
+
+	_, err := newRule(*r, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+	return err
+}
+
+// histogramBuckets returns the bucket boundaries of a histogram.
+func (r *Rule) histogramBuckets() []float64 {
+	if r.Type != metricTypeHistogram {
+		return nil
+	}
+	if len(r.Buckets) > 0 {
+		return r.Buckets
+	}
+	return defaultHistogramBuckets
+}
+
+// GetAggregationTemporality converts the string value given in the config into a AggregationTemporality.
+// Returns cumulative, unless delta is correctly specified.
+func (c *Config) GetAggregationTemporality() pmetric.AggregationTemporality {
+	if c.AggregationTemporality == delta {
+		return pmetric.AggregationTemporalityDelta
+	}
+	return pmetric.AggregationTemporalityCumulative
+}
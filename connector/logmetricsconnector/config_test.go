@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				AggregationTemporality:      cumulative,
+				MetricsFlushInterval:        15 * time.Second,
+				ErrorMode:                   ottl.PropagateError,
+				AggregationCardinalityLimit: 1000,
+				MetricsExpiration:           5 * time.Minute,
+				Rules:                       []Rule{{Name: "http.server.requests", Type: metricTypeCounter}},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				AggregationTemporality:      delta,
+				MetricsFlushInterval:        30 * time.Second,
+				ErrorMode:                   ottl.IgnoreError,
+				AggregationCardinalityLimit: 100,
+				MetricsExpiration:           time.Minute,
+				Rules: []Rule{
+					{
+						Name:        "http.server.duration",
+						Description: "The duration of the HTTP requests.",
+						Unit:        "ms",
+						Type:        metricTypeHistogram,
+						Conditions:  []string{`attributes["http.route"] != nil`},
+						Value:       `attributes["http.duration_ms"]`,
+						Attributes: []AttributeRule{
+							{Key: "http.route", Value: `attributes["http.route"]`},
+							{Key: "http.status_code", Value: `attributes["http.status_code"]`},
+						},
+						Buckets: []float64{10, 100, 1000},
+					},
+					{
+						Name:  "http.server.response.size",
+						Unit:  "By",
+						Type:  metricTypeCounter,
+						Value: `attributes["http.response.body.size"]`,
+					},
+					{
+						Name:  "queue.size",
+						Type:  metricTypeGauge,
+						Value: `Int(attributes["queue.size"])`,
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_rules"),
+			errorMessage: "at least one rule must be configured",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_type"),
+			errorMessage: `rule for metric "http.server.requests": unknown type "summary"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "gauge_without_value"),
+			errorMessage: `rule for metric "queue.size": type "gauge" requires a value`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_value"),
+			errorMessage: `rule for metric "http.server.duration": value: unable to parse OTTL statement`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_condition"),
+			errorMessage: `rule for metric "http.server.requests": conditions: unable to parse OTTL statement`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "duplicate_rule"),
+			errorMessage: `duplicate rule for metric "http.server.requests"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "duplicate_attribute"),
+			errorMessage: `rule for metric "http.server.requests": duplicate attribute "http.route"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unsorted_buckets"),
+			errorMessage: `rule for metric "http.server.duration": buckets must be in increasing order`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_cardinality_limit"),
+			errorMessage: "aggregation_cardinality_limit must not be negative",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_expiration"),
+			errorMessage: "metrics_expiration must not be negative",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_temporality"),
+			errorMessage: `invalid aggregation_temporality "AGGREGATION_TEMPORALITY_UNSPECIFIED"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			err = component.UnmarshalConfig(sub, cfg)
+
+			if tt.expected == nil {
+				err = multierr.Append(err, component.ValidateConfig(cfg))
+				assert.ErrorContains(t, err, tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestGetAggregationTemporality(t *testing.T) {
+	cfg := &Config{AggregationTemporality: delta}
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, cfg.GetAggregationTemporality())
+
+	cfg = &Config{AggregationTemporality: cumulative}
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, cfg.GetAggregationTemporality())
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tilinna/clock"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const scopeName = "otelcol/logmetricsconnector"
+
+// connectorImp aggregates the values extracted from log records by the rules,
+// and emits them as metrics at every flush interval.
+type connectorImp struct {
+	logger          *zap.Logger
+	config          Config
+	rules           []*rule
+	metricsConsumer consumer.Metrics
+
+	lock sync.Mutex
+	// startTimestamp is the start of the current interval with the delta temporality.
+	startTimestamp  pcommon.Timestamp
+	resourceMetrics map[[16]byte]*resourceMetrics
+
+	ticker  *clock.Ticker
+	done    chan struct{}
+	started bool
+
+	shutdownOnce sync.Once
+}
+
+// observation is a value recorded by a rule for a log record.
+type observation struct {
+	resource pcommon.Resource
+	rule     *rule
+	attrs    pcommon.Map
+	value    float64
+}
+
+func newConnector(set component.TelemetrySettings, config component.Config, ticker *clock.Ticker) (*connectorImp, error) {
+	cfg := config.(*Config)
+
+	rules := make([]*rule, 0, len(cfg.Rules))
+	for _, r := range cfg.Rules {
+		parsed, err := newRule(r, cfg.ErrorMode, set)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, parsed)
+	}
+
+	return &connectorImp{
+		logger:          set.Logger,
+		config:          *cfg,
+		rules:           rules,
+		startTimestamp:  pcommon.NewTimestampFromTime(time.Now()),
+		resourceMetrics: make(map[[16]byte]*resourceMetrics),
+		ticker:          ticker,
+		done:            make(chan struct{}),
+	}, nil
+}
+
+// Start implements the component.Component interface.
+func (c *connectorImp) Start(ctx context.Context, _ component.Host) error {
+	c.started = true
+	go func() {
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-c.ticker.C:
+				c.exportMetrics(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements the component.Component interface.
+// The metrics aggregated since the last flush are emitted.
+func (c *connectorImp) Shutdown(ctx context.Context) error {
+	c.shutdownOnce.Do(func() {
+		if c.started {
+			c.ticker.Stop()
+			c.done <- struct{}{}
+			c.started = false
+			c.exportMetrics(ctx)
+		}
+	})
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (c *connectorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeLogs implements the consumer.Logs interface.
+// The values of the rules are computed for all the log records before being aggregated, so that
+// a batch of logs rejected because of an error is not partially aggregated.
+func (c *connectorImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var observations []observation
+	var errors error
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		resourceLogs := ld.ResourceLogs().At(i)
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLogs.ScopeLogs().At(j)
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				lCtx := ottllog.NewTransformContext(scopeLogs.LogRecords().At(k), scopeLogs.Scope(), resourceLogs.Resource())
+				for _, r := range c.rules {
+					value, attrs, ok, err := r.eval(ctx, lCtx)
+					if isNotNumeric(err) {
+						c.logger.Debug("Skipping log record with a non-numeric value", zap.String("metric", r.name), zap.Error(err))
+						continue
+					}
+					if err != nil {
+						if c.config.ErrorMode == ottl.PropagateError {
+							errors = multierr.Append(errors, err)
+						} else {
+							c.logger.Warn("failed to evaluate rule", zap.String("metric", r.name), zap.Error(err))
+						}
+						continue
+					}
+					if ok {
+						observations = append(observations, observation{
+							resource: resourceLogs.Resource(),
+							rule:     r,
+							attrs:    attrs,
+							value:    value,
+						})
+					}
+				}
+			}
+		}
+	}
+	if errors != nil {
+		return errors
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, o := range observations {
+		rm := c.getOrCreateResourceMetrics(o.resource)
+		start := now
+		if c.config.GetAggregationTemporality() == pmetric.AggregationTemporalityDelta {
+			start = c.startTimestamp
+		}
+		rm.record(o.rule, o.attrs, o.value, start, now, c.config.AggregationCardinalityLimit)
+	}
+	return nil
+}
+
+func (c *connectorImp) getOrCreateResourceMetrics(resource pcommon.Resource) *resourceMetrics {
+	key := pdatautil.MapHash(resource.Attributes())
+	rm, ok := c.resourceMetrics[key]
+	if !ok {
+		attributes := pcommon.NewMap()
+		resource.Attributes().CopyTo(attributes)
+		rm = newResourceMetrics(attributes)
+		c.resourceMetrics[key] = rm
+	}
+	return rm
+}
+
+func (c *connectorImp) exportMetrics(ctx context.Context) {
+	c.lock.Lock()
+	m := c.buildMetrics()
+	c.resetState()
+	// The metrics are no longer read once built, so it is safe to unlock.
+	c.lock.Unlock()
+
+	if m.ResourceMetrics().Len() == 0 {
+		return
+	}
+	if err := c.metricsConsumer.ConsumeMetrics(ctx, m); err != nil {
+		c.logger.Error("Failed ConsumeMetrics", zap.Error(err))
+	}
+}
+
+// buildMetrics builds the metrics aggregated by resource.
+func (c *connectorImp) buildMetrics() pmetric.Metrics {
+	m := pmetric.NewMetrics()
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	for _, rawMetrics := range c.resourceMetrics {
+		rm := m.ResourceMetrics().AppendEmpty()
+		rawMetrics.attributes.CopyTo(rm.Resource().Attributes())
+
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+		rawMetrics.appendMetricsTo(sm.Metrics(), c.rules, timestamp, c.config.GetAggregationTemporality())
+	}
+	return m
+}
+
+// resetState starts a new interval. With the delta temporality, the aggregated state is cleared;
+// with the cumulative temporality, the series are kept until they expire.
+func (c *connectorImp) resetState() {
+	now := time.Now()
+	if c.config.GetAggregationTemporality() == pmetric.AggregationTemporalityDelta {
+		c.resourceMetrics = make(map[[16]byte]*resourceMetrics)
+		c.startTimestamp = pcommon.NewTimestampFromTime(now)
+		return
+	}
+	if c.config.MetricsExpiration <= 0 {
+		return
+	}
+	since := pcommon.NewTimestampFromTime(now.Add(-c.config.MetricsExpiration))
+	for key, rm := range c.resourceMetrics {
+		if rm.removeIdle(since) {
+			delete(c.resourceMetrics, key)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tilinna/clock"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type accessLog struct {
+	service  string
+	route    string
+	status   int64
+	duration any
+}
+
+func buildAccessLogs(records ...accessLog) plog.Logs {
+	ld := plog.NewLogs()
+	resources := map[string]plog.LogRecordSlice{}
+	for _, r := range records {
+		logs, ok := resources[r.service]
+		if !ok {
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().PutStr("service.name", r.service)
+			logs = rl.ScopeLogs().AppendEmpty().LogRecords()
+			resources[r.service] = logs
+		}
+		logRecord := logs.AppendEmpty()
+		logRecord.Body().SetStr("access log")
+		if r.route != "" {
+			logRecord.Attributes().PutStr("http.route", r.route)
+		}
+		logRecord.Attributes().PutInt("http.status_code", r.status)
+		if r.duration != nil {
+			_ = logRecord.Attributes().PutEmpty("http.duration_ms").FromRaw(r.duration)
+		}
+	}
+	return ld
+}
+
+func accessLogsConfig(temporality string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AggregationTemporality = temporality
+	cfg.Rules = []Rule{
+		{
+			Name:       "http.server.duration",
+			Unit:       "ms",
+			Type:       metricTypeHistogram,
+			Conditions: []string{`attributes["http.route"] != nil`},
+			Value:      `attributes["http.duration_ms"]`,
+			Attributes: []AttributeRule{{Key: "http.route", Value: `attributes["http.route"]`}},
+			Buckets:    []float64{10, 100},
+		},
+		{
+			Name:       "http.server.errors",
+			Type:       metricTypeCounter,
+			Conditions: []string{`attributes["http.status_code"] >= 500`},
+			Attributes: []AttributeRule{{Key: "http.status_code", Value: `attributes["http.status_code"]`}},
+		},
+		{
+			Name:  "http.server.last_duration",
+			Type:  metricTypeGauge,
+			Value: `attributes["http.duration_ms"]`,
+		},
+	}
+	return cfg
+}
+
+func newTestConnector(t *testing.T, cfg *Config) *connectorImp {
+	require.NoError(t, cfg.Validate())
+	set := componenttest.NewNopTelemetrySettings()
+	set.Logger = zaptest.NewLogger(t)
+	c, err := newConnector(set, cfg, nil)
+	require.NoError(t, err)
+	return c
+}
+
+// metricsByName returns the metrics of the resource with the given service name.
+func metricsByName(t *testing.T, md pmetric.Metrics, service string) map[string]pmetric.Metric {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		name, _ := rm.Resource().Attributes().Get("service.name")
+		if name.Str() != service {
+			continue
+		}
+		require.Equal(t, 1, rm.ScopeMetrics().Len())
+		assert.Equal(t, scopeName, rm.ScopeMetrics().At(0).Scope().Name())
+		metrics := map[string]pmetric.Metric{}
+		for j := 0; j < rm.ScopeMetrics().At(0).Metrics().Len(); j++ {
+			metric := rm.ScopeMetrics().At(0).Metrics().At(j)
+			metrics[metric.Name()] = metric
+		}
+		return metrics
+	}
+	t.Fatalf("no metrics for service %q", service)
+	return nil
+}
+
+func histogramByRoute(metric pmetric.Metric) map[string]pmetric.HistogramDataPoint {
+	dps := map[string]pmetric.HistogramDataPoint{}
+	for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+		dp := metric.Histogram().DataPoints().At(i)
+		route, _ := dp.Attributes().Get("http.route")
+		dps[route.Str()] = dp
+	}
+	return dps
+}
+
+func TestConsumeLogs(t *testing.T) {
+	c := newTestConnector(t, accessLogsConfig(delta))
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), buildAccessLogs(
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: int64(5)},
+		accessLog{service: "checkout", route: "/cart", status: 500, duration: 50.5},
+		accessLog{service: "checkout", route: "/pay", status: 503, duration: int64(500)},
+		// Not recorded by the histogram, which requires a route.
+		accessLog{service: "checkout", status: 500, duration: int64(1)},
+		accessLog{service: "catalog", route: "/items", status: 200, duration: int64(20)},
+		// Not recorded by the histogram and the gauge, for lack of a value.
+		accessLog{service: "catalog", route: "/items", status: 200},
+	)))
+
+	md := c.buildMetrics()
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	checkout := metricsByName(t, md, "checkout")
+	require.Len(t, checkout, 3)
+
+	duration := checkout["http.server.duration"]
+	assert.Equal(t, "ms", duration.Unit())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, duration.Histogram().AggregationTemporality())
+	dps := histogramByRoute(duration)
+	require.Len(t, dps, 2)
+	assert.Equal(t, []float64{10, 100}, dps["/cart"].ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 1, 0}, dps["/cart"].BucketCounts().AsRaw())
+	assert.Equal(t, uint64(2), dps["/cart"].Count())
+	assert.Equal(t, 55.5, dps["/cart"].Sum())
+	assert.Equal(t, 5.0, dps["/cart"].Min())
+	assert.Equal(t, 50.5, dps["/cart"].Max())
+	assert.Equal(t, []uint64{0, 0, 1}, dps["/pay"].BucketCounts().AsRaw())
+
+	errors := checkout["http.server.errors"]
+	assert.True(t, errors.Sum().IsMonotonic())
+	errorCounts := map[int64]int64{}
+	for i := 0; i < errors.Sum().DataPoints().Len(); i++ {
+		dp := errors.Sum().DataPoints().At(i)
+		status, _ := dp.Attributes().Get("http.status_code")
+		errorCounts[status.Int()] = dp.IntValue()
+	}
+	assert.Equal(t, map[int64]int64{500: 2, 503: 1}, errorCounts)
+
+	lastDuration := checkout["http.server.last_duration"]
+	require.Equal(t, 1, lastDuration.Gauge().DataPoints().Len())
+	assert.Equal(t, 1.0, lastDuration.Gauge().DataPoints().At(0).DoubleValue())
+
+	// Metrics without series are not emitted.
+	catalog := metricsByName(t, md, "catalog")
+	assert.Len(t, catalog, 2)
+	assert.NotContains(t, catalog, "http.server.errors")
+}
+
+func TestAggregationTemporality(t *testing.T) {
+	logs := buildAccessLogs(accessLog{service: "checkout", route: "/cart", status: 500, duration: int64(5)})
+
+	t.Run("delta", func(t *testing.T) {
+		c := newTestConnector(t, accessLogsConfig(delta))
+		require.NoError(t, c.ConsumeLogs(context.Background(), logs))
+		first := c.buildMetrics()
+		c.resetState()
+		assert.Equal(t, 0, c.buildMetrics().ResourceMetrics().Len())
+
+		require.NoError(t, c.ConsumeLogs(context.Background(), logs))
+		second := c.buildMetrics()
+		firstDP := metricsByName(t, first, "checkout")["http.server.errors"].Sum().DataPoints().At(0)
+		secondDP := metricsByName(t, second, "checkout")["http.server.errors"].Sum().DataPoints().At(0)
+		assert.Equal(t, int64(1), secondDP.IntValue())
+		// Each interval starts when the previous one ends.
+		assert.Less(t, firstDP.StartTimestamp(), secondDP.StartTimestamp())
+	})
+
+	t.Run("cumulative", func(t *testing.T) {
+		c := newTestConnector(t, accessLogsConfig(cumulative))
+		require.NoError(t, c.ConsumeLogs(context.Background(), logs))
+		first := c.buildMetrics()
+		c.resetState()
+
+		require.NoError(t, c.ConsumeLogs(context.Background(), logs))
+		second := c.buildMetrics()
+		firstDP := metricsByName(t, first, "checkout")["http.server.duration"].Histogram().DataPoints().At(0)
+		secondDP := metricsByName(t, second, "checkout")["http.server.duration"].Histogram().DataPoints().At(0)
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, metricsByName(t, second, "checkout")["http.server.duration"].Histogram().AggregationTemporality())
+		assert.Equal(t, uint64(2), secondDP.Count())
+		assert.Equal(t, firstDP.StartTimestamp(), secondDP.StartTimestamp())
+	})
+}
+
+func TestAggregationCardinalityLimit(t *testing.T) {
+	cfg := accessLogsConfig(cumulative)
+	cfg.AggregationCardinalityLimit = 2
+	c := newTestConnector(t, cfg)
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), buildAccessLogs(
+		accessLog{service: "checkout", route: "/a", status: 200, duration: int64(1)},
+		accessLog{service: "checkout", route: "/b", status: 200, duration: int64(1)},
+		accessLog{service: "checkout", route: "/c", status: 200, duration: int64(1)},
+		accessLog{service: "checkout", route: "/d", status: 200, duration: int64(1)},
+		// Existing series keep being updated.
+		accessLog{service: "checkout", route: "/a", status: 200, duration: int64(1)},
+	)))
+
+	duration := metricsByName(t, c.buildMetrics(), "checkout")["http.server.duration"]
+	counts := map[string]uint64{}
+	for i := 0; i < duration.Histogram().DataPoints().Len(); i++ {
+		dp := duration.Histogram().DataPoints().At(i)
+		if overflow, ok := dp.Attributes().Get(overflowKey); ok {
+			assert.True(t, overflow.Bool())
+			counts[overflowKey] = dp.Count()
+			continue
+		}
+		route, _ := dp.Attributes().Get("http.route")
+		counts[route.Str()] = dp.Count()
+	}
+	assert.Equal(t, map[string]uint64{"/a": 2, "/b": 1, overflowKey: 2}, counts)
+}
+
+func TestMetricsExpiration(t *testing.T) {
+	cfg := accessLogsConfig(cumulative)
+	cfg.MetricsExpiration = time.Hour
+	c := newTestConnector(t, cfg)
+
+	require.NoError(t, c.ConsumeLogs(context.Background(), buildAccessLogs(
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: int64(1)},
+		accessLog{service: "catalog", route: "/items", status: 200, duration: int64(1)},
+	)))
+	c.resetState()
+	assert.Equal(t, 2, c.buildMetrics().ResourceMetrics().Len())
+
+	// The series of catalog are no longer updated and expire, along with their resource.
+	c.config.MetricsExpiration = 10 * time.Millisecond
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, c.ConsumeLogs(context.Background(), buildAccessLogs(
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: int64(5)},
+	)))
+	c.resetState()
+
+	md := c.buildMetrics()
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	checkout := metricsByName(t, md, "checkout")
+	assert.Equal(t, uint64(2), histogramByRoute(checkout["http.server.duration"])["/cart"].Count())
+	assert.Equal(t, 5.0, checkout["http.server.last_duration"].Gauge().DataPoints().At(0).DoubleValue())
+
+	time.Sleep(20 * time.Millisecond)
+	c.resetState()
+	assert.Equal(t, 0, c.buildMetrics().ResourceMetrics().Len())
+}
+
+func TestErrorMode(t *testing.T) {
+	logs := buildAccessLogs(
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: int64(5)},
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: "12.5"},
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: int64(-1)},
+		// non-numeric values are skipped in both modes
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: "slow"},
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: "NaN"},
+		accessLog{service: "checkout", route: "/cart", status: 200, duration: math.Inf(1)},
+	)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Rules = []Rule{
+		{
+			Name:  "http.server.total_duration",
+			Type:  metricTypeCounter,
+			Value: `attributes["http.duration_ms"]`,
+		},
+	}
+
+	t.Run("propagate", func(t *testing.T) {
+		c := newTestConnector(t, cfg)
+		err := c.ConsumeLogs(context.Background(), logs)
+		assert.EqualError(t, err, "the value of a counter must not be negative")
+		// The batch is rejected as a whole.
+		assert.Equal(t, 0, c.buildMetrics().ResourceMetrics().Len())
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		ignoreCfg := *cfg
+		ignoreCfg.ErrorMode = ottl.IgnoreError
+		c := newTestConnector(t, &ignoreCfg)
+		require.NoError(t, c.ConsumeLogs(context.Background(), logs))
+		total := metricsByName(t, c.buildMetrics(), "checkout")["http.server.total_duration"]
+		require.Equal(t, 1, total.Sum().DataPoints().Len())
+		assert.Equal(t, 17.5, total.Sum().DataPoints().At(0).DoubleValue())
+	})
+}
+
+func TestFlushInterval(t *testing.T) {
+	mockClock := clock.NewMock(time.Now())
+	ctx := clock.Context(context.Background(), mockClock)
+
+	cfg := accessLogsConfig(delta)
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(ctx, connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(ctx, componenttest.NewNopHost()))
+
+	require.NoError(t, conn.ConsumeLogs(ctx, buildAccessLogs(accessLog{service: "checkout", route: "/cart", status: 200, duration: int64(5)})))
+	assert.Empty(t, sink.AllMetrics())
+
+	mockClock.Add(cfg.MetricsFlushInterval)
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	}, time.Second, time.Millisecond)
+
+	// Nothing is emitted for an interval without logs.
+	mockClock.Add(cfg.MetricsFlushInterval)
+
+	// The metrics aggregated since the last flush are emitted on shutdown.
+	require.NoError(t, conn.ConsumeLogs(ctx, buildAccessLogs(accessLog{service: "checkout", route: "/cart", status: 500, duration: int64(50)})))
+	require.NoError(t, conn.Shutdown(ctx))
+	require.Len(t, sink.AllMetrics(), 2)
+	errors := metricsByName(t, sink.AllMetrics()[1], "checkout")["http.server.errors"]
+	assert.Equal(t, int64(1), errors.Sum().DataPoints().At(0).IntValue())
+}
+
+func TestCapabilities(t *testing.T) {
+	c := newTestConnector(t, accessLogsConfig(delta))
+	assert.False(t, c.Capabilities().MutatesData)
+}
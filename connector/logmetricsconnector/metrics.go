@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const overflowKey = "otel.metric.overflow"
+
+var noAttributes = [16]byte{}
+
+// overflowSeriesKey is the key of the series recording the log records above the cardinality
+// limit. It can't collide with the hash of attributes.
+var overflowSeriesKey = [16]byte{0xff}
+
+// resourceMetrics holds the series of the metrics built from the log records of a resource.
+type resourceMetrics struct {
+	attributes pcommon.Map
+	// series are keyed by metric name and by the hash of their attributes.
+	series map[string]map[[16]byte]*series
+}
+
+// series is the aggregated state of a data point.
+type series struct {
+	attributes pcommon.Map
+	start      pcommon.Timestamp
+	// updated is the time of the last record, used to expire the idle series.
+	updated pcommon.Timestamp
+
+	// The aggregated values of a counter or a histogram.
+	count uint64
+	sum   float64
+
+	// The aggregated values of a histogram.
+	bucketCounts []uint64
+	min          float64
+	max          float64
+
+	// last is the last value of a gauge.
+	last float64
+}
+
+func newResourceMetrics(attributes pcommon.Map) *resourceMetrics {
+	return &resourceMetrics{
+		attributes: attributes,
+		series:     make(map[string]map[[16]byte]*series),
+	}
+}
+
+// getOrCreate returns the series of the metric with the given attributes. Once the metric has
+// limit series, log records that would create a new series are recorded in the overflow series.
+func (rm *resourceMetrics) getOrCreate(metricName string, attrs pcommon.Map, start pcommon.Timestamp, limit int) *series {
+	if _, ok := rm.series[metricName]; !ok {
+		rm.series[metricName] = make(map[[16]byte]*series)
+	}
+
+	key := noAttributes
+	if attrs.Len() > 0 {
+		key = pdatautil.MapHash(attrs)
+	}
+
+	s, ok := rm.series[metricName][key]
+	if ok {
+		return s
+	}
+	if limit > 0 && len(rm.series[metricName]) >= limit {
+		if s, ok = rm.series[metricName][overflowSeriesKey]; ok {
+			return s
+		}
+		key, attrs = overflowSeriesKey, pcommon.NewMap()
+		attrs.PutBool(overflowKey, true)
+	}
+	s = &series{attributes: attrs, start: start}
+	rm.series[metricName][key] = s
+	return s
+}
+
+// record adds the value to the series of the rule's metric with the given attributes.
+func (rm *resourceMetrics) record(r *rule, attrs pcommon.Map, value float64, start, now pcommon.Timestamp, limit int) {
+	s := rm.getOrCreate(r.name, attrs, start, limit)
+	s.updated = now
+	switch r.metricType {
+	case metricTypeCounter:
+		s.count++
+		s.sum += value
+	case metricTypeGauge:
+		s.last = value
+	case metricTypeHistogram:
+		if s.bucketCounts == nil {
+			s.bucketCounts = make([]uint64, len(r.buckets)+1)
+			s.min, s.max = value, value
+		}
+		s.bucketCounts[sort.SearchFloat64s(r.buckets, value)]++
+		s.count++
+		s.sum += value
+		if value < s.min {
+			s.min = value
+		}
+		if value > s.max {
+			s.max = value
+		}
+	}
+}
+
+// removeIdle drops the series that haven't been updated since the given time.
+// It reports whether the resource has no series left.
+func (rm *resourceMetrics) removeIdle(since pcommon.Timestamp) bool {
+	for name, series := range rm.series {
+		for key, s := range series {
+			if s.updated < since {
+				delete(series, key)
+			}
+		}
+		if len(series) == 0 {
+			delete(rm.series, name)
+		}
+	}
+	return len(rm.series) == 0
+}
+
+// appendMetricsTo builds the metrics of the resource, in the order of the rules.
+func (rm *resourceMetrics) appendMetricsTo(metrics pmetric.MetricSlice, rules []*rule, timestamp pcommon.Timestamp, temporality pmetric.AggregationTemporality) {
+	for _, r := range rules {
+		series := rm.series[r.name]
+		if len(series) == 0 {
+			continue
+		}
+		metric := metrics.AppendEmpty()
+		metric.SetName(r.name)
+		metric.SetDescription(r.description)
+		metric.SetUnit(r.unit)
+		switch r.metricType {
+		case metricTypeCounter:
+			sum := metric.SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(temporality)
+			for _, s := range series {
+				dp := sum.DataPoints().AppendEmpty()
+				s.attributes.CopyTo(dp.Attributes())
+				dp.SetStartTimestamp(s.start)
+				dp.SetTimestamp(timestamp)
+				if r.value == nil {
+					dp.SetIntValue(int64(s.count))
+				} else {
+					dp.SetDoubleValue(s.sum)
+				}
+			}
+		case metricTypeGauge:
+			gauge := metric.SetEmptyGauge()
+			for _, s := range series {
+				dp := gauge.DataPoints().AppendEmpty()
+				s.attributes.CopyTo(dp.Attributes())
+				dp.SetTimestamp(timestamp)
+				dp.SetDoubleValue(s.last)
+			}
+		case metricTypeHistogram:
+			histogram := metric.SetEmptyHistogram()
+			histogram.SetAggregationTemporality(temporality)
+			for _, s := range series {
+				dp := histogram.DataPoints().AppendEmpty()
+				s.attributes.CopyTo(dp.Attributes())
+				dp.SetStartTimestamp(s.start)
+				dp.SetTimestamp(timestamp)
+				dp.ExplicitBounds().FromRaw(r.buckets)
+				dp.BucketCounts().FromRaw(s.bucketCounts)
+				dp.SetCount(s.count)
+				dp.SetSum(s.sum)
+				dp.SetMin(s.min)
+				dp.SetMax(s.max)
+			}
+		}
+	}
+}
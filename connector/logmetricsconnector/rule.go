@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlexpr"
+)
+
+// rule is a Rule with its parsed OTTL conditions and expressions.
+type rule struct {
+	name        string
+	description string
+	unit        string
+	metricType  string
+	buckets     []float64
+
+	// condition is nil if the rule matches all log records.
+	condition expr.BoolExpr[ottllog.TransformContext]
+	// value is nil if the rule counts the log records.
+	value      *ottl.Statement[ottllog.TransformContext]
+	attributes []attributeRule
+}
+
+type attributeRule struct {
+	key   string
+	value *ottl.Statement[ottllog.TransformContext]
+}
+
+func newRule(r Rule, errorMode ottl.ErrorMode, set component.TelemetrySettings) (*rule, error) {
+	parsed := &rule{
+		name:        r.Name,
+		description: r.Description,
+		unit:        r.Unit,
+		metricType:  r.Type,
+		buckets:     r.histogramBuckets(),
+	}
+
+	var err error
+	if len(r.Conditions) > 0 {
+		if parsed.condition, err = filterottl.NewBoolExprForLog(r.Conditions, filterottl.StandardLogFuncs(), errorMode, set); err != nil {
+			return nil, fmt.Errorf("conditions: %w", err)
+		}
+	}
+
+	parser, err := ottllog.NewParser(ottlexpr.Functions[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	if r.Value != "" {
+		if parsed.value, err = ottlexpr.Parse(parser, r.Value); err != nil {
+			return nil, fmt.Errorf("value: %w", err)
+		}
+	}
+	for _, attr := range r.Attributes {
+		value, err := ottlexpr.Parse(parser, attr.Value)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", attr.Key, err)
+		}
+		parsed.attributes = append(parsed.attributes, attributeRule{key: attr.Key, value: value})
+	}
+	return parsed, nil
+}
+
+// eval returns the value and the attributes of the data point recorded for the log record.
+// The ok flag is false if the log record is not recorded by the rule.
+func (r *rule) eval(ctx context.Context, tCtx ottllog.TransformContext) (value float64, attrs pcommon.Map, ok bool, err error) {
+	if r.condition != nil {
+		match, err := r.condition.Eval(ctx, tCtx)
+		if err != nil || !match {
+			return 0, attrs, false, err
+		}
+	}
+
+	value = 1
+	if r.value != nil {
+		result, _, err := r.value.Execute(ctx, tCtx)
+		if err != nil {
+			return 0, attrs, false, fmt.Errorf("value: %w", err)
+		}
+		if value, ok, err = toFloat(result); err != nil || !ok {
+			return 0, attrs, false, err
+		}
+		if r.metricType == metricTypeCounter && value < 0 {
+			return 0, attrs, false, errors.New("the value of a counter must not be negative")
+		}
+	}
+
+	attrs = pcommon.NewMap()
+	attrs.EnsureCapacity(len(r.attributes))
+	for _, attr := range r.attributes {
+		result, _, err := attr.value.Execute(ctx, tCtx)
+		if err == nil && result != nil {
+			err = setValue(attrs, attr.key, result)
+		}
+		if err != nil {
+			return 0, attrs, false, fmt.Errorf("attribute %q: %w", attr.key, err)
+		}
+	}
+	return value, attrs, true, nil
+}
+
+// errNotNumeric is returned for the log records whose value is not a finite number. They are
+// skipped by the rule whatever the error mode, as such values are expected in heterogeneous logs.
+var errNotNumeric = errors.New("value is not numeric")
+
+func isNotNumeric(err error) bool {
+	return errors.Is(err, errNotNumeric)
+}
+
+// toFloat converts the value returned by an OTTL expression to a float64. Strings holding a
+// number are parsed, as numeric fields of logs are often recorded as strings.
+// The ok flag is false if the value is nil.
+func toFloat(value interface{}) (float64, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, false, nil
+	case int64:
+		return float64(v), true, nil
+	case float64:
+		return finite(v)
+	case string:
+		return parseNumber(v)
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeInt:
+			return float64(v.Int()), true, nil
+		case pcommon.ValueTypeDouble:
+			return finite(v.Double())
+		case pcommon.ValueTypeStr:
+			return parseNumber(v.Str())
+		case pcommon.ValueTypeEmpty:
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("%w: value of type %s", errNotNumeric, v.Type())
+	}
+	return 0, false, fmt.Errorf("%w: value of type %T", errNotNumeric, value)
+}
+
+func parseNumber(s string) (float64, bool, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%w: %q", errNotNumeric, s)
+	}
+	return finite(value)
+}
+
+// finite rejects the NaN and infinite values, which would poison the series they are recorded in.
+func finite(value float64) (float64, bool, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false, fmt.Errorf("%w: %v", errNotNumeric, value)
+	}
+	return value, true, nil
+}
+
+// setValue puts the value returned by an OTTL expression into the map.
+func setValue(m pcommon.Map, key string, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		m.PutStr(key, v)
+	case bool:
+		m.PutBool(key, v)
+	case int64:
+		m.PutInt(key, v)
+	case float64:
+		m.PutDouble(key, v)
+	case []byte:
+		m.PutEmptyBytes(key).FromRaw(v)
+	case pcommon.Map:
+		v.CopyTo(m.PutEmptyMap(key))
+	case pcommon.Slice:
+		v.CopyTo(m.PutEmptySlice(key))
+	case pcommon.Value:
+		v.CopyTo(m.PutEmpty(key))
+	default:
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+	return nil
+}
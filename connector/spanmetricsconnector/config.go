@@ -54,6 +54,29 @@ type Config struct {
 
 	// Namespace is the namespace of the metrics emitted by the connector.
 	Namespace string `mapstructure:"namespace"`
+
+	// AdjustedCountAttribute is the optional name of a numeric span attribute, such as the one set by the
+	// probabilistic sampler, that holds the number of spans represented by each span.
+	// If set, the calls metric is incremented by its value instead of by one, and the sums are emitted as doubles.
+	AdjustedCountAttribute string `mapstructure:"adjusted_count_attribute"`
+
+	// AggregationCardinalityLimit is the maximum number of distinct series of each metric per resource.
+	// Spans that would create a series above the limit are recorded in a single overflow series,
+	// with the otel.metric.overflow attribute set to true. Zero means no limit.
+	AggregationCardinalityLimit int `mapstructure:"aggregation_cardinality_limit"`
+
+	// Events configures the metric counting span events.
+	Events EventsConfig `mapstructure:"events"`
+}
+
+// EventsConfig defines the configuration of the events metric.
+type EventsConfig struct {
+	// Enabled turns on the events metric, counting the span events by the span dimensions and the event name.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Dimensions defines the list of additional dimensions of the events metric. They are fetched
+	// from the event's attributes, falling back to the span's attributes.
+	Dimensions []Dimension `mapstructure:"dimensions"`
 }
 
 type HistogramConfig struct {
@@ -90,6 +113,19 @@ func (c Config) Validate() error {
 	if c.Histogram.Explicit != nil && c.Histogram.Exponential != nil {
 		return errors.New("use either `explicit` or `exponential` buckets histogram")
 	}
+
+	if c.AggregationCardinalityLimit < 0 {
+		return fmt.Errorf(
+			"invalid aggregation_cardinality_limit: %v, the limit should be positive or zero",
+			c.AggregationCardinalityLimit,
+		)
+	}
+
+	if c.Events.Enabled {
+		if err := validateEventDimensions(c.Dimensions, c.Events.Dimensions); err != nil {
+			return fmt.Errorf("failed validating events dimensions: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -118,3 +154,9 @@ func validateDimensions(dimensions []Dimension) error {
 
 	return nil
 }
+
+// validateEventDimensions checks duplicates for the dimensions of the events metric, which also
+// has the reserved dimensions, the span dimensions and the event name.
+func validateEventDimensions(dimensions []Dimension, eventDimensions []Dimension) error {
+	return validateDimensions(append(append([]Dimension{{Name: eventNameKey}}, dimensions...), eventDimensions...))
+}
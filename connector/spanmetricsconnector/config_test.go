@@ -47,8 +47,14 @@ func TestLoadConfig(t *testing.T) {
 					{Name: "http.method", Default: &defaultMethod},
 					{Name: "http.status_code", Default: (*string)(nil)},
 				},
-				DimensionsCacheSize:  1500,
-				MetricsFlushInterval: 30 * time.Second,
+				DimensionsCacheSize:         1500,
+				MetricsFlushInterval:        30 * time.Second,
+				AdjustedCountAttribute:      "sampling.adjusted_count",
+				AggregationCardinalityLimit: 500,
+				Events: EventsConfig{
+					Enabled:    true,
+					Dimensions: []Dimension{{Name: "exception.type"}},
+				},
 				Histogram: HistogramConfig{
 					Unit: metrics.Seconds,
 					Explicit: &ExplicitHistogramConfig{
@@ -83,6 +89,14 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "invalid_histogram_unit"),
 			errorMessage: "unknown Unit \"h\"",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_cardinality_limit"),
+			errorMessage: "invalid aggregation_cardinality_limit: -1, the limit should be positive or zero",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "duplicate_event_dimension"),
+			errorMessage: "failed validating events dimensions: duplicate dimension name http.method",
+		},
 	}
 
 	for _, tt := range tests {
@@ -105,6 +119,41 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestValidateEventDimensions(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		dimensions      []Dimension
+		eventDimensions []Dimension
+		expectedErr     string
+	}{
+		{
+			name:            "no duplicate dimensions",
+			dimensions:      []Dimension{{Name: "http.method"}},
+			eventDimensions: []Dimension{{Name: "exception.type"}},
+		},
+		{
+			name:            "duplicate event name",
+			eventDimensions: []Dimension{{Name: "event.name"}},
+			expectedErr:     "duplicate dimension name event.name",
+		},
+		{
+			name:            "duplicate span dimension",
+			dimensions:      []Dimension{{Name: "http.method"}},
+			eventDimensions: []Dimension{{Name: "http.method"}},
+			expectedErr:     "duplicate dimension name http.method",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEventDimensions(tc.dimensions, tc.eventDimensions)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestGetAggregationTemporality(t *testing.T) {
 	cfg := &Config{AggregationTemporality: delta}
 	assert.Equal(t, pmetric.AggregationTemporalityDelta, cfg.GetAggregationTemporality())
@@ -21,6 +21,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/adjustedcount"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
@@ -30,12 +31,15 @@ const (
 	spanNameKey        = "span.name"   // OpenTelemetry non-standard constant.
 	spanKindKey        = "span.kind"   // OpenTelemetry non-standard constant.
 	statusCodeKey      = "status.code" // OpenTelemetry non-standard constant.
+	eventNameKey       = "event.name"  // OpenTelemetry non-standard constant.
+	overflowKey        = "otel.metric.overflow"
 	metricKeySeparator = string(byte(0))
 
 	defaultDimensionsCacheSize = 1000
 
 	metricNameDuration = "duration"
 	metricNameCalls    = "calls"
+	metricNameEvents   = "events"
 
 	defaultUnit = metrics.Milliseconds
 )
@@ -50,6 +54,12 @@ type connectorImp struct {
 	// Additional dimensions to add to metrics.
 	dimensions []dimension
 
+	// Additional dimensions to add to the events metric.
+	eventDimensions []dimension
+
+	// The attributes of the series collecting the spans above the cardinality limit.
+	overflowAttributes pcommon.Map
+
 	// The starting time of the data points.
 	startTimestamp pcommon.Timestamp
 
@@ -61,6 +71,9 @@ type connectorImp struct {
 	// e.g. { "foo/barOK": { "serviceName": "foo", "span.name": "/bar", "status_code": "OK" }}
 	metricKeyToDimensions *cache.Cache[metrics.Key, pcommon.Map]
 
+	// An LRU cache of the dimensions of the events metric, keyed like metricKeyToDimensions.
+	eventKeyToDimensions *cache.Cache[metrics.Key, pcommon.Map]
+
 	ticker  *clock.Ticker
 	done    chan struct{}
 	started bool
@@ -71,6 +84,7 @@ type connectorImp struct {
 type resourceMetrics struct {
 	histograms metrics.HistogramMetrics
 	sums       metrics.SumMetrics
+	events     metrics.SumMetrics
 	attributes pcommon.Map
 }
 
@@ -103,14 +117,25 @@ func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Tic
 		return nil, err
 	}
 
+	eventKeyToDimensionsCache, err := cache.NewCache[metrics.Key, pcommon.Map](cfg.DimensionsCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	overflowAttributes := pcommon.NewMap()
+	overflowAttributes.PutBool(overflowKey, true)
+
 	return &connectorImp{
 		logger:                logger,
 		config:                *cfg,
 		startTimestamp:        pcommon.NewTimestampFromTime(time.Now()),
 		resourceMetrics:       make(map[resourceKey]*resourceMetrics),
 		dimensions:            newDimensions(cfg.Dimensions),
+		eventDimensions:       newDimensions(cfg.Events.Dimensions),
+		overflowAttributes:    overflowAttributes,
 		keyBuf:                bytes.NewBuffer(make([]byte, 0, 1024)),
 		metricKeyToDimensions: metricKeyToDimensionsCache,
+		eventKeyToDimensions:  eventKeyToDimensionsCache,
 		ticker:                ticker,
 		done:                  make(chan struct{}),
 	}, nil
@@ -241,6 +266,13 @@ func (p *connectorImp) buildMetrics() pmetric.Metrics {
 		metric.SetName(buildMetricName(p.config.Namespace, metricNameDuration))
 		metric.SetUnit(p.config.Histogram.Unit.String())
 		histograms.BuildMetrics(metric, p.startTimestamp, p.config.GetAggregationTemporality())
+
+		if p.config.Events.Enabled {
+			events := rawMetrics.events
+			metric = sm.Metrics().AppendEmpty()
+			metric.SetName(buildMetricName(p.config.Namespace, metricNameEvents))
+			events.BuildMetrics(metric, p.startTimestamp, p.config.GetAggregationTemporality())
+		}
 	}
 
 	return m
@@ -251,9 +283,11 @@ func (p *connectorImp) resetState() {
 	if p.config.GetAggregationTemporality() == pmetric.AggregationTemporalityDelta {
 		p.resourceMetrics = make(map[resourceKey]*resourceMetrics)
 		p.metricKeyToDimensions.Purge()
+		p.eventKeyToDimensions.Purge()
 		p.startTimestamp = pcommon.NewTimestampFromTime(time.Now())
 	} else {
 		p.metricKeyToDimensions.RemoveEvictedItems()
+		p.eventKeyToDimensions.RemoveEvictedItems()
 
 		// Exemplars are only relevant to this batch of traces, so must be cleared within the lock
 		for _, m := range p.resourceMetrics {
@@ -268,6 +302,9 @@ func (p *connectorImp) resetState() {
 // Each metric is identified by a key that is built from the service name
 // and span metadata such as name, kind, status_code and any additional
 // dimensions the user has configured.
+//
+// Once a resource has reached the configured cardinality limit for a metric,
+// spans that would create a new series are recorded in the overflow series.
 func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 	for i := 0; i < traces.ResourceSpans().Len(); i++ {
 		rspans := traces.ResourceSpans().At(i)
@@ -298,27 +335,63 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				}
 				key := p.buildKey(serviceName, span, p.dimensions, resourceAttr)
 
+				// The histograms and the sums have the same series, so the sums alone tell whether
+				// the span overflows both. The dimensions of overflowing spans aren't cached, as
+				// they are only needed by the events metric.
+				overflow := sums.IsCardinalityLimitReached() && !sums.Contains(key)
 				attributes, ok := p.metricKeyToDimensions.Get(key)
-				if !ok {
+				if !ok && (!overflow || p.config.Events.Enabled) {
 					attributes = p.buildAttributes(serviceName, span, resourceAttr)
-					p.metricKeyToDimensions.Add(key, attributes)
+					if !overflow {
+						p.metricKeyToDimensions.Add(key, attributes)
+					}
+				}
+
+				seriesKey, seriesAttributes := key, attributes
+				if overflow {
+					seriesKey, seriesAttributes = overflowKey, p.overflowAttributes
 				}
 
 				// aggregate histogram metrics
-				h := histograms.GetOrCreate(key, attributes)
+				h := histograms.GetOrCreate(seriesKey, seriesAttributes)
 				h.Observe(duration)
 				if !span.TraceID().IsEmpty() {
 					h.AddExemplar(span.TraceID(), span.SpanID(), duration)
 				}
 
 				// aggregate sums metrics
-				s := sums.GetOrCreate(key, attributes)
-				s.Add(1)
+				count := adjustedcount.FromAttributes(span.Attributes(), p.config.AdjustedCountAttribute)
+				s := sums.GetOrCreate(seriesKey, seriesAttributes)
+				s.Add(count)
+
+				if p.config.Events.Enabled {
+					p.aggregateEvents(&rm.events, key, attributes, span, count)
+				}
 			}
 		}
 	}
 }
 
+// aggregateEvents counts the events of the span, keyed by the span's metric key, the event name
+// and the configured event dimensions. Each event is weighted by the adjusted count of its span.
+func (p *connectorImp) aggregateEvents(events *metrics.SumMetrics, spanKey metrics.Key, spanDimensions pcommon.Map, span ptrace.Span, count float64) {
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		key := p.buildEventKey(spanKey, event, span.Attributes())
+		if events.IsCardinalityLimitReached() && !events.Contains(key) {
+			events.GetOrCreate(overflowKey, p.overflowAttributes).Add(count)
+			continue
+		}
+
+		attributes, ok := p.eventKeyToDimensions.Get(key)
+		if !ok {
+			attributes = p.buildEventAttributes(spanDimensions, event, span.Attributes())
+			p.eventKeyToDimensions.Add(key, attributes)
+		}
+		events.GetOrCreate(key, attributes).Add(count)
+	}
+}
+
 type resourceKey [16]byte
 
 func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map) *resourceMetrics {
@@ -327,7 +400,8 @@ func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map) *resourceMet
 	if !ok {
 		v = &resourceMetrics{
 			histograms: initHistogramMetrics(p.config),
-			sums:       metrics.NewSumMetrics(),
+			sums:       metrics.NewSumMetrics(p.config.AggregationCardinalityLimit, p.config.AdjustedCountAttribute != ""),
+			events:     metrics.NewSumMetrics(p.config.AggregationCardinalityLimit, p.config.AdjustedCountAttribute != ""),
 			attributes: attr,
 		}
 		p.resourceMetrics[key] = v
@@ -350,6 +424,19 @@ func (p *connectorImp) buildAttributes(serviceName string, span ptrace.Span, res
 	return attr
 }
 
+func (p *connectorImp) buildEventAttributes(spanDimensions pcommon.Map, event ptrace.SpanEvent, spanAttrs pcommon.Map) pcommon.Map {
+	attr := pcommon.NewMap()
+	attr.EnsureCapacity(spanDimensions.Len() + 1 + len(p.eventDimensions))
+	spanDimensions.CopyTo(attr)
+	attr.PutStr(eventNameKey, event.Name())
+	for _, d := range p.eventDimensions {
+		if v, ok := getDimensionValue(d, event.Attributes(), spanAttrs); ok {
+			v.CopyTo(attr.PutEmpty(d.name))
+		}
+	}
+	return attr
+}
+
 func concatDimensionValue(dest *bytes.Buffer, value string, prefixSep bool) {
 	if prefixSep {
 		dest.WriteString(metricKeySeparator)
@@ -378,6 +465,23 @@ func (p *connectorImp) buildKey(serviceName string, span ptrace.Span, optionalDi
 	return metrics.Key(p.keyBuf.String())
 }
 
+// buildEventKey builds the key of the events metric by appending the event name and the values of the
+// configured event dimensions to the span's metric key. Event dimensions are looked up in the event's
+// attributes first, falling back to the span's attributes.
+func (p *connectorImp) buildEventKey(spanKey metrics.Key, event ptrace.SpanEvent, spanAttrs pcommon.Map) metrics.Key {
+	p.keyBuf.Reset()
+	p.keyBuf.WriteString(string(spanKey))
+	concatDimensionValue(p.keyBuf, event.Name(), true)
+
+	for _, d := range p.eventDimensions {
+		if v, ok := getDimensionValue(d, event.Attributes(), spanAttrs); ok {
+			concatDimensionValue(p.keyBuf, v.AsString(), true)
+		}
+	}
+
+	return metrics.Key(p.keyBuf.String())
+}
+
 // getDimensionValue gets the dimension value for the given configured dimension.
 // It searches through the span's attributes first, being the more specific;
 // falling back to searching in resource attributes if it can't be found in the span.
@@ -753,6 +753,171 @@ func TestMetricKeyCache(t *testing.T) {
 	}, 10*time.Second, time.Millisecond*100)
 }
 
+func TestAdjustedCount(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AdjustedCountAttribute = "sampling.adjusted_count"
+	p, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for _, count := range []any{4.0, int64(2), 2.5, nil, "ten", -1.0} {
+		span := spans.AppendEmpty()
+		span.SetName("/ping")
+		if count != nil {
+			require.NoError(t, span.Attributes().PutEmpty("sampling.adjusted_count").FromRaw(count))
+		}
+	}
+
+	p.aggregateMetrics(traces)
+	metrics := p.buildMetrics()
+
+	calls := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, metricNameCalls, calls.Name())
+	require.Equal(t, 1, calls.Sum().DataPoints().Len())
+	// 4 + 2 + 2.5 for the weighted spans, plus 1 for each span without a valid adjusted count.
+	assert.Equal(t, 11.5, calls.Sum().DataPoints().At(0).DoubleValue())
+
+	duration := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1)
+	require.Equal(t, metricNameDuration, duration.Name())
+	assert.Equal(t, uint64(6), duration.Histogram().DataPoints().At(0).Count())
+}
+
+func TestAggregationCardinalityLimit(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AggregationCardinalityLimit = 2
+	cfg.Events.Enabled = true
+	p, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	for _, service := range []string{"service-a", "service-b"} {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(serviceNameKey, service)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for _, name := range []string{"/a", "/b", "/c", "/d", "/a"} {
+			span := spans.AppendEmpty()
+			span.SetName(name)
+			span.Events().AppendEmpty().SetName(name)
+		}
+	}
+
+	p.aggregateMetrics(traces)
+	// Only the dimensions of the series below the limit are cached.
+	assert.Equal(t, 4, p.metricKeyToDimensions.Len())
+	metrics := p.buildMetrics()
+
+	// The limit applies to each resource separately.
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		ms := metrics.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 3, ms.Len())
+		for _, metric := range []pmetric.Metric{ms.At(0), ms.At(2)} {
+			counts := map[string]int64{}
+			dps := metric.Sum().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				dp := dps.At(j)
+				if overflow, ok := dp.Attributes().Get(overflowKey); ok {
+					assert.True(t, overflow.Bool())
+					assert.Equal(t, 1, dp.Attributes().Len())
+					counts[overflowKey] = dp.IntValue()
+					continue
+				}
+				name, ok := dp.Attributes().Get(spanNameKey)
+				require.True(t, ok)
+				counts[name.Str()] = dp.IntValue()
+			}
+			// The spans of existing series are still recorded once the limit is reached.
+			assert.Equal(t, map[string]int64{"/a": 2, "/b": 1, overflowKey: 2}, counts, metric.Name())
+		}
+
+		duration := ms.At(1)
+		require.Equal(t, metricNameDuration, duration.Name())
+		assert.Equal(t, 3, duration.Histogram().DataPoints().Len())
+	}
+}
+
+func TestEvents(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Dimensions = []Dimension{{Name: "http.method"}}
+	cfg.Events = EventsConfig{
+		Enabled: true,
+		Dimensions: []Dimension{
+			{Name: "exception.type"},
+			{Name: "http.route", Default: stringp("unknown")},
+		},
+	}
+	cfg.AdjustedCountAttribute = "sampling.adjusted_count"
+	p, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	span := spans.AppendEmpty()
+	span.SetName("/ping")
+	span.Attributes().PutStr("http.method", "GET")
+	span.Attributes().PutStr("http.route", "/ping")
+	span.Attributes().PutInt("sampling.adjusted_count", 2)
+	for _, exceptionType := range []string{"IOException", "IOException", "TimeoutException"} {
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().PutStr("exception.type", exceptionType)
+	}
+
+	span = spans.AppendEmpty()
+	span.SetName("/ping")
+	span.Attributes().PutStr("http.method", "GET")
+	span.Events().AppendEmpty().SetName("retry")
+
+	// Spans without events don't create series of the events metric.
+	spans.AppendEmpty().SetName("/health")
+
+	p.aggregateMetrics(traces)
+	metrics := p.buildMetrics()
+
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, ms.Len())
+	events := ms.At(2)
+	require.Equal(t, metricNameEvents, events.Name())
+	assert.True(t, events.Sum().IsMonotonic())
+
+	got := map[string]float64{}
+	dps := events.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		assert.Equal(t, map[string]any{
+			serviceNameKey: "service-a",
+			spanNameKey:    "/ping",
+			spanKindKey:    "SPAN_KIND_UNSPECIFIED",
+			statusCodeKey:  "STATUS_CODE_UNSET",
+			"http.method":  "GET",
+		}, filterAttributes(attrs, serviceNameKey, spanNameKey, spanKindKey, statusCodeKey, "http.method"))
+
+		event := filterAttributes(attrs, eventNameKey, "exception.type", "http.route")
+		got[fmt.Sprintf("%v|%v|%v", event[eventNameKey], event["exception.type"], event["http.route"])] = dps.At(i).DoubleValue()
+	}
+	assert.Equal(t, map[string]float64{
+		"exception|IOException|/ping":      4,
+		"exception|TimeoutException|/ping": 2,
+		"retry|<nil>|unknown":              1,
+	}, got)
+}
+
+func filterAttributes(attrs pcommon.Map, keys ...string) map[string]any {
+	filtered := map[string]any{}
+	for _, k := range keys {
+		if v, ok := attrs.Get(k); ok {
+			filtered[k] = v.AsRaw()
+		}
+	}
+	return filtered
+}
+
 func BenchmarkConnectorConsumeTraces(b *testing.B) {
 	// Prepare
 	mcon := &mocks.MetricsConsumer{}
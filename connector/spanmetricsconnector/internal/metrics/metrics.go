@@ -231,19 +231,38 @@ func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcomm
 
 type Sum struct {
 	attributes pcommon.Map
-	count      uint64
+	count      float64
 }
 
-func (s *Sum) Add(value uint64) {
+// Add increases the sum by value. Values may be fractional, e.g. the adjusted count of
+// a sampled span.
+func (s *Sum) Add(value float64) {
 	s.count += value
 }
 
-func NewSumMetrics() SumMetrics {
-	return SumMetrics{metrics: make(map[Key]*Sum)}
+// NewSumMetrics returns the sums of a resource. A positive cardinalityLimit is the number
+// of distinct sums after which IsCardinalityLimitReached reports true. Sums are built as
+// double data points when doubleValues is set, so fractional counts are kept, and as
+// int data points otherwise.
+func NewSumMetrics(cardinalityLimit int, doubleValues bool) SumMetrics {
+	return SumMetrics{metrics: make(map[Key]*Sum), cardinalityLimit: cardinalityLimit, doubleValues: doubleValues}
 }
 
 type SumMetrics struct {
-	metrics map[Key]*Sum
+	metrics          map[Key]*Sum
+	cardinalityLimit int
+	doubleValues     bool
+}
+
+// Contains reports whether there is a sum for the key.
+func (m *SumMetrics) Contains(key Key) bool {
+	_, ok := m.metrics[key]
+	return ok
+}
+
+// IsCardinalityLimitReached reports whether no new sum should be created, if the limit is set.
+func (m *SumMetrics) IsCardinalityLimitReached() bool {
+	return m.cardinalityLimit > 0 && len(m.metrics) >= m.cardinalityLimit
 }
 
 func (m *SumMetrics) GetOrCreate(key Key, attributes pcommon.Map) *Sum {
@@ -272,7 +291,11 @@ func (m *SumMetrics) BuildMetrics(
 		dp := dps.AppendEmpty()
 		dp.SetStartTimestamp(start)
 		dp.SetTimestamp(timestamp)
-		dp.SetIntValue(int64(s.count))
+		if m.doubleValues {
+			dp.SetDoubleValue(s.count)
+		} else {
+			dp.SetIntValue(int64(s.count))
+		}
 		s.attributes.CopyTo(dp.Attributes())
 	}
 }
@@ -4,6 +4,7 @@
 package basicauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
@@ -12,12 +13,17 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/tg123/go-htpasswd"
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/auth"
+	"go.uber.org/zap"
 	creds "google.golang.org/grpc/credentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
 )
 
 var (
@@ -25,12 +31,19 @@ var (
 	errInvalidCredentials  = errors.New("invalid credentials")
 	errInvalidSchemePrefix = errors.New("invalid authorization scheme prefix")
 	errInvalidFormat       = errors.New("invalid authorization format")
+	errEmptyHtpasswdFile   = errors.New("empty htpasswd file")
 )
 
 type basicAuth struct {
 	htpasswd   *HtpasswdSettings
 	clientAuth *ClientAuthSettings
-	matchFunc  func(username, password string) bool
+
+	muMatchFunc sync.RWMutex
+	matchFunc   func(username, password string) bool
+
+	id      component.ID
+	logger  *zap.Logger
+	watcher *filereload.Watcher
 }
 
 func newClientAuthExtension(cfg *Config) (auth.Client, error) {
@@ -38,7 +51,7 @@ func newClientAuthExtension(cfg *Config) (auth.Client, error) {
 		return nil, errNoCredentialSource
 	}
 
-	ba := basicAuth{
+	ba := &basicAuth{
 		clientAuth: cfg.ClientAuth,
 	}
 	return auth.NewClient(
@@ -47,32 +60,81 @@ func newClientAuthExtension(cfg *Config) (auth.Client, error) {
 	), nil
 }
 
-func newServerAuthExtension(cfg *Config) (auth.Server, error) {
+func newServerAuthExtension(cfg *Config, set extension.CreateSettings) (auth.Server, error) {
 
 	if cfg.Htpasswd == nil || (cfg.Htpasswd.File == "" && cfg.Htpasswd.Inline == "") {
 		return nil, errNoCredentialSource
 	}
 
-	ba := basicAuth{
+	ba := &basicAuth{
 		htpasswd: cfg.Htpasswd,
+		id:       set.ID,
+		logger:   set.Logger,
 	}
 	return auth.NewServer(
 		auth.WithServerStart(ba.serverStart),
+		auth.WithServerShutdown(ba.serverShutdown),
 		auth.WithServerAuthenticate(ba.authenticate),
 	), nil
 }
 
 func (ba *basicAuth) serverStart(_ context.Context, _ component.Host) error {
+	htp, err := ba.loadCredentials(false)
+	if err != nil {
+		return err
+	}
+	ba.setMatchFunc(htp.Match)
+
+	if ba.htpasswd.File == "" {
+		return nil
+	}
+
+	// The credentials are reloaded each time the htpasswd file changes, until the extension is shut down
+	ba.watcher, err = filereload.StartWatcher(ba.htpasswd.File, ba.logger, ba.reload)
+	if err != nil {
+		return fmt.Errorf("watch htpasswd file: %w", err)
+	}
+	return nil
+}
+
+func (ba *basicAuth) serverShutdown(_ context.Context) error {
+	if ba.watcher != nil {
+		ba.watcher.Stop()
+		ba.watcher = nil
+	}
+	return nil
+}
+
+// reload swaps the credentials for the ones of the htpasswd file, or keeps the current ones when the file is invalid
+func (ba *basicAuth) reload() {
+	htp, err := ba.loadCredentials(true)
+	if err != nil {
+		ba.logger.Warn("Failed to reload the htpasswd file, keeping the current credentials", zap.String("file", ba.htpasswd.File), zap.Error(err))
+		reloadMetrics.RecordReloadFailed(ba.id)
+		return
+	}
+	ba.setMatchFunc(htp.Match)
+	ba.logger.Info("Reloaded the htpasswd file", zap.String("file", ba.htpasswd.File))
+	reloadMetrics.RecordReloaded(ba.id)
+}
+
+// loadCredentials reads the htpasswd file at once, and parses it along with the inline content. The malformed lines
+// are skipped, unless strict: an empty file or a malformed line of the file then fails the loading.
+func (ba *basicAuth) loadCredentials(strict bool) (*htpasswd.File, error) {
 	var rs []io.Reader
 
 	if ba.htpasswd.File != "" {
-		f, err := os.Open(ba.htpasswd.File)
+		content, err := os.ReadFile(ba.htpasswd.File)
 		if err != nil {
-			return fmt.Errorf("open htpasswd file: %w", err)
+			return nil, fmt.Errorf("open htpasswd file: %w", err)
+		}
+		if strict {
+			if err = validateHtpasswd(content); err != nil {
+				return nil, err
+			}
 		}
-		defer f.Close()
 
-		rs = append(rs, f)
+		rs = append(rs, bytes.NewReader(content))
 		rs = append(rs, strings.NewReader("\n"))
 	}
 
@@ -81,16 +143,51 @@ func (ba *basicAuth) serverStart(_ context.Context, _ component.Host) error {
 	rs = append(rs, strings.NewReader(ba.htpasswd.Inline))
 	mr := io.MultiReader(rs...)
 
-	htp, err := htpasswd.NewFromReader(mr, htpasswd.DefaultSystems, nil)
+	htp, err := htpasswd.NewFromReader(mr, htpasswd.DefaultSystems, func(err error) {
+		if !strict {
+			ba.logger.Warn("Skipping a malformed htpasswd line", zap.Error(err))
+		}
+	})
 	if err != nil {
-		return fmt.Errorf("read htpasswd content: %w", err)
+		return nil, fmt.Errorf("read htpasswd content: %w", err)
 	}
 
-	ba.matchFunc = htp.Match
+	return htp, nil
+}
+
+// validateHtpasswd checks that the content of a htpasswd file is not empty, and has no malformed line
+func validateHtpasswd(content []byte) error {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return errEmptyHtpasswdFile
+	}
 
+	var malformed error
+	_, err := htpasswd.NewFromReader(bytes.NewReader(content), htpasswd.DefaultSystems, func(err error) {
+		if malformed == nil {
+			malformed = err
+		}
+	})
+	if err == nil {
+		err = malformed
+	}
+	if err != nil {
+		return fmt.Errorf("read htpasswd content: %w", err)
+	}
 	return nil
 }
 
+func (ba *basicAuth) setMatchFunc(matchFunc func(username, password string) bool) {
+	ba.muMatchFunc.Lock()
+	ba.matchFunc = matchFunc
+	ba.muMatchFunc.Unlock()
+}
+
+func (ba *basicAuth) match(username, password string) bool {
+	ba.muMatchFunc.RLock()
+	defer ba.muMatchFunc.RUnlock()
+	return ba.matchFunc != nil && ba.matchFunc(username, password)
+}
+
 func (ba *basicAuth) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
 	auth := getAuthHeader(headers)
 	if auth == "" {
@@ -102,7 +199,7 @@ func (ba *basicAuth) authenticate(ctx context.Context, headers map[string][]stri
 		return ctx, err
 	}
 
-	if !ba.matchFunc(authData.username, authData.password) {
+	if !ba.match(authData.username, authData.password) {
 		return ctx, errInvalidCredentials
 	}
 
@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package basicauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
+
+import (
+	"go.opencensus.io/stats"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
+)
+
+var (
+	statCredentialsReloaded     = stats.Int64("credentials_reloaded", "Number of times the htpasswd file was reloaded", stats.UnitDimensionless)
+	statCredentialsReloadFailed = stats.Int64("credentials_reload_failed", "Number of times the htpasswd file failed to be reloaded, keeping the current credentials", stats.UnitDimensionless)
+
+	reloadMetrics = filereload.NewMetrics(metadata.Type, statCredentialsReloaded, statCredentialsReloadFailed)
+)
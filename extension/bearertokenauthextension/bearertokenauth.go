@@ -9,25 +9,27 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 
-	"github.com/fsnotify/fsnotify"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension/auth"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/credentials"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
 )
 
 var _ credentials.PerRPCCredentials = (*PerRPCAuth)(nil)
 
 // PerRPCAuth is a gRPC credentials.PerRPCCredentials implementation that returns an 'authorization' header.
 type PerRPCAuth struct {
-	metadata map[string]string
+	bearerTokenFunc func() string
 }
 
-// GetRequestMetadata returns the request metadata to be used with the RPC.
+// GetRequestMetadata returns the request metadata to be used with the RPC, holding the current token.
 func (c *PerRPCAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
-	return c.metadata, nil
+	return map[string]string{"authorization": c.bearerTokenFunc()}, nil
 }
 
 // RequireTransportSecurity always returns true for this implementation. Passing bearer tokens in plain-text connections is a bad idea.
@@ -40,21 +42,24 @@ var (
 	_ auth.Client = (*BearerTokenAuth)(nil)
 )
 
+var errEmptyTokenFile = errors.New("empty bearer token file")
+
 // BearerTokenAuth is an implementation of auth.Client. It embeds a static authorization "bearer" token in every rpc call.
 type BearerTokenAuth struct {
 	muTokenString sync.RWMutex
 	scheme        string
 	tokenString   string
 
-	shutdownCH chan struct{}
+	watcher *filereload.Watcher
 
 	filename string
+	id       component.ID
 	logger   *zap.Logger
 }
 
 var _ auth.Client = (*BearerTokenAuth)(nil)
 
-func newBearerTokenAuth(cfg *Config, logger *zap.Logger) *BearerTokenAuth {
+func newBearerTokenAuth(cfg *Config, id component.ID, logger *zap.Logger) *BearerTokenAuth {
 	if cfg.Filename != "" && cfg.BearerToken != "" {
 		logger.Warn("a filename is specified. Configured token is ignored!")
 	}
@@ -62,82 +67,60 @@ func newBearerTokenAuth(cfg *Config, logger *zap.Logger) *BearerTokenAuth {
 		scheme:      cfg.Scheme,
 		tokenString: string(cfg.BearerToken),
 		filename:    cfg.Filename,
+		id:          id,
 		logger:      logger,
 	}
 }
 
 // Start of BearerTokenAuth does nothing and returns nil if no filename
-// is specified. Otherwise a routine is started to monitor the file containing
-// the token to be transferred.
-func (b *BearerTokenAuth) Start(ctx context.Context, _ component.Host) error {
+// is specified. Otherwise the file containing the token to be transferred
+// is watched, and the token reloaded each time the file changes.
+func (b *BearerTokenAuth) Start(context.Context, component.Host) error {
 	if b.filename == "" {
 		return nil
 	}
 
-	if b.shutdownCH != nil {
+	if b.watcher != nil {
 		return fmt.Errorf("bearerToken file monitoring is already running")
 	}
 
 	// Read file once
-	b.refreshToken()
-
-	b.shutdownCH = make(chan struct{})
+	if err := b.refreshToken(); err != nil {
+		b.logger.Error(err.Error())
+	}
 
-	watcher, err := fsnotify.NewWatcher()
+	watcher, err := filereload.StartWatcher(b.filename, b.logger, b.reloadToken)
 	if err != nil {
-		return err
+		return fmt.Errorf("watch bearer token file: %w", err)
 	}
-	// start file watcher
-	go b.startWatcher(ctx, watcher)
-
-	return watcher.Add(b.filename)
+	b.watcher = watcher
+	return nil
 }
 
-func (b *BearerTokenAuth) startWatcher(ctx context.Context, watcher *fsnotify.Watcher) {
-	defer watcher.Close()
-	for {
-		select {
-		case _, ok := <-b.shutdownCH:
-			_ = ok
-			return
-		case <-ctx.Done():
-			return
-		case event, ok := <-watcher.Events:
-			if !ok {
-				continue
-			}
-			// NOTE: k8s configmaps uses symlinks, we need this workaround.
-			// original configmap file is removed.
-			// SEE: https://martensson.io/go-fsnotify-and-kubernetes-configmaps/
-			if event.Op == fsnotify.Remove || event.Op == fsnotify.Chmod {
-				// remove the watcher since the file is removed
-				if err := watcher.Remove(event.Name); err != nil {
-					b.logger.Error(err.Error())
-				}
-				// add a new watcher pointing to the new symlink/file
-				if err := watcher.Add(b.filename); err != nil {
-					b.logger.Error(err.Error())
-				}
-				b.refreshToken()
-			}
-			// also allow normal files to be modified and reloaded.
-			if event.Op == fsnotify.Write {
-				b.refreshToken()
-			}
-		}
+// reloadToken refreshes the token from the file, keeping the current token when the file can't be read or is empty
+func (b *BearerTokenAuth) reloadToken() {
+	if err := b.refreshToken(); err != nil {
+		b.logger.Warn("Failed to reload the token, keeping the current token", zap.String("filename", b.filename), zap.Error(err))
+		reloadMetrics.RecordReloadFailed(b.id)
+		return
 	}
+	reloadMetrics.RecordReloaded(b.id)
 }
 
-func (b *BearerTokenAuth) refreshToken() {
+func (b *BearerTokenAuth) refreshToken() error {
 	b.logger.Info("refresh token", zap.String("filename", b.filename))
 	token, err := os.ReadFile(b.filename)
 	if err != nil {
-		b.logger.Error(err.Error())
-		return
+		return err
+	}
+	// the file is validated before swapping the token, so that a file truncated while being written is not used
+	if strings.TrimSpace(string(token)) == "" {
+		return errEmptyTokenFile
 	}
 	b.muTokenString.Lock()
 	b.tokenString = string(token)
 	b.muTokenString.Unlock()
+	return nil
 }
 
 // Shutdown of BearerTokenAuth does nothing and returns nil
@@ -146,19 +129,18 @@ func (b *BearerTokenAuth) Shutdown(_ context.Context) error {
 		return nil
 	}
 
-	if b.shutdownCH == nil {
+	if b.watcher == nil {
 		return fmt.Errorf("bearerToken file monitoring is not running")
 	}
-	b.shutdownCH <- struct{}{}
-	close(b.shutdownCH)
-	b.shutdownCH = nil
+	b.watcher.Stop()
+	b.watcher = nil
 	return nil
 }
 
 // PerRPCCredentials returns PerRPCAuth an implementation of credentials.PerRPCCredentials that
 func (b *BearerTokenAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
 	return &PerRPCAuth{
-		metadata: map[string]string{"authorization": b.bearerToken()},
+		bearerTokenFunc: b.bearerToken,
 	}, nil
 }
 
@@ -184,7 +166,9 @@ func (b *BearerTokenAuth) Authenticate(ctx context.Context, headers map[string][
 		return ctx, errors.New("authentication didn't succeed")
 	}
 	token := auth[0]
+	b.muTokenString.RLock()
 	expect := b.tokenString
+	b.muTokenString.RUnlock()
 	if len(b.scheme) != 0 {
 		expect = fmt.Sprintf("%s %s", b.scheme, expect)
 	}
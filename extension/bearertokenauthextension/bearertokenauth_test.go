@@ -8,12 +8,20 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
 )
 
 func TestPerRPCAuth(t *testing.T) {
@@ -22,7 +30,7 @@ func TestPerRPCAuth(t *testing.T) {
 	}
 
 	// test meta data is properly
-	perRPCAuth := &PerRPCAuth{metadata: metadata}
+	perRPCAuth := &PerRPCAuth{bearerTokenFunc: func() string { return metadata["authorization"] }}
 	md, err := perRPCAuth.GetRequestMetadata(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, md, metadata)
@@ -48,7 +56,7 @@ func TestBearerAuthenticatorHttp(t *testing.T) {
 	cfg.BearerToken = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
 	cfg.Scheme = scheme
 
-	bauth := newBearerTokenAuth(cfg, nil)
+	bauth := newBearerTokenAuth(cfg, component.NewID(metadata.Type), nil)
 	assert.NotNil(t, bauth)
 
 	base := &mockRoundTripper{}
@@ -68,7 +76,7 @@ func TestBearerAuthenticator(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.BearerToken = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
 
-	bauth := newBearerTokenAuth(cfg, nil)
+	bauth := newBearerTokenAuth(cfg, component.NewID(metadata.Type), nil)
 	assert.NotNil(t, bauth)
 
 	assert.Nil(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
@@ -104,7 +112,7 @@ func TestBearerStartWatchStop(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.Filename = "test.token"
 
-	bauth := newBearerTokenAuth(cfg, zaptest.NewLogger(t))
+	bauth := newBearerTokenAuth(cfg, component.NewID(metadata.Type), zaptest.NewLogger(t))
 	assert.NotNil(t, bauth)
 
 	assert.Nil(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
@@ -146,7 +154,7 @@ func TestBearerStartWatchStop(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Nil(t, bauth.Shutdown(context.Background()))
-	assert.Nil(t, bauth.shutdownCH)
+	assert.Nil(t, bauth.watcher)
 }
 
 func TestBearerTokenFileContentUpdate(t *testing.T) {
@@ -155,7 +163,7 @@ func TestBearerTokenFileContentUpdate(t *testing.T) {
 	cfg.Filename = "test.token"
 	cfg.Scheme = scheme
 
-	bauth := newBearerTokenAuth(cfg, zaptest.NewLogger(t))
+	bauth := newBearerTokenAuth(cfg, component.NewID(metadata.Type), zaptest.NewLogger(t))
 	assert.NotNil(t, bauth)
 
 	assert.Nil(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
@@ -207,7 +215,7 @@ func TestBearerServerAuthenticateWithScheme(t *testing.T) {
 	cfg.Scheme = "Bearer"
 	cfg.BearerToken = token
 
-	bauth := newBearerTokenAuth(cfg, nil)
+	bauth := newBearerTokenAuth(cfg, component.NewID(metadata.Type), nil)
 	assert.NotNil(t, bauth)
 
 	ctx := context.Background()
@@ -231,7 +239,7 @@ func TestBearerServerAuthenticate(t *testing.T) {
 	cfg.Scheme = ""
 	cfg.BearerToken = token
 
-	bauth := newBearerTokenAuth(cfg, nil)
+	bauth := newBearerTokenAuth(cfg, component.NewID(metadata.Type), nil)
 	assert.NotNil(t, bauth)
 
 	ctx := context.Background()
@@ -251,3 +259,58 @@ func TestBearerServerAuthenticate(t *testing.T) {
 
 	assert.Nil(t, bauth.Shutdown(context.Background()))
 }
+
+func TestBearerTokenFileReload(t *testing.T) {
+	require.NoError(t, reloadMetrics.Register())
+
+	id := component.NewIDWithName(metadata.Type, "reload")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filename = filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(cfg.Filename, []byte("first"), 0600))
+
+	bauth := newBearerTokenAuth(cfg, id, zaptest.NewLogger(t))
+	require.NoError(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, bauth.Shutdown(context.Background())) }()
+
+	// the credentials of the clients created before a reload hold the current token
+	credential, err := bauth.PerRPCCredentials()
+	require.NoError(t, err)
+
+	// The file is rotated by swapping it, as done for the secrets mounted by Kubernetes
+	rotated := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(rotated, []byte("second"), 0600))
+	require.NoError(t, os.Rename(rotated, cfg.Filename))
+
+	assert.Eventually(t, func() bool {
+		_, err := bauth.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer second"}})
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	md, err := credential.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer second"}, md)
+	assert.Eventually(t, func() bool {
+		return reloads(t, id, statTokenReloaded) > 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The current token is kept when the file is empty
+	failed := reloads(t, id, statTokenReloadFailed)
+	require.NoError(t, os.WriteFile(cfg.Filename, []byte("\n"), 0600))
+	assert.Eventually(t, func() bool {
+		return reloads(t, id, statTokenReloadFailed) > failed
+	}, 10*time.Second, 10*time.Millisecond)
+	_, err = bauth.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer second"}})
+	assert.NoError(t, err)
+}
+
+func reloads(t *testing.T, id component.ID, measure *stats.Int64Measure) float64 {
+	rows, err := view.RetrieveData("extension/" + metadata.Type + "/" + measure.Name())
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key == filereload.TagExtensionKey && tag.Value == id.String() {
+				return row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	return 0
+}
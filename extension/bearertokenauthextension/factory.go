@@ -33,5 +33,8 @@ func createDefaultConfig() component.Config {
 }
 
 func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
-	return newBearerTokenAuth(cfg.(*Config), set.Logger), nil
+	if err := reloadMetrics.Register(); err != nil {
+		return nil, err
+	}
+	return newBearerTokenAuth(cfg.(*Config), set.ID, set.Logger), nil
 }
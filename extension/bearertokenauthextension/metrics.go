@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bearertokenauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension"
+
+import (
+	"go.opencensus.io/stats"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
+)
+
+var (
+	statTokenReloaded     = stats.Int64("token_reloaded", "Number of times the token was reloaded from its file", stats.UnitDimensionless)
+	statTokenReloadFailed = stats.Int64("token_reload_failed", "Number of times the token failed to be reloaded from its file", stats.UnitDimensionless)
+
+	reloadMetrics = filereload.NewMetrics(metadata.Type, statTokenReloaded, statTokenReloadFailed)
+)
@@ -5,13 +5,16 @@ package headerssetterextension // import "github.com/open-telemetry/opentelemetr
 
 import (
 	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
 )
 
 var (
 	errMissingHeader        = fmt.Errorf("missing header name")
 	errMissingHeadersConfig = fmt.Errorf("missing headers configuration")
-	errMissingSource        = fmt.Errorf("missing header source, must be 'from_context' or 'value'")
-	errConflictingSources   = fmt.Errorf("invalid header source, must either 'from_context' or 'value'")
+	errMissingSource        = fmt.Errorf("missing header source, must be 'from_context', 'from_auth_attribute', 'template' or 'value'")
+	errConflictingSources   = fmt.Errorf("invalid header source, must either 'from_context', 'from_auth_attribute', 'template' or 'value'")
+	errInvalidTemplate      = fmt.Errorf("invalid header template")
 )
 
 type Config struct {
@@ -23,6 +26,14 @@ type HeaderConfig struct {
 	Key         *string     `mapstructure:"key"`
 	Value       *string     `mapstructure:"value"`
 	FromContext *string     `mapstructure:"from_context"`
+	// FromAuthAttribute is the name of an attribute of the authentication data of the request,
+	// such as a claim of a token validated by a server authenticator.
+	FromAuthAttribute *string `mapstructure:"from_auth_attribute"`
+	// Template combines literals with values of the request metadata and of the authentication data,
+	// using the `metadata` and `auth` functions, such as `{{ metadata "tenant" }}-{{ auth "sub" }}`.
+	Template *string `mapstructure:"template"`
+	// Default is the value used when the source of the header yields an empty value.
+	Default *string `mapstructure:"default"`
 }
 
 // actionValue is the enum to capture the four types of actions to perform on a header
@@ -54,12 +65,23 @@ func (cfg *Config) Validate() error {
 		}
 
 		if header.Action != DELETE {
-			if header.FromContext == nil && header.Value == nil {
+			sources := 0
+			for _, s := range []*string{header.Value, header.FromContext, header.FromAuthAttribute, header.Template} {
+				if s != nil {
+					sources++
+				}
+			}
+			if sources == 0 {
 				return errMissingSource
 			}
-			if header.FromContext != nil && header.Value != nil {
+			if sources > 1 {
 				return errConflictingSources
 			}
+			if header.Template != nil {
+				if _, err := source.NewTemplateSource(*header.Template); err != nil {
+					return fmt.Errorf("%w: %v", errInvalidTemplate, err)
+				}
+			}
 		}
 	}
 	return nil
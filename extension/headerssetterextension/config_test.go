@@ -53,6 +53,17 @@ func TestLoadConfig(t *testing.T) {
 						Key:    stringp("User-ID"),
 						Action: DELETE,
 					},
+					{
+						Key:               stringp("X-Scope-OrgID"),
+						Action:            UPSERT,
+						FromAuthAttribute: stringp("tenant"),
+						Default:           stringp("anonymous"),
+					},
+					{
+						Key:      stringp("X-Tenant-User"),
+						Action:   INSERT,
+						Template: stringp(`{{ metadata "tenant_id" }}/{{ auth "sub" }}`),
+					},
 				},
 			},
 		},
@@ -138,6 +149,41 @@ func TestValidateConfig(t *testing.T) {
 			},
 			errConflictingSources,
 		},
+		{
+			"header value from auth attribute and template",
+			[]HeaderConfig{
+				{
+					Key:               stringp("name"),
+					Action:            INSERT,
+					FromAuthAttribute: stringp("tenant"),
+					Template:          stringp(`{{ auth "sub" }}`),
+				},
+			},
+			errConflictingSources,
+		},
+		{
+			"header value from invalid template",
+			[]HeaderConfig{
+				{
+					Key:      stringp("name"),
+					Action:   INSERT,
+					Template: stringp(`{{ auth "sub" }`),
+				},
+			},
+			errInvalidTemplate,
+		},
+		{
+			"header value from template",
+			[]HeaderConfig{
+				{
+					Key:      stringp("name"),
+					Action:   INSERT,
+					Template: stringp(`tenant-{{ metadata "tenant" }}`),
+					Default:  stringp("tenant-unknown"),
+				},
+			},
+			nil,
+		},
 		{
 			"header value source is missing",
 			[]HeaderConfig{
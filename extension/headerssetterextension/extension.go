@@ -18,8 +18,9 @@ import (
 )
 
 type Header struct {
-	action action.Action
-	source source.Source
+	action       action.Action
+	source       source.Source
+	defaultValue *string
 }
 
 func newHeadersSetterExtension(cfg *Config, logger *zap.Logger) (auth.Client, error) {
@@ -30,14 +31,25 @@ func newHeadersSetterExtension(cfg *Config, logger *zap.Logger) (auth.Client, er
 	headers := make([]Header, 0, len(cfg.HeadersConfig))
 	for _, header := range cfg.HeadersConfig {
 		var s source.Source
-		if header.Value != nil {
+		switch {
+		case header.Value != nil:
 			s = &source.StaticSource{
 				Value: *header.Value,
 			}
-		} else if header.FromContext != nil {
+		case header.FromContext != nil:
 			s = &source.ContextSource{
 				Key: *header.FromContext,
 			}
+		case header.FromAuthAttribute != nil:
+			s = &source.AuthSource{
+				Attribute: *header.FromAuthAttribute,
+			}
+		case header.Template != nil:
+			ts, err := source.NewTemplateSource(*header.Template)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the template of header %q: %w", *header.Key, err)
+			}
+			s = ts
 		}
 
 		var a action.Action
@@ -55,7 +67,7 @@ func newHeadersSetterExtension(cfg *Config, logger *zap.Logger) (auth.Client, er
 			logger.Warn("The action was not provided, using 'upsert'." +
 				" In future versions, we'll require this to be explicitly set")
 		}
-		headers = append(headers, Header{action: a, source: s})
+		headers = append(headers, Header{action: a, source: s, defaultValue: header.Default})
 	}
 
 	return auth.NewClient(
@@ -87,9 +99,9 @@ func (h *headersPerRPC) GetRequestMetadata(
 
 	metadata := make(map[string]string, len(h.headers))
 	for _, header := range h.headers {
-		value, err := header.source.Get(ctx)
+		value, err := header.value(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to determine the source: %w", err)
+			return nil, err
 		}
 		header.action.ApplyOnMetadata(metadata, value)
 	}
@@ -118,11 +130,27 @@ func (h *headersRoundTripper) RoundTrip(req *http.Request) (*http.Response, erro
 		req2.Header = make(http.Header)
 	}
 	for _, header := range h.headers {
-		value, err := header.source.Get(req.Context())
+		value, err := header.value(req.Context())
 		if err != nil {
-			return nil, fmt.Errorf("failed to determine the source: %w", err)
+			return nil, err
 		}
 		header.action.ApplyOnHeaders(req2.Header, value)
 	}
 	return h.base.RoundTrip(req2)
 }
+
+// value returns the value of the header from its source, or its default value if the source
+// yields an empty value. Headers without source, such as deleted headers, have an empty value.
+func (h Header) value(ctx context.Context) (string, error) {
+	var value string
+	if h.source != nil {
+		var err error
+		if value, err = h.source.Get(ctx); err != nil {
+			return "", fmt.Errorf("failed to determine the source: %w", err)
+		}
+	}
+	if value == "" && h.defaultValue != nil {
+		value = *h.defaultValue
+	}
+	return value, nil
+}
@@ -37,6 +37,7 @@ func TestRoundTripper(t *testing.T) {
 				context.Background(),
 				client.Info{
 					Metadata: tt.metadata,
+					Auth:     tt.auth,
 				},
 			)
 			req, err := http.NewRequestWithContext(ctx, "GET", "", nil)
@@ -70,7 +71,7 @@ func TestPerRPCCredentials(t *testing.T) {
 
 			ctx := client.NewContext(
 				context.Background(),
-				client.Info{Metadata: tt.metadata},
+				client.Info{Metadata: tt.metadata, Auth: tt.auth},
 			)
 
 			metadata, err := perRPC.GetRequestMetadata(ctx)
@@ -94,6 +95,7 @@ var (
 	tests         = []struct {
 		cfg             *Config
 		metadata        client.Metadata
+		auth            client.AuthData
 		expectedHeaders map[string]string
 	}{
 		{
@@ -218,9 +220,97 @@ var (
 				"header_name": "",
 			},
 		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:               &header,
+						Action:            INSERT,
+						FromAuthAttribute: stringp("tenant"),
+					},
+				},
+			},
+			auth: authData{"tenant": "acme"},
+			expectedHeaders: map[string]string{
+				"header_name": "acme",
+			},
+		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:      &header,
+						Action:   INSERT,
+						Template: stringp(`{{ metadata "tenant" }}/{{ auth "sub" }}`),
+					},
+				},
+			},
+			metadata: client.NewMetadata(
+				map[string][]string{"tenant": {"acme"}},
+			),
+			auth: authData{"sub": "alice"},
+			expectedHeaders: map[string]string{
+				"header_name": "acme/alice",
+			},
+		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:         &header,
+						Action:      INSERT,
+						FromContext: stringp("tenant"),
+						Default:     stringp("anonymous"),
+					},
+					{
+						Key:               &anotherHeader,
+						Action:            INSERT,
+						FromAuthAttribute: stringp("tenant"),
+						Default:           stringp("anonymous"),
+					},
+				},
+			},
+			auth: authData{"tenant": "acme"},
+			expectedHeaders: map[string]string{
+				"header_name":         "anonymous",
+				"another_header_name": "acme",
+			},
+		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:    &header,
+						Action: INSERT,
+						Value:  stringp("config value"),
+					},
+					{
+						Key:    &header,
+						Action: DELETE,
+					},
+				},
+			},
+			expectedHeaders: map[string]string{
+				"header_name": "",
+			},
+		},
 	}
 )
 
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
 func stringp(str string) *string {
 	return &str
 }
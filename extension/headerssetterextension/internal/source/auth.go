@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+var _ Source = (*AuthSource)(nil)
+
+// AuthSource looks up the value of an attribute of the authentication data of the request,
+// such as a claim of a token validated by a server authenticator.
+type AuthSource struct {
+	Attribute string
+}
+
+func (ts *AuthSource) Get(ctx context.Context) (string, error) {
+	return authValue(ctx, ts.Attribute)
+}
+
+// authValue returns the value of an attribute of the authentication data, or an empty string
+// if the request is not authenticated or the attribute is not set.
+func authValue(ctx context.Context, attribute string) (string, error) {
+	cl := client.FromContext(ctx)
+	if cl.Auth == nil {
+		return "", nil
+	}
+
+	switch v := cl.Auth.GetAttribute(attribute).(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []string:
+		if len(v) == 0 {
+			return "", nil
+		}
+		if len(v) > 1 {
+			return "", fmt.Errorf("%d values found for the auth attribute %q, can't determine which one to use", len(v), attribute)
+		}
+		return v[0], nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
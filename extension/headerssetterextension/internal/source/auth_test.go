@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/client"
+)
+
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestAuthSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     client.AuthData
+		expected string
+		err      bool
+	}{
+		{
+			name: "not authenticated",
+		},
+		{
+			name: "missing attribute",
+			auth: authData{"subject": "acme"},
+		},
+		{
+			name:     "string attribute",
+			auth:     authData{"tenant": "acme"},
+			expected: "acme",
+		},
+		{
+			name:     "single value attribute",
+			auth:     authData{"tenant": []string{"acme"}},
+			expected: "acme",
+		},
+		{
+			name: "multiple values attribute",
+			auth: authData{"tenant": []string{"acme", "globex"}},
+			err:  true,
+		},
+		{
+			name:     "other attribute",
+			auth:     authData{"tenant": 42},
+			expected: "42",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &AuthSource{Attribute: "tenant"}
+			ctx := client.NewContext(context.Background(), client.Info{Auth: tt.auth})
+
+			value, err := ts.Get(ctx)
+
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
@@ -17,8 +17,13 @@ type ContextSource struct {
 }
 
 func (ts *ContextSource) Get(ctx context.Context) (string, error) {
+	return metadataValue(ctx, ts.Key)
+}
+
+// metadataValue returns the value of a key of the request metadata, or an empty string if the key is not set.
+func metadataValue(ctx context.Context, key string) (string, error) {
 	cl := client.FromContext(ctx)
-	ss := cl.Metadata.Get(ts.Key)
+	ss := cl.Metadata.Get(key)
 
 	if len(ss) == 0 {
 		return "", nil
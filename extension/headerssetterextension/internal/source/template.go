@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
+
+import (
+	"context"
+	"strings"
+	"text/template"
+)
+
+var _ Source = (*TemplateSource)(nil)
+
+// TemplateSource renders a template combining literals with values of the request metadata
+// and of the authentication data, such as `{{ metadata "tenant" }}-{{ auth "sub" }}`.
+type TemplateSource struct {
+	template *template.Template
+}
+
+// NewTemplateSource parses the template of a header value.
+func NewTemplateSource(text string) (*TemplateSource, error) {
+	// The functions are bound to the request context when the template is rendered
+	tmpl, err := template.New("header").Option("missingkey=error").Funcs(templateFuncs(context.Background())).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateSource{template: tmpl}, nil
+}
+
+func (ts *TemplateSource) Get(ctx context.Context) (string, error) {
+	tmpl, err := ts.template.Clone()
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Funcs(templateFuncs(ctx)).Execute(&sb, nil); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func templateFuncs(ctx context.Context) template.FuncMap {
+	return template.FuncMap{
+		"metadata": func(key string) (string, error) {
+			return metadataValue(ctx, key)
+		},
+		"auth": func(attribute string) (string, error) {
+			return authValue(ctx, attribute)
+		},
+	}
+}
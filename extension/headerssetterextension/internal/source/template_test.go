@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+)
+
+func TestTemplateSource(t *testing.T) {
+	ts, err := NewTemplateSource(`{{ metadata "X-Scope-OrgID" }}-{{ auth "sub" }}|{{ metadata "missing" }}`)
+	require.NoError(t, err)
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Scope-OrgID": {"acme"}}),
+		Auth:     authData{"sub": "alice"},
+	})
+	value, err := ts.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme-alice|", value)
+
+	value, err = ts.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "-|", value)
+}
+
+func TestTemplateSourceError(t *testing.T) {
+	ts, err := NewTemplateSource(`{{ metadata "X-Scope-OrgID" }}`)
+	require.NoError(t, err)
+
+	cl := client.FromContext(context.Background())
+	cl.Metadata = client.NewMetadata(map[string][]string{"X-Scope-OrgID": {"acme", "globex"}})
+	value, err := ts.Get(client.NewContext(context.Background(), cl))
+	assert.Error(t, err)
+	assert.Empty(t, value)
+}
+
+func TestTemplateSourceInvalid(t *testing.T) {
+	_, err := NewTemplateSource(`{{ metadata "X-Scope-OrgID" }`)
+	assert.Error(t, err)
+
+	_, err = NewTemplateSource(`{{ unknown "X-Scope-OrgID" }}`)
+	assert.Error(t, err)
+}
@@ -37,6 +37,44 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// Liveness configures the liveness endpoint, which fails when the collector is broken.
+	Liveness ProbeSettings `mapstructure:"liveness"`
+
+	// Readiness configures the readiness endpoint, which fails until the collector is ready to handle data.
+	Readiness ProbeSettings `mapstructure:"readiness"`
+
+	// ComponentCheckInterval is the interval at which the status of the exporters and receivers is checked.
+	// The default value is 10s.
+	ComponentCheckInterval time.Duration `mapstructure:"component_check_interval"`
+
+	// Pipelines lists the pipelines reported by the liveness and readiness endpoints, by pipeline ID, with
+	// their receivers and exporters as set in the service, whose configuration extensions can't access.
+	// The components of the other pipelines are reported in a pipeline named after their signal.
+	Pipelines map[component.ID]PipelineSettings `mapstructure:"pipelines"`
+}
+
+// PipelineSettings lists the components of a pipeline reported by the liveness and readiness endpoints.
+type PipelineSettings struct {
+	// Receivers are the receivers of the pipeline, reported before they handle any data.
+	Receivers []component.ID `mapstructure:"receivers"`
+
+	// Exporters are the exporters of the pipeline, reported before they handle any data.
+	Exporters []component.ID `mapstructure:"exporters"`
+}
+
+// ProbeSettings configures a liveness or readiness endpoint.
+type ProbeSettings struct {
+	// Path represents the path the probe is served on. The probe is disabled when empty.
+	Path string `mapstructure:"path"`
+
+	// ExporterFailureThreshold is the number of consecutive checks during which an exporter failed to send data,
+	// without sending any, after which the probe fails. Exporter failures are ignored when set to 0.
+	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
+
+	// ReceiverFailureThreshold is the number of consecutive checks during which a receiver refused data,
+	// without accepting any, after which the probe fails. Receiver failures are ignored when set to 0.
+	ReceiverFailureThreshold int `mapstructure:"receiver_failure_threshold"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -44,6 +82,10 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errInvalidProbePath                        = errors.New("bad config: liveness and readiness paths must start with / and be distinct from path")
+	errInvalidProbeFailureThreshold            = errors.New("bad config: liveness and readiness exporter_failure_threshold and receiver_failure_threshold cannot be negative")
+	errInvalidComponentCheckInterval           = errors.New("bad config: component_check_interval must be positive")
+	errInvalidPipelineID                       = errors.New("bad config: the type of the pipelines must be traces, metrics or logs")
 )
 
 // Validate checks if the extension configuration is valid
@@ -61,6 +103,27 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	for _, probe := range []ProbeSettings{cfg.Liveness, cfg.Readiness} {
+		if probe.Path != "" && (!strings.HasPrefix(probe.Path, "/") || probe.Path == cfg.Path) {
+			return errInvalidProbePath
+		}
+		if probe.ExporterFailureThreshold < 0 || probe.ReceiverFailureThreshold < 0 {
+			return errInvalidProbeFailureThreshold
+		}
+	}
+	if cfg.Liveness.Path != "" && cfg.Liveness.Path == cfg.Readiness.Path {
+		return errInvalidProbePath
+	}
+	if (cfg.Liveness.Path != "" || cfg.Readiness.Path != "") && cfg.ComponentCheckInterval <= 0 {
+		return errInvalidComponentCheckInterval
+	}
+	for id := range cfg.Pipelines {
+		switch id.Type() {
+		case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+		default:
+			return errInvalidPipelineID
+		}
+	}
 	return nil
 }
 
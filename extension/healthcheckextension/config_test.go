@@ -6,6 +6,7 @@ package healthcheckextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,6 +46,18 @@ func TestLoadConfig(t *testing.T) {
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
 				Path:                   "/",
 				ResponseBody:           nil,
+				Readiness: ProbeSettings{
+					Path:                     "/ready",
+					ExporterFailureThreshold: 3,
+					ReceiverFailureThreshold: 2,
+				},
+				ComponentCheckInterval: 30 * time.Second,
+				Pipelines: map[component.ID]PipelineSettings{
+					component.NewIDWithName(component.DataTypeTraces, "2"): {
+						Receivers: []component.ID{component.NewID("otlp")},
+						Exporters: []component.ID{component.NewID("otlp"), component.NewIDWithName("otlp", "backup")},
+					},
+				},
 			},
 		},
 		{
@@ -59,6 +72,26 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(metadata.Type, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidprobepath"),
+			expectedErr: errInvalidProbePath,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidprobethreshold"),
+			expectedErr: errInvalidProbeFailureThreshold,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidreceiverthreshold"),
+			expectedErr: errInvalidProbeFailureThreshold,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidcomponentcheckinterval"),
+			expectedErr: errInvalidComponentCheckInterval,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidpipeline"),
+			expectedErr: errInvalidPipelineID,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -5,6 +5,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -17,6 +18,8 @@ const (
 	// Use 0.0.0.0 to make the health check endpoint accessible
 	// in container orchestration environments like Kubernetes.
 	defaultEndpoint = "0.0.0.0:13133"
+
+	defaultComponentCheckInterval = 10 * time.Second
 )
 
 // NewFactory creates a factory for HealthCheck extension.
@@ -36,6 +39,7 @@ func createDefaultConfig() component.Config {
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
 		Path:                   "/",
+		ComponentCheckInterval: defaultComponentCheckInterval,
 	}
 }
 
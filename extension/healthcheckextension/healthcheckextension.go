@@ -5,9 +5,12 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
@@ -25,6 +28,13 @@ type healthCheckExtension struct {
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
 	settings component.TelemetrySettings
+
+	// tracker tracks the status of the components for the liveness and readiness endpoints
+	tracker      *componentTracker
+	trackerStop  chan struct{}
+	trackerWG    sync.WaitGroup
+	wasReady     atomic.Bool
+	shutdownOnce sync.Once
 }
 
 var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
@@ -46,6 +56,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		// Mount HC handler
 		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.baseHandler())
+		hc.mountProbes(mux, host)
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
@@ -71,6 +82,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 
 		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.checkCollectorPipelineHandler())
+		hc.mountProbes(mux, host)
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
@@ -131,6 +143,78 @@ func (hc *healthCheckExtension) checkCollectorPipelineHandler() http.Handler {
 	})
 }
 
+// mountProbes mounts the liveness and readiness endpoints, and starts tracking the status of the components
+func (hc *healthCheckExtension) mountProbes(mux *http.ServeMux, host component.Host) {
+	if hc.config.Liveness.Path == "" && hc.config.Readiness.Path == "" {
+		return
+	}
+
+	hc.tracker = newComponentTracker(hc.config.Pipelines)
+	hc.tracker.seed(host.GetExporters(), time.Now()) //nolint:staticcheck
+	view.RegisterExporter(hc.tracker)
+
+	if hc.config.Liveness.Path != "" {
+		mux.Handle(hc.config.Liveness.Path, hc.probeHandler(hc.config.Liveness, false))
+	}
+	if hc.config.Readiness.Path != "" {
+		mux.Handle(hc.config.Readiness.Path, hc.probeHandler(hc.config.Readiness, true))
+	}
+
+	ticker := time.NewTicker(hc.config.ComponentCheckInterval)
+	hc.trackerStop = make(chan struct{})
+	hc.trackerWG.Add(1)
+	go func() {
+		defer hc.trackerWG.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				hc.tracker.check(now)
+			case <-hc.trackerStop:
+				return
+			}
+		}
+	}()
+}
+
+// probeHandler serves the status of the collector and of its components. A liveness probe only fails when
+// the exporters or receivers fail, while a readiness probe also fails until the pipelines are started.
+func (hc *healthCheckExtension) probeHandler(probe ProbeSettings, readiness bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		status := collectorStatus{
+			Status:    hc.collectorStatus(),
+			Pipelines: hc.tracker.snapshot(),
+		}
+		if probe.ExporterFailureThreshold > 0 && hc.tracker.maxFailures(component.KindExporter) >= probe.ExporterFailureThreshold {
+			status.Status = collectorStatusUnhealthy
+		}
+		if probe.ReceiverFailureThreshold > 0 && hc.tracker.maxFailures(component.KindReceiver) >= probe.ReceiverFailureThreshold {
+			status.Status = collectorStatusUnhealthy
+		}
+
+		code := http.StatusOK
+		if status.Status == collectorStatusUnhealthy || (readiness && status.Status != collectorStatusReady) {
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
+
+// collectorStatus tells whether the pipelines are starting, ready, or not ready anymore
+func (hc *healthCheckExtension) collectorStatus() string {
+	switch {
+	case hc.state.Get() == healthcheck.Ready:
+		return collectorStatusReady
+	case hc.wasReady.Load():
+		return collectorStatusNotReady
+	default:
+		return collectorStatusStarting
+	}
+}
+
 func (hc *healthCheckExtension) check() bool {
 	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
 }
@@ -139,6 +223,13 @@ func (hc *healthCheckExtension) Shutdown(context.Context) error {
 	if hc.server == nil {
 		return nil
 	}
+	if hc.tracker != nil {
+		hc.shutdownOnce.Do(func() {
+			close(hc.trackerStop)
+			hc.trackerWG.Wait()
+			view.UnregisterExporter(hc.tracker)
+		})
+	}
 	err := hc.server.Close()
 	if hc.stopCh != nil {
 		<-hc.stopCh
@@ -147,6 +238,7 @@ func (hc *healthCheckExtension) Shutdown(context.Context) error {
 }
 
 func (hc *healthCheckExtension) Ready() error {
+	hc.wasReady.Store(true)
 	hc.state.Set(healthcheck.Ready)
 	return nil
 }
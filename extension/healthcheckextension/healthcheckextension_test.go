@@ -5,6 +5,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net"
 	"net/http"
@@ -332,6 +333,107 @@ func TestHealthCheckExtensionUsage(t *testing.T) {
 	}
 }
 
+func TestHealthCheckExtensionProbes(t *testing.T) {
+	config := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		Path:                   "/",
+		Liveness:               ProbeSettings{Path: "/livez", ExporterFailureThreshold: 3},
+		Readiness:              ProbeSettings{Path: "/readyz", ExporterFailureThreshold: 2, ReceiverFailureThreshold: 2},
+		ComponentCheckInterval: time.Hour,
+	}
+
+	hcExt := newServer(config, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, hcExt)
+
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+
+	// Give a chance for the server goroutine to run.
+	runtime.Gosched()
+	require.Eventuallyf(t, ensureServerRunning(config.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	probe := func(path string) (int, collectorStatus) {
+		resp, err := http.Get("http://" + config.Endpoint + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var status collectorStatus
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+		return resp.StatusCode, status
+	}
+	fail := func(total float64) {
+		hcExt.tracker.ExportView(sumViewData(t, "exporter/send_failed_spans", "exporter", map[string]float64{"otlp": total}))
+		hcExt.tracker.check(time.Now())
+	}
+
+	code, status := probe("/livez")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, collectorStatusStarting, status.Status)
+	code, status = probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, collectorStatusStarting, status.Status)
+
+	require.NoError(t, hcExt.Ready())
+	code, status = probe("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, collectorStatusReady, status.Status)
+
+	// The exporter fails twice in a row, the collector is not ready but still alive
+	fail(1)
+	fail(2)
+	code, status = probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, collectorStatusUnhealthy, status.Status)
+	require.Contains(t, status.Pipelines, "traces")
+	exporter := status.Pipelines["traces"].Exporters["otlp"]
+	require.NotNil(t, exporter)
+	assert.Equal(t, componentStatusFailing, exporter.Status)
+	assert.Equal(t, 2, exporter.ConsecutiveFailures)
+	assert.Equal(t, "failed to send 1 spans", exporter.Error)
+	code, status = probe("/livez")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, collectorStatusReady, status.Status)
+
+	// The exporter fails a third time in a row, the collector is broken
+	fail(3)
+	code, status = probe("/livez")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, collectorStatusUnhealthy, status.Status)
+
+	// The exporter recovers, the receiver refuses data twice in a row
+	hcExt.tracker.ExportView(sumViewData(t, "exporter/sent_spans", "exporter", map[string]float64{"otlp": 1}))
+	for _, total := range []float64{1, 2} {
+		hcExt.tracker.ExportView(sumViewData(t, "receiver/refused_spans", "receiver", map[string]float64{"otlp": total}))
+		hcExt.tracker.check(time.Now())
+	}
+	code, status = probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, collectorStatusUnhealthy, status.Status)
+	code, _ = probe("/livez")
+	assert.Equal(t, http.StatusOK, code)
+
+	// The receiver stops refusing data, and the pipelines are shut down
+	hcExt.tracker.check(time.Now())
+	code, _ = probe("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	require.NoError(t, hcExt.NotReady())
+	code, status = probe("/livez")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, collectorStatusNotReady, status.Status)
+	code, status = probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, collectorStatusNotReady, status.Status)
+
+	// The legacy endpoint is still served
+	resp, err := http.Get("http://" + config.Endpoint + config.Path)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+}
+
 func TestHealthCheckExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+)
+
+// The statuses of a component
+const (
+	componentStatusStarting = "starting"
+	componentStatusOK       = "ok"
+	componentStatusFailing  = "failing"
+	componentStatusRefusing = "refusing"
+	componentStatusIdle     = "idle"
+)
+
+// The statuses of the collector reported by the liveness and readiness endpoints
+const (
+	collectorStatusStarting  = "starting"
+	collectorStatusReady     = "ready"
+	collectorStatusNotReady  = "not_ready"
+	collectorStatusUnhealthy = "unhealthy"
+)
+
+// trackedView describes a view reporting the data a component succeeded or failed to handle
+type trackedView struct {
+	kind   component.Kind
+	tag    string
+	signal component.DataType
+	items  string
+	failed bool
+}
+
+// trackedViews are the views of the exporters and receivers, by name
+var trackedViews = map[string]trackedView{
+	"exporter/sent_spans":                {component.KindExporter, "exporter", component.DataTypeTraces, "spans", false},
+	"exporter/send_failed_spans":         {component.KindExporter, "exporter", component.DataTypeTraces, "spans", true},
+	"exporter/sent_metric_points":        {component.KindExporter, "exporter", component.DataTypeMetrics, "metric points", false},
+	"exporter/send_failed_metric_points": {component.KindExporter, "exporter", component.DataTypeMetrics, "metric points", true},
+	"exporter/sent_log_records":          {component.KindExporter, "exporter", component.DataTypeLogs, "log records", false},
+	"exporter/send_failed_log_records":   {component.KindExporter, "exporter", component.DataTypeLogs, "log records", true},
+	"receiver/accepted_spans":            {component.KindReceiver, "receiver", component.DataTypeTraces, "spans", false},
+	"receiver/refused_spans":             {component.KindReceiver, "receiver", component.DataTypeTraces, "spans", true},
+	"receiver/accepted_metric_points":    {component.KindReceiver, "receiver", component.DataTypeMetrics, "metric points", false},
+	"receiver/refused_metric_points":     {component.KindReceiver, "receiver", component.DataTypeMetrics, "metric points", true},
+	"receiver/accepted_log_records":      {component.KindReceiver, "receiver", component.DataTypeLogs, "log records", false},
+	"receiver/refused_log_records":       {component.KindReceiver, "receiver", component.DataTypeLogs, "log records", true},
+}
+
+// componentStatus is the last status of a component, as reported by the liveness and readiness endpoints
+type componentStatus struct {
+	Status              string    `json:"status"`
+	ConsecutiveFailures int       `json:"consecutive_failures,omitempty"`
+	Error               string    `json:"error,omitempty"`
+	Timestamp           time.Time `json:"timestamp"`
+}
+
+// pipelineStatus holds the status of the components of a pipeline. A component used by several pipelines
+// of the same signal has the same status in each of them, as the internal metrics do not tell them apart.
+type pipelineStatus struct {
+	Receivers map[string]*componentStatus `json:"receivers,omitempty"`
+	Exporters map[string]*componentStatus `json:"exporters,omitempty"`
+}
+
+// collectorStatus is the body of the responses of the liveness and readiness endpoints
+type collectorStatus struct {
+	Status    string                     `json:"status"`
+	Pipelines map[string]*pipelineStatus `json:"pipelines"`
+}
+
+type componentKey struct {
+	kind   component.Kind
+	signal component.DataType
+	id     string
+}
+
+// componentCounts are the cumulative counts of data a component succeeded or failed to handle,
+// as last reported by the views and as of the last check
+type componentCounts struct {
+	items                     string
+	succeeded, failed         float64
+	lastSucceeded, lastFailed float64
+}
+
+// componentTracker implements the view exporter interface of open census, and tracks the status
+// of the exporters and receivers from the data they succeed or fail to handle between checks
+type componentTracker struct {
+	mu       sync.Mutex
+	counts   map[componentKey]*componentCounts
+	statuses map[componentKey]*componentStatus
+	// pipelines are the configured pipelines, the components of the other pipelines
+	// are reported in a pipeline named after their signal
+	pipelines map[component.ID]PipelineSettings
+}
+
+func newComponentTracker(pipelines map[component.ID]PipelineSettings) *componentTracker {
+	return &componentTracker{
+		counts:    map[componentKey]*componentCounts{},
+		statuses:  map[componentKey]*componentStatus{},
+		pipelines: pipelines,
+	}
+}
+
+// seed registers the components of the configured pipelines and the exporters of the host,
+// so that they are reported before handling any data
+func (t *componentTracker) seed(exporters map[component.DataType]map[component.ID]component.Component, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for pipelineID, p := range t.pipelines {
+		for _, id := range p.Receivers {
+			t.status(componentKey{kind: component.KindReceiver, signal: pipelineID.Type(), id: id.String()}, now)
+		}
+		for _, id := range p.Exporters {
+			t.status(componentKey{kind: component.KindExporter, signal: pipelineID.Type(), id: id.String()}, now)
+		}
+	}
+	for signal, ids := range exporters {
+		for id := range ids {
+			t.status(componentKey{kind: component.KindExporter, signal: signal, id: id.String()}, now)
+		}
+	}
+}
+
+// ExportView records the cumulative counts of the tracked views
+func (t *componentTracker) ExportView(vd *view.Data) {
+	tv, ok := trackedViews[vd.View.Name]
+	if !ok {
+		return
+	}
+
+	// Receivers report a row per transport, which are summed up
+	totals := map[string]float64{}
+	for _, row := range vd.Rows {
+		sum, ok := row.Data.(*view.SumData)
+		if !ok {
+			continue
+		}
+		for _, tag := range row.Tags {
+			if tag.Key.Name() == tv.tag {
+				totals[tag.Value] += sum.Value
+			}
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for id, total := range totals {
+		key := componentKey{kind: tv.kind, signal: tv.signal, id: id}
+		c, ok := t.counts[key]
+		if !ok {
+			c = &componentCounts{items: tv.items}
+			t.counts[key] = c
+		}
+		if tv.failed {
+			c.failed = total
+		} else {
+			c.succeeded = total
+		}
+	}
+}
+
+// check updates the status of the components from the data they handled since the last check.
+// An exporter which failed to send data, and did not send any, fails one more time in a row.
+// A component which did not handle any data since failing becomes idle, ending its failures in a row.
+func (t *componentTracker) check(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, c := range t.counts {
+		succeeded, failed := c.succeeded-c.lastSucceeded, c.failed-c.lastFailed
+		c.lastSucceeded, c.lastFailed = c.succeeded, c.failed
+
+		s := t.status(key, now)
+		switch {
+		case succeeded > 0:
+			s.Status = componentStatusOK
+			s.ConsecutiveFailures = 0
+			s.Error = ""
+			s.Timestamp = now
+		case failed > 0:
+			s.ConsecutiveFailures++
+			s.Timestamp = now
+			if key.kind == component.KindExporter {
+				s.Status = componentStatusFailing
+				s.Error = fmt.Sprintf("failed to send %.0f %s", failed, c.items)
+			} else {
+				s.Status = componentStatusRefusing
+				s.Error = fmt.Sprintf("refused %.0f %s", failed, c.items)
+			}
+		case s.ConsecutiveFailures > 0:
+			s.Status = componentStatusIdle
+			s.ConsecutiveFailures = 0
+			s.Timestamp = now
+		}
+	}
+}
+
+// maxFailures returns the highest number of consecutive failures of the exporters or of the receivers
+func (t *componentTracker) maxFailures(kind component.Kind) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	max := 0
+	for key, s := range t.statuses {
+		if key.kind == kind && s.ConsecutiveFailures > max {
+			max = s.ConsecutiveFailures
+		}
+	}
+	return max
+}
+
+// snapshot returns a copy of the status of the pipelines, by pipeline ID
+func (t *componentTracker) snapshot() map[string]*pipelineStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pipelines := map[string]*pipelineStatus{}
+	add := func(pipelineID string, key componentKey) {
+		s, ok := t.statuses[key]
+		if !ok {
+			return
+		}
+		p, ok := pipelines[pipelineID]
+		if !ok {
+			p = &pipelineStatus{}
+			pipelines[pipelineID] = p
+		}
+		statuses := &p.Exporters
+		if key.kind == component.KindReceiver {
+			statuses = &p.Receivers
+		}
+		if *statuses == nil {
+			*statuses = map[string]*componentStatus{}
+		}
+		c := *s
+		(*statuses)[key.id] = &c
+	}
+
+	listed := map[componentKey]bool{}
+	for pipelineID, p := range t.pipelines {
+		for _, id := range p.Receivers {
+			key := componentKey{kind: component.KindReceiver, signal: pipelineID.Type(), id: id.String()}
+			listed[key] = true
+			add(pipelineID.String(), key)
+		}
+		for _, id := range p.Exporters {
+			key := componentKey{kind: component.KindExporter, signal: pipelineID.Type(), id: id.String()}
+			listed[key] = true
+			add(pipelineID.String(), key)
+		}
+	}
+	for key := range t.statuses {
+		if !listed[key] {
+			add(string(key.signal), key)
+		}
+	}
+	return pipelines
+}
+
+// status returns the status of a component, creating it if needed. It must be called with the lock held.
+func (t *componentTracker) status(key componentKey, now time.Time) *componentStatus {
+	s, ok := t.statuses[key]
+	if !ok {
+		s = &componentStatus{Status: componentStatusStarting, Timestamp: now}
+		t.statuses[key] = s
+	}
+	return s
+}
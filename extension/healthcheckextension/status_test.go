@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestComponentTracker(t *testing.T) {
+	tracker := newComponentTracker(nil)
+	start := time.Now()
+	tracker.seed(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeTraces: {component.NewID("otlp"): nil},
+	}, start)
+
+	traces := tracker.snapshot()["traces"]
+	require.NotNil(t, traces)
+	assert.Equal(t, &componentStatus{Status: componentStatusStarting, Timestamp: start}, traces.Exporters["otlp"])
+
+	// The exporter sends data, the receiver accepts data over two transports
+	tracker.ExportView(sumViewData(t, "exporter/sent_spans", "exporter", map[string]float64{"otlp": 10}))
+	tracker.ExportView(sumViewData(t, "receiver/accepted_spans", "receiver", map[string]float64{"otlp": 4}))
+	tracker.ExportView(sumViewData(t, "receiver/accepted_spans", "receiver", map[string]float64{"otlp": 4}))
+	first := start.Add(time.Second)
+	tracker.check(first)
+
+	traces = tracker.snapshot()["traces"]
+	assert.Equal(t, &componentStatus{Status: componentStatusOK, Timestamp: first}, traces.Exporters["otlp"])
+	assert.Equal(t, &componentStatus{Status: componentStatusOK, Timestamp: first}, traces.Receivers["otlp"])
+	assert.Equal(t, 0, tracker.maxFailures(component.KindExporter))
+
+	// The exporter fails twice in a row, the receiver is idle
+	tracker.ExportView(sumViewData(t, "exporter/send_failed_spans", "exporter", map[string]float64{"otlp": 5}))
+	second := first.Add(time.Second)
+	tracker.check(second)
+	tracker.ExportView(sumViewData(t, "exporter/send_failed_spans", "exporter", map[string]float64{"otlp": 12}))
+	third := second.Add(time.Second)
+	tracker.check(third)
+
+	traces = tracker.snapshot()["traces"]
+	assert.Equal(t, &componentStatus{
+		Status:              componentStatusFailing,
+		ConsecutiveFailures: 2,
+		Error:               "failed to send 7 spans",
+		Timestamp:           third,
+	}, traces.Exporters["otlp"])
+	assert.Equal(t, &componentStatus{Status: componentStatusOK, Timestamp: first}, traces.Receivers["otlp"])
+	assert.Equal(t, 2, tracker.maxFailures(component.KindExporter))
+
+	// The exporter recovers
+	tracker.ExportView(sumViewData(t, "exporter/sent_spans", "exporter", map[string]float64{"otlp": 11}))
+	fourth := third.Add(time.Second)
+	tracker.check(fourth)
+
+	traces = tracker.snapshot()["traces"]
+	assert.Equal(t, &componentStatus{Status: componentStatusOK, Timestamp: fourth}, traces.Exporters["otlp"])
+	assert.Equal(t, 0, tracker.maxFailures(component.KindExporter))
+}
+
+func TestComponentTrackerRefusingReceiver(t *testing.T) {
+	tracker := newComponentTracker(nil)
+	tracker.ExportView(sumViewData(t, "receiver/refused_log_records", "receiver", map[string]float64{"filelog": 3}))
+	now := time.Now()
+	tracker.check(now)
+
+	logs := tracker.snapshot()["logs"]
+	require.NotNil(t, logs)
+	assert.Nil(t, logs.Exporters)
+	assert.Equal(t, &componentStatus{
+		Status:              componentStatusRefusing,
+		ConsecutiveFailures: 1,
+		Error:               "refused 3 log records",
+		Timestamp:           now,
+	}, logs.Receivers["filelog"])
+	assert.Equal(t, 0, tracker.maxFailures(component.KindExporter))
+	assert.Equal(t, 1, tracker.maxFailures(component.KindReceiver))
+}
+
+func TestComponentTrackerIdle(t *testing.T) {
+	tracker := newComponentTracker(nil)
+	start := time.Now()
+	tracker.ExportView(sumViewData(t, "exporter/send_failed_spans", "exporter", map[string]float64{"otlp": 5}))
+	tracker.check(start)
+	tracker.ExportView(sumViewData(t, "exporter/send_failed_spans", "exporter", map[string]float64{"otlp": 6}))
+	tracker.check(start.Add(time.Second))
+	assert.Equal(t, 2, tracker.maxFailures(component.KindExporter))
+
+	// The exporter stops handling data, its failures in a row end
+	idle := start.Add(2 * time.Second)
+	tracker.check(idle)
+	assert.Equal(t, &componentStatus{
+		Status:    componentStatusIdle,
+		Error:     "failed to send 1 spans",
+		Timestamp: idle,
+	}, tracker.snapshot()["traces"].Exporters["otlp"])
+	assert.Equal(t, 0, tracker.maxFailures(component.KindExporter))
+
+	// Later idle checks leave it unchanged, and a new failure starts a new count
+	tracker.check(idle.Add(time.Second))
+	assert.Equal(t, idle, tracker.snapshot()["traces"].Exporters["otlp"].Timestamp)
+	tracker.ExportView(sumViewData(t, "exporter/send_failed_spans", "exporter", map[string]float64{"otlp": 8}))
+	tracker.check(idle.Add(2 * time.Second))
+	exporter := tracker.snapshot()["traces"].Exporters["otlp"]
+	assert.Equal(t, componentStatusFailing, exporter.Status)
+	assert.Equal(t, 1, exporter.ConsecutiveFailures)
+}
+
+func TestComponentTrackerPipelines(t *testing.T) {
+	tracker := newComponentTracker(map[component.ID]PipelineSettings{
+		component.NewIDWithName(component.DataTypeTraces, "2"): {
+			Receivers: []component.ID{component.NewID("otlp")},
+			Exporters: []component.ID{component.NewID("otlp")},
+		},
+	})
+	start := time.Now()
+	tracker.seed(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeTraces: {component.NewID("otlp"): nil, component.NewID("jaeger"): nil},
+	}, start)
+
+	// The receivers of the listed pipelines are reported before handling any data
+	pipelines := tracker.snapshot()
+	require.Contains(t, pipelines, "traces/2")
+	starting := &componentStatus{Status: componentStatusStarting, Timestamp: start}
+	assert.Equal(t, &pipelineStatus{
+		Receivers: map[string]*componentStatus{"otlp": starting},
+		Exporters: map[string]*componentStatus{"otlp": starting},
+	}, pipelines["traces/2"])
+	assert.Equal(t, &pipelineStatus{
+		Exporters: map[string]*componentStatus{"jaeger": starting},
+	}, pipelines["traces"])
+
+	// The failures of an unlisted receiver are reported in the pipeline named after its signal
+	tracker.ExportView(sumViewData(t, "receiver/refused_spans", "receiver", map[string]float64{"zipkin": 2}))
+	now := start.Add(time.Second)
+	tracker.check(now)
+	pipelines = tracker.snapshot()
+	assert.Equal(t, starting, pipelines["traces/2"].Receivers["otlp"])
+	assert.Equal(t, componentStatusRefusing, pipelines["traces"].Receivers["zipkin"].Status)
+	assert.Equal(t, 1, tracker.maxFailures(component.KindReceiver))
+}
+
+func TestComponentTrackerIgnoredViews(t *testing.T) {
+	tracker := newComponentTracker(nil)
+	tracker.ExportView(sumViewData(t, "processor/dropped_spans", "processor", map[string]float64{"batch": 3}))
+	tracker.ExportView(&view.Data{View: &view.View{Name: exporterFailureView}})
+	tracker.check(time.Now())
+	assert.Empty(t, tracker.snapshot())
+}
+
+func sumViewData(t *testing.T, name string, key string, values map[string]float64) *view.Data {
+	tagKey, err := tag.NewKey(key)
+	require.NoError(t, err)
+	transport, err := tag.NewKey("transport")
+	require.NoError(t, err)
+
+	vd := &view.Data{View: &view.View{Name: name}}
+	for id, value := range values {
+		vd.Rows = append(vd.Rows, &view.Row{
+			Tags: []tag.Tag{{Key: tagKey, Value: id}, {Key: transport, Value: "grpc"}},
+			Data: &view.SumData{Value: value},
+		})
+	}
+	return vd
+}
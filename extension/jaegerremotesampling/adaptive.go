@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/adaptive"
+)
+
+var _ strategystore.StrategyStore = (*adaptiveStrategyStore)(nil)
+
+// adaptiveStrategyStore serves the per-operation probabilistic strategies computed from the observed traffic
+type adaptiveStrategyStore struct {
+	strategies          *adaptive.Strategies
+	minSamplesPerSecond float64
+}
+
+func (a *adaptiveStrategyStore) GetSamplingStrategy(_ context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	strategy := a.strategies.Get(serviceName)
+
+	operations := make([]*sampling.OperationSamplingStrategy, 0, len(strategy.Operations))
+	for operation, probability := range strategy.Operations {
+		operations = append(operations, &sampling.OperationSamplingStrategy{
+			Operation:             operation,
+			ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: probability},
+		})
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].Operation < operations[j].Operation
+	})
+
+	return &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: strategy.DefaultProbability},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       strategy.DefaultProbability,
+			DefaultLowerBoundTracesPerSecond: a.minSamplesPerSecond,
+			PerOperationStrategies:           operations,
+		},
+	}, nil
+}
+
+// getStorageClient returns the client of the storage extension persisting the adaptive strategies
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, id component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return nil, nil
+	}
+
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindExtension, id, "")
+}
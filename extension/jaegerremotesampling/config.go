@@ -13,9 +13,19 @@ import (
 )
 
 var (
-	errTooManySources     = errors.New("too many sources specified, has to be either 'file' or 'remote'")
-	errNoSources          = errors.New("no sources specified, has to be either 'file' or 'remote'")
-	errAtLeastOneProtocol = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+	errTooManySources          = errors.New("too many sources specified, has to be either 'file', 'remote' or 'adaptive'")
+	errNoSources               = errors.New("no sources specified, has to be either 'file', 'remote' or 'adaptive'")
+	errAtLeastOneProtocol      = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+	errNegativeAdaptiveSetting = errors.New("the adaptive settings cannot be negative")
+	errInvalidProbability      = errors.New("the adaptive sampling probabilities must not be greater than 1")
+)
+
+const (
+	defaultTargetSamplesPerSecond     = 1
+	defaultCalculationInterval        = time.Minute
+	defaultInitialSamplingProbability = 0.001
+	defaultMinSamplingProbability     = 1e-5
+	defaultMinSamplesPerSecond        = 1.0 / 60
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -24,7 +34,7 @@ type Config struct {
 	*confighttp.HTTPServerSettings `mapstructure:"http"`
 	*configgrpc.GRPCServerSettings `mapstructure:"grpc"`
 
-	// Source configures the source for the strategies file. One of `remote` or `file` has to be specified.
+	// Source configures the source for the strategies file. One of `remote`, `file` or `adaptive` has to be specified.
 	Source Source `mapstructure:"source"`
 }
 
@@ -37,6 +47,54 @@ type Source struct {
 
 	// ReloadInterval determines the periodicity to refresh the strategies
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// Adaptive computes the strategies from the throughput of the root spans observed by the
+	// sampling_throughput processor
+	Adaptive *AdaptiveSettings `mapstructure:"adaptive"`
+}
+
+// AdaptiveSettings configures the strategies computed from the observed traffic. Unset values take their default.
+type AdaptiveSettings struct {
+	// TargetSamplesPerSecond is the number of traces sampled per second targeted for each operation.
+	// The default value is 1.
+	TargetSamplesPerSecond float64 `mapstructure:"target_samples_per_second"`
+
+	// CalculationInterval is the interval at which the strategies are computed. The default value is 1m.
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
+
+	// InitialSamplingProbability is the sampling probability of the operations without observed traffic.
+	// The default value is 0.001.
+	InitialSamplingProbability float64 `mapstructure:"initial_sampling_probability"`
+
+	// MinSamplingProbability is the lowest sampling probability of an operation. The default value is 0.00001.
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+
+	// MinSamplesPerSecond is the number of traces sampled per second guaranteed for each operation,
+	// regardless of its sampling probability. The default value is 1/60.
+	MinSamplesPerSecond float64 `mapstructure:"min_samples_per_second"`
+
+	// StorageID is the storage extension persisting the computed strategies, if any.
+	StorageID *component.ID `mapstructure:"storage"`
+}
+
+// withDefaults returns the settings with the default values for unset values
+func (cfg AdaptiveSettings) withDefaults() AdaptiveSettings {
+	if cfg.TargetSamplesPerSecond == 0 {
+		cfg.TargetSamplesPerSecond = defaultTargetSamplesPerSecond
+	}
+	if cfg.CalculationInterval == 0 {
+		cfg.CalculationInterval = defaultCalculationInterval
+	}
+	if cfg.InitialSamplingProbability == 0 {
+		cfg.InitialSamplingProbability = defaultInitialSamplingProbability
+	}
+	if cfg.MinSamplingProbability == 0 {
+		cfg.MinSamplingProbability = defaultMinSamplingProbability
+	}
+	if cfg.MinSamplesPerSecond == 0 {
+		cfg.MinSamplesPerSecond = defaultMinSamplesPerSecond
+	}
+	return cfg
 }
 
 var _ component.Config = (*Config)(nil)
@@ -47,13 +105,39 @@ func (cfg *Config) Validate() error {
 		return errAtLeastOneProtocol
 	}
 
-	if cfg.Source.File != "" && cfg.Source.Remote != nil {
+	sources := 0
+	if cfg.Source.File != "" {
+		sources++
+	}
+	if cfg.Source.Remote != nil {
+		sources++
+	}
+	if cfg.Source.Adaptive != nil {
+		sources++
+	}
+
+	if sources > 1 {
 		return errTooManySources
 	}
 
-	if cfg.Source.File == "" && cfg.Source.Remote == nil {
+	if sources == 0 {
 		return errNoSources
 	}
 
+	if cfg.Source.Adaptive != nil {
+		return cfg.Source.Adaptive.validate()
+	}
+
+	return nil
+}
+
+func (cfg *AdaptiveSettings) validate() error {
+	if cfg.TargetSamplesPerSecond < 0 || cfg.CalculationInterval < 0 || cfg.InitialSamplingProbability < 0 ||
+		cfg.MinSamplingProbability < 0 || cfg.MinSamplesPerSecond < 0 {
+		return errNegativeAdaptiveSetting
+	}
+	if cfg.InitialSamplingProbability > 1 || cfg.MinSamplingProbability > 1 {
+		return errInvalidProbability
+	}
 	return nil
 }
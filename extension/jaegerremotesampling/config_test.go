@@ -22,6 +22,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -55,6 +57,26 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "2"),
+			expected: &Config{
+				HTTPServerSettings: &confighttp.HTTPServerSettings{Endpoint: ":5778"},
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{NetAddr: confignet.NetAddr{
+					Endpoint:  ":14250",
+					Transport: "tcp",
+				}},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{
+						TargetSamplesPerSecond:     2,
+						CalculationInterval:        30 * time.Second,
+						InitialSamplingProbability: 0.01,
+						MinSamplingProbability:     0.0001,
+						MinSamplesPerSecond:        0.1,
+						StorageID:                  &storageID,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -71,6 +93,20 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestAdaptiveSettingsWithDefaults(t *testing.T) {
+	assert.Equal(t, AdaptiveSettings{
+		TargetSamplesPerSecond:     defaultTargetSamplesPerSecond,
+		CalculationInterval:        defaultCalculationInterval,
+		InitialSamplingProbability: defaultInitialSamplingProbability,
+		MinSamplingProbability:     defaultMinSamplingProbability,
+		MinSamplesPerSecond:        defaultMinSamplesPerSecond,
+	}, AdaptiveSettings{}.withDefaults())
+
+	settings := AdaptiveSettings{TargetSamplesPerSecond: 5, CalculationInterval: time.Second}.withDefaults()
+	assert.Equal(t, 5.0, settings.TargetSamplesPerSecond)
+	assert.Equal(t, time.Second, settings.CalculationInterval)
+}
+
 func TestValidate(t *testing.T) {
 
 	testCases := []struct {
@@ -101,6 +137,46 @@ func TestValidate(t *testing.T) {
 			},
 			expected: errTooManySources,
 		},
+		{
+			desc: "too many sources with adaptive",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					File:     "/tmp/some-file",
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+			expected: errTooManySources,
+		},
+		{
+			desc: "adaptive with defaults",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+		},
+		{
+			desc: "adaptive with negative target",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{TargetSamplesPerSecond: -1},
+				},
+			},
+			expected: errNegativeAdaptiveSetting,
+		},
+		{
+			desc: "adaptive with invalid probability",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{InitialSamplingProbability: 1.5},
+				},
+			},
+			expected: errInvalidProbability,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -12,14 +12,17 @@ import (
 	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/adaptive"
 )
 
 var _ extension.Extension = (*jrsExtension)(nil)
 
 type jrsExtension struct {
+	id        component.ID
 	cfg       *Config
 	telemetry component.TelemetrySettings
 
@@ -27,11 +30,15 @@ type jrsExtension struct {
 	grpcServer    component.Component
 	samplingStore strategystore.StrategyStore
 
+	// adaptiveStrategies computes the strategies from the observed traffic, when the source is adaptive
+	adaptiveStrategies *adaptive.Strategies
+
 	closers []func() error
 }
 
-func newExtension(cfg *Config, telemetry component.TelemetrySettings) *jrsExtension {
+func newExtension(cfg *Config, id component.ID, telemetry component.TelemetrySettings) *jrsExtension {
 	jrse := &jrsExtension{
+		id:        id,
 		cfg:       cfg,
 		telemetry: telemetry,
 	}
@@ -43,6 +50,7 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	// source of the sampling config:
 	// - remote (gRPC)
 	// - local file
+	// - adaptive
 	// we can then use a simplified logic here to assign the appropriate store
 	if jrse.cfg.Source.File != "" {
 		opts := static.Options{
@@ -71,6 +79,36 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 		})
 	}
 
+	if jrse.cfg.Source.Adaptive != nil {
+		settings := jrse.cfg.Source.Adaptive.withDefaults()
+		client, err := getStorageClient(ctx, host, settings.StorageID, jrse.id)
+		if err != nil {
+			return fmt.Errorf("failed to get the storage client of the adaptive strategies: %w", err)
+		}
+
+		jrse.adaptiveStrategies = adaptive.New(adaptive.Settings{
+			TargetSamplesPerSecond:     settings.TargetSamplesPerSecond,
+			CalculationInterval:        settings.CalculationInterval,
+			InitialSamplingProbability: settings.InitialSamplingProbability,
+			MinSamplingProbability:     settings.MinSamplingProbability,
+		}, client, jrse.telemetry.Logger)
+		if err := jrse.adaptiveStrategies.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start the adaptive strategies: %w", err)
+		}
+
+		jrse.samplingStore = &adaptiveStrategyStore{
+			strategies:          jrse.adaptiveStrategies,
+			minSamplesPerSecond: settings.MinSamplesPerSecond,
+		}
+		jrse.closers = append(jrse.closers, func() error {
+			err := jrse.adaptiveStrategies.Shutdown(context.Background())
+			if client != nil {
+				err = multierr.Append(err, client.Close(context.Background()))
+			}
+			return err
+		})
+	}
+
 	if jrse.cfg.HTTPServerSettings != nil {
 		httpServer, err := internal.NewHTTP(jrse.telemetry, *jrse.cfg.HTTPServerSettings, jrse.samplingStore)
 		if err != nil {
@@ -98,6 +136,14 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	return nil
 }
 
+// RecordThroughput records root spans of an operation of a service, from which the adaptive strategies are
+// computed. It is called by the sampling_throughput processor, and ignored unless the source is adaptive.
+func (jrse *jrsExtension) RecordThroughput(service, operation string, count int64) {
+	if jrse.adaptiveStrategies != nil {
+		jrse.adaptiveStrategies.RecordThroughput(service, operation, count)
+	}
+}
+
 func (jrse *jrsExtension) Shutdown(ctx context.Context) error {
 	// we probably don't want to break whenever an error occurs, we want to continue and close the other resources
 	if jrse.httpServer != nil {
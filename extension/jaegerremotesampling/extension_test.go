@@ -9,20 +9,28 @@ import (
 	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/adaptive"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/metadata"
 )
 
 func TestNewExtension(t *testing.T) {
 	// test
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, component.NewID(metadata.Type), componenttest.NewNopTelemetrySettings())
 
 	// verify
 	assert.NotNil(t, e)
@@ -33,7 +41,7 @@ func TestStartAndShutdownLocalFile(t *testing.T) {
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
 
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, component.NewID(metadata.Type), componenttest.NewNopTelemetrySettings())
 	require.NotNil(t, e)
 	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -66,7 +74,7 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	}
 
 	// create the extension
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, component.NewID(metadata.Type), componenttest.NewNopTelemetrySettings())
 	require.NotNil(t, e)
 
 	// test
@@ -74,6 +82,118 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	assert.NoError(t, e.Shutdown(context.Background()))
 }
 
+func TestStartAndShutdownAdaptive(t *testing.T) {
+	cfg := testConfig()
+	cfg.HTTPServerSettings = nil
+	cfg.GRPCServerSettings.NetAddr.Endpoint = "127.0.0.1:0"
+	cfg.Source.Adaptive = &AdaptiveSettings{TargetSamplesPerSecond: 2}
+
+	e := newExtension(cfg, component.NewID(metadata.Type), componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, e)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+
+	e.RecordThroughput("frontend", "GET /", 10)
+	resp, err := e.samplingStore.GetSamplingStrategy(context.Background(), "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: defaultInitialSamplingProbability},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       defaultInitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: defaultMinSamplesPerSecond,
+			PerOperationStrategies:           []*sampling.OperationSamplingStrategy{},
+		},
+	}, resp)
+
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestStartAdaptiveMissingStorage(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveSettings{StorageID: &storageID}
+
+	e := newExtension(cfg, component.NewID(metadata.Type), componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, e)
+	assert.ErrorContains(t, e.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'file_storage' not found")
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestAdaptiveStrategyStore(t *testing.T) {
+	strategies := adaptive.New(adaptive.Settings{
+		TargetSamplesPerSecond:     1,
+		CalculationInterval:        time.Millisecond,
+		InitialSamplingProbability: 0.1,
+		MinSamplingProbability:     0.001,
+	}, nil, zap.NewNop())
+	require.NoError(t, strategies.Start(context.Background()))
+	t.Cleanup(func() { require.NoError(t, strategies.Shutdown(context.Background())) })
+
+	store := &adaptiveStrategyStore{strategies: strategies, minSamplesPerSecond: 0.5}
+	strategies.RecordThroughput("frontend", "POST /cart", 1e9)
+	strategies.RecordThroughput("frontend", "GET /", 1e9)
+	require.Eventually(t, func() bool {
+		return len(strategies.Get("frontend").Operations) == 2
+	}, 10*time.Second, time.Millisecond)
+
+	resp, err := store.GetSamplingStrategy(context.Background(), "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.1},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       0.1,
+			DefaultLowerBoundTracesPerSecond: 0.5,
+			PerOperationStrategies: []*sampling.OperationSamplingStrategy{
+				{Operation: "GET /", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.001}},
+				{Operation: "POST /cart", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.001}},
+			},
+		},
+	}, resp)
+}
+
+func TestGetStorageClient(t *testing.T) {
+	id := component.NewID(metadata.Type)
+	client, err := getStorageClient(context.Background(), componenttest.NewNopHost(), nil, id)
+	assert.NoError(t, err)
+	assert.Nil(t, client)
+
+	storageID := component.NewID("file_storage")
+	host := &extensionsHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{
+		storageID: &nopStorage{},
+	}}
+	client, err = getStorageClient(context.Background(), host, &storageID, id)
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	host.extensions[storageID] = notStorage{}
+	_, err = getStorageClient(context.Background(), host, &storageID, id)
+	assert.EqualError(t, err, "non-storage extension 'file_storage' found")
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (nopStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return storage.NewNopClient(), nil
+}
+
+type notStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
 type samplingServer struct {
 	api_v2.UnimplementedSamplingManagerServer
 }
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adaptive // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/adaptive"
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	// probabilitiesKey is the storage key of the persisted sampling probabilities
+	probabilitiesKey = "probabilities"
+
+	// maxOperationsPerService bounds the number of operations tracked for a service
+	maxOperationsPerService = 2000
+
+	// maxIncreaseFactor bounds how much a sampling probability may increase at each calculation,
+	// so that the probability of an operation which suddenly receives less traffic does not oscillate
+	maxIncreaseFactor = 2
+)
+
+// Settings configures the calculation of the adaptive strategies
+type Settings struct {
+	// TargetSamplesPerSecond is the number of traces sampled per second targeted for each operation
+	TargetSamplesPerSecond float64
+	// CalculationInterval is the interval at which the sampling probabilities are calculated
+	CalculationInterval time.Duration
+	// InitialSamplingProbability is the sampling probability of the operations without observed traffic
+	InitialSamplingProbability float64
+	// MinSamplingProbability is the lowest sampling probability of an operation
+	MinSamplingProbability float64
+}
+
+// ServiceStrategy holds the sampling probabilities of a service
+type ServiceStrategy struct {
+	// DefaultProbability is the sampling probability of the operations without observed traffic
+	DefaultProbability float64
+	// Operations are the sampling probabilities of the operations with observed traffic
+	Operations map[string]float64
+}
+
+// Strategies computes per-service and per-operation sampling probabilities from the throughput of the
+// root spans observed, targeting a number of traces sampled per second for each operation.
+type Strategies struct {
+	settings Settings
+	client   storage.Client
+	logger   *zap.Logger
+
+	mu              sync.Mutex
+	throughput      map[string]map[string]int64
+	probabilities   map[string]map[string]float64
+	lastCalculation time.Time
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates the adaptive strategies. The probabilities are persisted in the storage client when not nil.
+func New(settings Settings, client storage.Client, logger *zap.Logger) *Strategies {
+	return &Strategies{
+		settings:      settings,
+		client:        client,
+		logger:        logger,
+		throughput:    map[string]map[string]int64{},
+		probabilities: map[string]map[string]float64{},
+	}
+}
+
+// Start loads the persisted probabilities and starts calculating the probabilities periodically
+func (s *Strategies) Start(ctx context.Context) error {
+	if s.client != nil {
+		data, err := s.client.Get(ctx, probabilitiesKey)
+		if err != nil {
+			return err
+		}
+		if data != nil {
+			var probabilities map[string]map[string]float64
+			if err := json.Unmarshal(data, &probabilities); err != nil {
+				s.logger.Warn("Discarding the invalid persisted sampling probabilities", zap.Error(err))
+			} else {
+				s.probabilities = probabilities
+			}
+		}
+	}
+
+	s.lastCalculation = time.Now()
+	s.stopCh = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.settings.CalculationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				s.calculate(now)
+				s.persist(context.Background())
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops calculating the probabilities, and persists them
+func (s *Strategies) Shutdown(ctx context.Context) error {
+	if s.stopCh == nil {
+		return nil
+	}
+	close(s.stopCh)
+	s.wg.Wait()
+	s.stopCh = nil
+	return s.persist(ctx)
+}
+
+// RecordThroughput records root spans of an operation of a service
+func (s *Strategies) RecordThroughput(service, operation string, count int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	operations, ok := s.throughput[service]
+	if !ok {
+		operations = map[string]int64{}
+		s.throughput[service] = operations
+	}
+	if _, ok := operations[operation]; !ok && len(operations) >= maxOperationsPerService {
+		return
+	}
+	operations[operation] += count
+}
+
+// Get returns the sampling probabilities of a service
+func (s *Strategies) Get(service string) ServiceStrategy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	strategy := ServiceStrategy{
+		DefaultProbability: s.settings.InitialSamplingProbability,
+		Operations:         make(map[string]float64, len(s.probabilities[service])),
+	}
+	for operation, probability := range s.probabilities[service] {
+		strategy.Operations[operation] = probability
+	}
+	return strategy
+}
+
+// calculate updates the sampling probabilities from the throughput recorded since the last calculation.
+// The probability of an operation is scaled by the ratio between the targeted and the observed number of
+// sampled traces per second, and kept as is when no traffic was observed.
+func (s *Strategies) calculate(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := now.Sub(s.lastCalculation).Seconds()
+	s.lastCalculation = now
+	if elapsed <= 0 {
+		return
+	}
+
+	for service, operations := range s.throughput {
+		probabilities, ok := s.probabilities[service]
+		if !ok {
+			probabilities = map[string]float64{}
+			s.probabilities[service] = probabilities
+		}
+		for operation, count := range operations {
+			probability, ok := probabilities[operation]
+			if !ok {
+				probability = s.settings.InitialSamplingProbability
+			}
+
+			observed := float64(count) / elapsed
+			updated := probability * s.settings.TargetSamplesPerSecond / observed
+			updated = math.Min(updated, probability*maxIncreaseFactor)
+			updated = math.Max(math.Min(updated, 1), s.settings.MinSamplingProbability)
+			probabilities[operation] = updated
+		}
+	}
+	s.throughput = map[string]map[string]int64{}
+}
+
+func (s *Strategies) persist(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	data, err := json.Marshal(s.probabilities)
+	s.mu.Unlock()
+	if err == nil {
+		err = s.client.Set(ctx, probabilitiesKey, data)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to persist the sampling probabilities", zap.Error(err))
+	}
+	return err
+}
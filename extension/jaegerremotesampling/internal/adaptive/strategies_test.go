@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adaptive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+var testSettings = Settings{
+	TargetSamplesPerSecond:     1,
+	CalculationInterval:        time.Hour,
+	InitialSamplingProbability: 0.1,
+	MinSamplingProbability:     0.001,
+}
+
+func TestCalculate(t *testing.T) {
+	s := New(testSettings, nil, zap.NewNop())
+	start := time.Now()
+	s.lastCalculation = start
+
+	// 100 root spans per second sampled with the initial probability
+	s.RecordThroughput("frontend", "GET /", 6000)
+	// 0.1 root span per second sampled with the initial probability
+	s.RecordThroughput("frontend", "POST /cart", 6)
+	// 1 root span per second sampled with the initial probability
+	s.RecordThroughput("backend", "query", 60)
+	s.calculate(start.Add(time.Minute))
+
+	frontend := s.Get("frontend")
+	assert.Equal(t, 0.1, frontend.DefaultProbability)
+	assert.InDelta(t, 0.001, frontend.Operations["GET /"], 1e-9)
+	// the probability can at most double at each calculation
+	assert.InDelta(t, 0.2, frontend.Operations["POST /cart"], 1e-9)
+	assert.InDelta(t, 0.1, s.Get("backend").Operations["query"], 1e-9)
+	assert.Empty(t, s.Get("unknown").Operations)
+
+	// 0.5 root span per second sampled with 0.001, no traffic for the other operations
+	s.RecordThroughput("frontend", "GET /", 30)
+	s.calculate(start.Add(2 * time.Minute))
+
+	frontend = s.Get("frontend")
+	assert.InDelta(t, 0.002, frontend.Operations["GET /"], 1e-9)
+	assert.InDelta(t, 0.2, frontend.Operations["POST /cart"], 1e-9)
+
+	// the minimum probability bounds the probability of busy operations, the probability never exceeds 1
+	s.RecordThroughput("frontend", "GET /", 6000000)
+	s.RecordThroughput("frontend", "POST /cart", 1)
+	s.calculate(start.Add(3 * time.Minute))
+	s.RecordThroughput("frontend", "POST /cart", 1)
+	s.calculate(start.Add(4 * time.Minute))
+	s.RecordThroughput("frontend", "POST /cart", 1)
+	s.calculate(start.Add(5 * time.Minute))
+
+	frontend = s.Get("frontend")
+	assert.Equal(t, testSettings.MinSamplingProbability, frontend.Operations["GET /"])
+	assert.Equal(t, 1.0, frontend.Operations["POST /cart"])
+}
+
+func TestRecordThroughputMaxOperations(t *testing.T) {
+	s := New(testSettings, nil, zap.NewNop())
+	for i := 0; i < maxOperationsPerService+10; i++ {
+		s.RecordThroughput("frontend", time.Duration(i).String(), 1)
+	}
+	assert.Len(t, s.throughput["frontend"], maxOperationsPerService)
+}
+
+func TestPersistence(t *testing.T) {
+	client := newMemoryClient()
+
+	s := New(testSettings, client, zap.NewNop())
+	require.NoError(t, s.Start(context.Background()))
+	s.RecordThroughput("frontend", "GET /", 6000)
+	s.calculate(s.lastCalculation.Add(time.Minute))
+	require.NoError(t, s.Shutdown(context.Background()))
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	s = New(testSettings, client, zap.NewNop())
+	require.NoError(t, s.Start(context.Background()))
+	assert.InDelta(t, 0.001, s.Get("frontend").Operations["GET /"], 1e-9)
+	require.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestPersistenceInvalidData(t *testing.T) {
+	client := newMemoryClient()
+	require.NoError(t, client.Set(context.Background(), probabilitiesKey, []byte("invalid")))
+
+	s := New(testSettings, client, zap.NewNop())
+	require.NoError(t, s.Start(context.Background()))
+	assert.Empty(t, s.Get("frontend").Operations)
+	require.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestPersistenceError(t *testing.T) {
+	client := newMemoryClient()
+	client.err = errors.New("unavailable")
+
+	s := New(testSettings, client, zap.NewNop())
+	assert.EqualError(t, s.Start(context.Background()), "unavailable")
+}
+
+func TestCalculationLoop(t *testing.T) {
+	settings := testSettings
+	settings.CalculationInterval = time.Millisecond
+	client := newMemoryClient()
+
+	s := New(settings, client, zap.NewNop())
+	require.NoError(t, s.Start(context.Background()))
+	s.RecordThroughput("frontend", "GET /", 1000000)
+	assert.Eventually(t, func() bool {
+		return s.Get("frontend").Operations["GET /"] != 0
+	}, 10*time.Second, time.Millisecond)
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	data, err := client.Get(context.Background(), probabilitiesKey)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "GET /")
+}
+
+type memoryClient struct {
+	data map[string][]byte
+	err  error
+}
+
+var _ storage.Client = (*memoryClient)(nil)
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{data: map[string][]byte{}}
+}
+
+func (m *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	return m.data[key], m.err
+}
+
+func (m *memoryClient) Set(_ context.Context, key string, value []byte) error {
+	m.data[key] = value
+	return m.err
+}
+
+func (m *memoryClient) Delete(_ context.Context, key string) error {
+	delete(m.data, key)
+	return m.err
+}
+
+func (m *memoryClient) Batch(context.Context, ...storage.Operation) error {
+	return m.err
+}
+
+func (m *memoryClient) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+// Config configures the mTLS authenticator. The patterns are regular expressions which have to match a whole value.
+// When a list of patterns is set, at least one value of the corresponding identity field has to match one of them.
+// When no list is set, any verified client certificate is accepted.
+type Config struct {
+	// AllowedCommonNames are the patterns matched against the common name of the subject of the certificate.
+	AllowedCommonNames []string `mapstructure:"allowed_common_names"`
+
+	// AllowedOrganizationalUnits are the patterns matched against the organizational units of the subject of the certificate.
+	AllowedOrganizationalUnits []string `mapstructure:"allowed_organizational_units"`
+
+	// AllowedSANs are the patterns matched against the DNS names, email addresses, URIs and IP addresses
+	// of the subject alternative names of the certificate.
+	AllowedSANs []string `mapstructure:"allowed_sans"`
+
+	// ForwardedCertificateHeader is the header holding the URL-encoded PEM client certificate forwarded by a proxy
+	// terminating TLS, used for requests received without a TLS connection state from one of the TrustedProxies.
+	// The proxy has to overwrite the header sent by the clients. Disabled when empty.
+	ForwardedCertificateHeader string `mapstructure:"forwarded_certificate_header"`
+
+	// TrustedProxies are the IP addresses and CIDR ranges of the proxies allowed to forward client certificates.
+	// Required with ForwardedCertificateHeader.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+
+	// ClientCAFile is the path to the CA certificates the forwarded client certificates are verified against.
+	// Required with ForwardedCertificateHeader.
+	ClientCAFile string `mapstructure:"client_ca_file"`
+}
+
+var errForwardedCertificateSettings = errors.New("forwarded_certificate_header requires trusted_proxies and client_ca_file")
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if _, err := cfg.compile(); err != nil {
+		return err
+	}
+	if _, err := cfg.trustedProxies(); err != nil {
+		return err
+	}
+	if cfg.ForwardedCertificateHeader != "" && (len(cfg.TrustedProxies) == 0 || cfg.ClientCAFile == "") {
+		return errForwardedCertificateSettings
+	}
+	return nil
+}
+
+// identityPatterns are the compiled allow-lists of the configuration
+type identityPatterns struct {
+	commonNames         []*regexp.Regexp
+	organizationalUnits []*regexp.Regexp
+	sans                []*regexp.Regexp
+}
+
+func (cfg *Config) compile() (*identityPatterns, error) {
+	var (
+		patterns identityPatterns
+		err      error
+	)
+	if patterns.commonNames, err = compilePatterns("allowed_common_names", cfg.AllowedCommonNames); err != nil {
+		return nil, err
+	}
+	if patterns.organizationalUnits, err = compilePatterns("allowed_organizational_units", cfg.AllowedOrganizationalUnits); err != nil {
+		return nil, err
+	}
+	if patterns.sans, err = compilePatterns("allowed_sans", cfg.AllowedSANs); err != nil {
+		return nil, err
+	}
+	return &patterns, nil
+}
+
+func compilePatterns(field string, patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in %s: %w", pattern, field, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// trustedProxies parses the addresses and ranges of the trusted proxies, an address being a range of a single address
+func (cfg *Config) trustedProxies() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cfg.TrustedProxies))
+	for _, proxy := range cfg.TrustedProxies {
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid range %q in trusted_proxies: %w", proxy, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q in trusted_proxies: %w", proxy, err)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "allowlist"),
+			expected: &Config{
+				AllowedCommonNames:         []string{"agent-.*"},
+				AllowedOrganizationalUnits: []string{"observability", "platform"},
+				AllowedSANs:                []string{`.*\.example\.com`, "spiffe://example.com/.*"},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "proxy"),
+			expected: &Config{
+				ForwardedCertificateHeader: "X-SSL-Client-Cert",
+				TrustedProxies:             []string{"10.0.0.0/8", "192.168.1.10"},
+				ClientCAFile:               "ca.crt",
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "untrustedproxy"),
+			expectedErr: errForwardedCertificateSettings.Error(),
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidproxy"),
+			expectedErr: `invalid range "10.0.0.0/33" in trusted_proxies`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid"),
+			expectedErr: `invalid pattern "agent-(" in allowed_common_names`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
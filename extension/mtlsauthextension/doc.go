@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package mtlsauthextension implements a server authenticator mapping the identity of the verified client
+// certificate of gRPC requests, or of the certificate forwarded to HTTP receivers by a trusted proxy, to the
+// auth data of the client info, and authorizing it against allow-lists of patterns.
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
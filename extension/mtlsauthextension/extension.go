@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/extension/auth"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+var (
+	errNoCertificate                  = errors.New("no verified client certificate provided")
+	errIdentityNotAllowed             = errors.New("client certificate identity not allowed")
+	errInvalidForwardedCertificate    = errors.New("invalid forwarded client certificate")
+	errUnverifiedForwardedCertificate = errors.New("forwarded client certificate not verified")
+)
+
+type mtlsAuth struct {
+	patterns                   *identityPatterns
+	forwardedCertificateHeader string
+	trustedProxies             []netip.Prefix
+	clientCAs                  *x509.CertPool
+}
+
+func newServerAuthExtension(cfg *Config) (auth.Server, error) {
+	patterns, err := cfg.compile()
+	if err != nil {
+		return nil, err
+	}
+	trustedProxies, err := cfg.trustedProxies()
+	if err != nil {
+		return nil, err
+	}
+
+	ma := mtlsAuth{
+		patterns:                   patterns,
+		forwardedCertificateHeader: cfg.ForwardedCertificateHeader,
+		trustedProxies:             trustedProxies,
+	}
+	if cfg.ClientCAFile != "" {
+		if ma.clientCAs, err = loadCertPool(cfg.ClientCAFile); err != nil {
+			return nil, err
+		}
+	}
+	return auth.NewServer(
+		auth.WithServerAuthenticate(ma.authenticate),
+	), nil
+}
+
+func (ma *mtlsAuth) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	cert, err := ma.clientCertificate(ctx, headers)
+	if err != nil {
+		return ctx, err
+	}
+
+	identity := newAuthData(cert)
+	if !ma.patterns.allow(identity) {
+		return ctx, errIdentityNotAllowed
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = identity
+	return client.NewContext(ctx, cl), nil
+}
+
+// clientCertificate returns the verified client certificate of the TLS connection of a gRPC request. As the TLS
+// connection state of HTTP requests isn't made available to authenticators, the certificate forwarded by a trusted
+// proxy is used for requests without a TLS connection state, when enabled, once verified against the client CAs.
+func (ma *mtlsAuth) clientCertificate(ctx context.Context, headers map[string][]string) (*x509.Certificate, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+				return nil, errNoCertificate
+			}
+			return info.State.VerifiedChains[0][0], nil
+		}
+	}
+
+	if ma.forwardedCertificateHeader == "" || !ma.fromTrustedProxy(ctx) {
+		return nil, errNoCertificate
+	}
+	forwarded := getHeader(headers, ma.forwardedCertificateHeader)
+	if forwarded == "" {
+		return nil, errNoCertificate
+	}
+	cert, err := parseForwardedCertificate(forwarded)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{
+		Roots:     ma.clientCAs,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnverifiedForwardedCertificate, err)
+	}
+	return cert, nil
+}
+
+// fromTrustedProxy returns whether the request was sent from the address of a trusted proxy
+func (ma *mtlsAuth) fromTrustedProxy(ctx context.Context) bool {
+	addr, ok := remoteAddr(client.FromContext(ctx).Addr)
+	if !ok {
+		return false
+	}
+	for _, prefix := range ma.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteAddr returns the IP address of the client, as set by the receiver in the client info
+func remoteAddr(addr net.Addr) (netip.Addr, bool) {
+	switch a := addr.(type) {
+	case nil:
+		return netip.Addr{}, false
+	case *net.TCPAddr:
+		ip, ok := netip.AddrFromSlice(a.IP)
+		return ip.Unmap(), ok
+	case *net.IPAddr:
+		ip, ok := netip.AddrFromSlice(a.IP)
+		return ip.Unmap(), ok
+	}
+	addrPort, err := netip.ParseAddrPort(addr.String())
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addrPort.Addr().Unmap(), true
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	certs, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client_ca_file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(certs) {
+		return nil, fmt.Errorf("no certificate found in client_ca_file %q", path)
+	}
+	return pool, nil
+}
+
+func getHeader(headers map[string][]string, name string) string {
+	values, ok := headers[name]
+	if !ok {
+		for k, v := range headers {
+			if strings.EqualFold(k, name) {
+				values = v
+				break
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// parseForwardedCertificate parses a URL-encoded PEM certificate, as forwarded by proxies such as NGINX or Envoy
+func parseForwardedCertificate(forwarded string) (*x509.Certificate, error) {
+	decoded, err := url.PathUnescape(forwarded)
+	if err != nil {
+		return nil, errInvalidForwardedCertificate
+	}
+	block, _ := pem.Decode([]byte(decoded))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errInvalidForwardedCertificate
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errInvalidForwardedCertificate
+	}
+	return cert, nil
+}
+
+// allow returns whether an identity matches the allow-lists
+func (p *identityPatterns) allow(identity *authData) bool {
+	return matchAny(p.commonNames, identity.commonName) &&
+		matchAny(p.organizationalUnits, identity.organizationalUnits...) &&
+		matchAny(p.sans, identity.sans()...)
+}
+
+// matchAny returns whether any value matches any pattern, or true when there are no patterns
+func matchAny(patterns []*regexp.Regexp, values ...string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, value := range values {
+		for _, pattern := range patterns {
+			if pattern.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+type authData struct {
+	subject             string
+	commonName          string
+	organizationalUnits []string
+	dnsNames            []string
+	emailAddresses      []string
+	uris                []string
+	ipAddresses         []string
+}
+
+func newAuthData(cert *x509.Certificate) *authData {
+	identity := &authData{
+		subject:             cert.Subject.String(),
+		commonName:          cert.Subject.CommonName,
+		organizationalUnits: cert.Subject.OrganizationalUnit,
+		dnsNames:            cert.DNSNames,
+		emailAddresses:      cert.EmailAddresses,
+	}
+	for _, uri := range cert.URIs {
+		identity.uris = append(identity.uris, uri.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		identity.ipAddresses = append(identity.ipAddresses, ip.String())
+	}
+	return identity
+}
+
+// sans returns the subject alternative names of all types
+func (a *authData) sans() []string {
+	sans := make([]string, 0, len(a.dnsNames)+len(a.emailAddresses)+len(a.uris)+len(a.ipAddresses))
+	sans = append(sans, a.dnsNames...)
+	sans = append(sans, a.emailAddresses...)
+	sans = append(sans, a.uris...)
+	return append(sans, a.ipAddresses...)
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	switch name {
+	case "subject":
+		return a.subject
+	case "common_name":
+		return a.commonName
+	case "organizational_units":
+		return a.organizationalUnits
+	case "dns_names":
+		return a.dnsNames
+	case "email_addresses":
+		return a.emailAddresses
+	case "uris":
+		return a.uris
+	case "ip_addresses":
+		return a.ipAddresses
+	default:
+		return nil
+	}
+}
+
+func (*authData) GetAttributeNames() []string {
+	return []string{"subject", "common_name", "organizational_units", "dns_names", "email_addresses", "uris", "ip_addresses"}
+}
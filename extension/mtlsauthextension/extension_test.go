@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestAuthenticate(t *testing.T) {
+	cert := newCertificate(t, pkix.Name{CommonName: "agent-1", OrganizationalUnit: []string{"observability"}})
+
+	ext, err := newServerAuthExtension(&Config{
+		AllowedCommonNames:         []string{"agent-.*"},
+		AllowedOrganizationalUnits: []string{"observability", "platform"},
+		AllowedSANs:                []string{`.*\.example\.com`},
+	})
+	require.NoError(t, err)
+
+	ctx, err := ext.Authenticate(tlsContext(cert, true), map[string][]string{})
+	require.NoError(t, err)
+
+	cl := client.FromContext(ctx)
+	require.NotNil(t, cl.Auth)
+	assert.Equal(t, "CN=agent-1,OU=observability", cl.Auth.GetAttribute("subject"))
+	assert.Equal(t, "agent-1", cl.Auth.GetAttribute("common_name"))
+	assert.Equal(t, []string{"observability"}, cl.Auth.GetAttribute("organizational_units"))
+	assert.Equal(t, []string{"agent-1.example.com"}, cl.Auth.GetAttribute("dns_names"))
+	assert.Equal(t, []string{"agent@example.com"}, cl.Auth.GetAttribute("email_addresses"))
+	assert.Equal(t, []string{"spiffe://example.com/agent"}, cl.Auth.GetAttribute("uris"))
+	assert.Equal(t, []string{"10.0.0.1"}, cl.Auth.GetAttribute("ip_addresses"))
+	assert.Nil(t, cl.Auth.GetAttribute("unknown"))
+	assert.Len(t, cl.Auth.GetAttributeNames(), 7)
+}
+
+func TestAuthenticateAllowLists(t *testing.T) {
+	cert := newCertificate(t, pkix.Name{CommonName: "agent-1", OrganizationalUnit: []string{"observability"}})
+
+	tests := []struct {
+		desc    string
+		cfg     *Config
+		allowed bool
+	}{
+		{
+			desc:    "no allow-lists",
+			cfg:     &Config{},
+			allowed: true,
+		},
+		{
+			desc:    "patterns match the whole value",
+			cfg:     &Config{AllowedCommonNames: []string{"agent"}},
+			allowed: false,
+		},
+		{
+			desc:    "any organizational unit",
+			cfg:     &Config{AllowedOrganizationalUnits: []string{"platform", "observ.*"}},
+			allowed: true,
+		},
+		{
+			desc:    "any subject alternative name",
+			cfg:     &Config{AllowedSANs: []string{"10\\.0\\.0\\.1"}},
+			allowed: true,
+		},
+		{
+			desc:    "all allow-lists must match",
+			cfg:     &Config{AllowedCommonNames: []string{"agent-.*"}, AllowedSANs: []string{"spiffe://other/.*"}},
+			allowed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ext, err := newServerAuthExtension(tt.cfg)
+			require.NoError(t, err)
+
+			_, err = ext.Authenticate(tlsContext(cert, true), map[string][]string{})
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errIdentityNotAllowed)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithoutVerifiedCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.issue(t, pkix.Name{CommonName: "agent-1"}, x509.ExtKeyUsageClientAuth)
+	ext, err := newServerAuthExtension(&Config{
+		ForwardedCertificateHeader: "X-SSL-Client-Cert",
+		TrustedProxies:             []string{"10.0.0.0/8"},
+		ClientCAFile:               ca.file,
+	})
+	require.NoError(t, err)
+
+	// The certificate was not verified by the TLS server
+	_, err = ext.Authenticate(tlsContext(cert, false), map[string][]string{})
+	assert.ErrorIs(t, err, errNoCertificate)
+
+	// A forwarded certificate is ignored when a TLS connection state is available
+	ctx := client.NewContext(tlsContext(cert, false), client.Info{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2")}})
+	_, err = ext.Authenticate(ctx, map[string][]string{"X-Ssl-Client-Cert": {forwardedCertificate(cert)}})
+	assert.ErrorIs(t, err, errNoCertificate)
+
+	// The request was received without TLS, nor forwarded certificate
+	_, err = ext.Authenticate(context.Background(), map[string][]string{})
+	assert.ErrorIs(t, err, errNoCertificate)
+}
+
+func TestAuthenticateForwardedCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.issue(t, pkix.Name{CommonName: "agent-1"}, x509.ExtKeyUsageClientAuth)
+	cfg := &Config{
+		ForwardedCertificateHeader: "X-SSL-Client-Cert",
+		TrustedProxies:             []string{"10.0.0.0/8", "2001:db8::1"},
+		ClientCAFile:               ca.file,
+	}
+	require.NoError(t, cfg.Validate())
+	ext, err := newServerAuthExtension(cfg)
+	require.NoError(t, err)
+
+	fromProxy := client.NewContext(context.Background(), client.Info{Addr: &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 4318}})
+	ctx, err := ext.Authenticate(fromProxy, map[string][]string{"x-ssl-client-cert": {forwardedCertificate(cert)}})
+	require.NoError(t, err)
+	assert.Equal(t, "agent-1", client.FromContext(ctx).Auth.GetAttribute("common_name"))
+
+	fromIPv6Proxy := client.NewContext(context.Background(), client.Info{Addr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 4318}})
+	_, err = ext.Authenticate(fromIPv6Proxy, map[string][]string{"X-SSL-Client-Cert": {forwardedCertificate(cert)}})
+	assert.NoError(t, err)
+
+	_, err = ext.Authenticate(fromProxy, map[string][]string{"X-SSL-Client-Cert": {"invalid"}})
+	assert.ErrorIs(t, err, errInvalidForwardedCertificate)
+
+	_, err = ext.Authenticate(fromProxy, map[string][]string{"X-SSL-Client-Cert": {"%zz"}})
+	assert.ErrorIs(t, err, errInvalidForwardedCertificate)
+
+	// A forged self-signed certificate with the same identity is rejected
+	forged := newCertificate(t, pkix.Name{CommonName: "agent-1"})
+	_, err = ext.Authenticate(fromProxy, map[string][]string{"X-SSL-Client-Cert": {forwardedCertificate(forged)}})
+	assert.ErrorIs(t, err, errUnverifiedForwardedCertificate)
+
+	// A certificate of the CA which isn't meant for client authentication is rejected
+	server := ca.issue(t, pkix.Name{CommonName: "agent-1"}, x509.ExtKeyUsageServerAuth)
+	_, err = ext.Authenticate(fromProxy, map[string][]string{"X-SSL-Client-Cert": {forwardedCertificate(server)}})
+	assert.ErrorIs(t, err, errUnverifiedForwardedCertificate)
+
+	// The forwarded certificate is ignored when not sent by a trusted proxy
+	fromClient := client.NewContext(context.Background(), client.Info{Addr: &net.TCPAddr{IP: net.ParseIP("192.168.0.1"), Port: 4318}})
+	_, err = ext.Authenticate(fromClient, map[string][]string{"X-SSL-Client-Cert": {forwardedCertificate(cert)}})
+	assert.ErrorIs(t, err, errNoCertificate)
+	_, err = ext.Authenticate(context.Background(), map[string][]string{"X-SSL-Client-Cert": {forwardedCertificate(cert)}})
+	assert.ErrorIs(t, err, errNoCertificate)
+
+	// The forwarded certificate is ignored when disabled
+	ext, err = newServerAuthExtension(&Config{})
+	require.NoError(t, err)
+	_, err = ext.Authenticate(fromProxy, map[string][]string{"X-SSL-Client-Cert": {forwardedCertificate(cert)}})
+	assert.ErrorIs(t, err, errNoCertificate)
+}
+
+func TestNewServerAuthExtensionClientCAFile(t *testing.T) {
+	_, err := newServerAuthExtension(&Config{ClientCAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorContains(t, err, "failed to read client_ca_file")
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(empty, nil, 0600))
+	_, err = newServerAuthExtension(&Config{ClientCAFile: empty})
+	assert.ErrorContains(t, err, "no certificate found in client_ca_file")
+}
+
+// newCertificate returns a self-signed client certificate
+func newCertificate(t *testing.T, subject pkix.Name) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := certificateTemplate(t, subject, x509.ExtKeyUsageClientAuth)
+	return createCertificate(t, template, template, &key.PublicKey, key)
+}
+
+// testCA is a certificate authority issuing client certificates, written to file
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	ca := &testCA{
+		cert: createCertificate(t, template, template, &key.PublicKey, key),
+		key:  key,
+		file: filepath.Join(t.TempDir(), "ca.pem"),
+	}
+	require.NoError(t, os.WriteFile(ca.file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	return ca
+}
+
+func (ca *testCA) issue(t *testing.T, subject pkix.Name, usage x509.ExtKeyUsage) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return createCertificate(t, certificateTemplate(t, subject, usage), ca.cert, &key.PublicKey, ca.key)
+}
+
+func certificateTemplate(t *testing.T, subject pkix.Name, usage x509.ExtKeyUsage) *x509.Certificate {
+	spiffe, err := url.Parse("spiffe://example.com/agent")
+	require.NoError(t, err)
+	return &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		Subject:        subject,
+		NotBefore:      time.Now(),
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{usage},
+		DNSNames:       []string{subject.CommonName + ".example.com"},
+		EmailAddresses: []string{"agent@example.com"},
+		URIs:           []*url.URL{spiffe},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+	}
+}
+
+func createCertificate(t *testing.T, template, parent *x509.Certificate, pub *ecdsa.PublicKey, priv *ecdsa.PrivateKey) *x509.Certificate {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func tlsContext(cert *x509.Certificate, verified bool) context.Context {
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func forwardedCertificate(cert *x509.Certificate) string {
+	return url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+}
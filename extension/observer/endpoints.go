@@ -6,6 +6,7 @@ package observer // import "github.com/open-telemetry/opentelemetry-collector-co
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"reflect"
 )
 
@@ -29,6 +30,10 @@ const (
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
 	ContainerType EndpointType = "container"
+	// ProcessType is a process endpoint.
+	ProcessType EndpointType = "process"
+	// FileType is a file endpoint.
+	FileType EndpointType = "file"
 )
 
 var (
@@ -37,6 +42,8 @@ var (
 	_ EndpointDetails = (*K8sNode)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
+	_ EndpointDetails = (*Process)(nil)
+	_ EndpointDetails = (*File)(nil)
 )
 
 // EndpointDetails provides additional context about an endpoint such as a Pod or Port.
@@ -262,3 +269,54 @@ func (n *K8sNode) Env() EndpointEnv {
 func (n *K8sNode) Type() EndpointType {
 	return K8sNodeType
 }
+
+// Process is a process running on a host.
+type Process struct {
+	// PID is the process identifier.
+	PID int32
+	// Name of the process.
+	Name string
+	// Command used to invoke the process, including its arguments.
+	Command string
+	// Executable is the absolute path of the process executable. If host_observer
+	// is unable to resolve it, this value is an empty string.
+	Executable string
+	// User is the name of the user owning the process. If host_observer
+	// is unable to resolve it, this value is an empty string.
+	User string
+	// Ports are the TCP and UDP ports the process is listening on, in ascending order.
+	Ports []uint16
+}
+
+func (p *Process) Env() EndpointEnv {
+	return map[string]interface{}{
+		"pid":          p.PID,
+		"process_name": p.Name,
+		"command":      p.Command,
+		"executable":   p.Executable,
+		"user":         p.User,
+		"ports":        p.Ports,
+	}
+}
+
+func (p *Process) Type() EndpointType {
+	return ProcessType
+}
+
+// File is a file discovered on a host.
+type File struct {
+	// Path is the absolute path of the file.
+	Path string
+}
+
+func (f *File) Env() EndpointEnv {
+	return map[string]interface{}{
+		"path":      f.Path,
+		"name":      filepath.Base(f.Path),
+		"directory": filepath.Dir(f.Path),
+	}
+}
+
+func (f *File) Type() EndpointType {
+	return FileType
+}
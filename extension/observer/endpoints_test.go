@@ -194,6 +194,50 @@ func TestEndpointEnv(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Process",
+			endpoint: Endpoint{
+				ID:     EndpointID("process_endpoint_id"),
+				Target: "127.0.0.1:9999",
+				Details: &Process{
+					PID:        1234,
+					Name:       "java",
+					Command:    "java -jar app.jar",
+					Executable: "/usr/bin/java",
+					User:       "app",
+					Ports:      []uint16{8080, 9999},
+				},
+			},
+			want: EndpointEnv{
+				"type":         "process",
+				"id":           "process_endpoint_id",
+				"endpoint":     "127.0.0.1:9999",
+				"pid":          int32(1234),
+				"process_name": "java",
+				"command":      "java -jar app.jar",
+				"executable":   "/usr/bin/java",
+				"user":         "app",
+				"ports":        []uint16{8080, 9999},
+			},
+		},
+		{
+			name: "File",
+			endpoint: Endpoint{
+				ID:     EndpointID("file_endpoint_id"),
+				Target: "/var/log/app/app.log",
+				Details: &File{
+					Path: "/var/log/app/app.log",
+				},
+			},
+			want: EndpointEnv{
+				"type":      "file",
+				"id":        "file_endpoint_id",
+				"endpoint":  "/var/log/app/app.log",
+				"path":      "/var/log/app/app.log",
+				"name":      "app.log",
+				"directory": "/var/log/app",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
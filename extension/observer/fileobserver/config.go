@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"go.opentelemetry.io/collector/component"
+)
+
+var errNoInclude = errors.New("`include` must not be empty")
+
+// Config defines configuration for file observer.
+type Config struct {
+	// Include is a list of glob patterns matching the files to observe.
+	Include []string `mapstructure:"include"`
+	// Exclude is a list of glob patterns matching files to ignore among
+	// the included ones.
+	Exclude []string `mapstructure:"exclude"`
+
+	// RefreshInterval determines how often the observer looks for
+	// files appearing or disappearing.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Include) == 0 {
+		return errNoInclude
+	}
+	for _, include := range cfg.Include {
+		if _, err := doublestar.PathMatch(include, "matchstring"); err != nil {
+			return fmt.Errorf("parse include glob %q: %w", include, err)
+		}
+	}
+	for _, exclude := range cfg.Exclude {
+		if _, err := doublestar.PathMatch(exclude, "matchstring"); err != nil {
+			return fmt.Errorf("parse exclude glob %q: %w", exclude, err)
+		}
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("`refresh_interval` must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+type fileObserver struct {
+	*observer.EndpointsWatcher
+}
+
+type endpointsLister struct {
+	logger       *zap.Logger
+	observerName string
+	include      []string
+	exclude      []string
+}
+
+var _ extension.Extension = (*fileObserver)(nil)
+
+func newObserver(params extension.CreateSettings, config *Config) *fileObserver {
+	return &fileObserver{
+		EndpointsWatcher: observer.NewEndpointsWatcher(
+			endpointsLister{
+				logger:       params.Logger,
+				observerName: params.ID.String(),
+				include:      config.Include,
+				exclude:      config.Exclude,
+			},
+			config.RefreshInterval,
+			params.Logger,
+		),
+	}
+}
+
+func (f *fileObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (f *fileObserver) Shutdown(context.Context) error {
+	f.StopListAndWatch()
+	return nil
+}
+
+// ListEndpoints returns a file endpoint for every regular file matching
+// the include patterns and none of the exclude ones.
+func (e endpointsLister) ListEndpoints() []observer.Endpoint {
+	var endpoints []observer.Endpoint
+	seen := make(map[string]struct{})
+	for _, include := range e.include {
+		matches, err := doublestar.FilepathGlob(include, doublestar.WithFilesOnly())
+		if err != nil {
+			e.logger.Error("Could not match files", zap.String("include", include), zap.Error(err))
+			continue
+		}
+
+	MATCHES:
+		for _, match := range matches {
+			for _, exclude := range e.exclude {
+				if excluded, _ := doublestar.PathMatch(exclude, match); excluded {
+					continue MATCHES
+				}
+			}
+
+			path, err := filepath.Abs(match)
+			if err != nil {
+				e.logger.Warn("Could not resolve file path (skipping)", zap.String("path", match), zap.Error(err))
+				continue
+			}
+			if _, ok := seen[path]; ok {
+				continue
+			}
+			seen[path] = struct{}{}
+
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:      observer.EndpointID(fmt.Sprintf("(%s)%s", e.observerName, path)),
+				Target:  path,
+				Details: &observer.File{Path: path},
+			})
+		}
+	}
+	return endpoints
+}
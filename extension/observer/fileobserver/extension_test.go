@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestListEndpoints(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app.log", "debug.log", "app.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("line\n"), 0600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "dir.log"), 0700))
+
+	e := endpointsLister{
+		logger:       zap.NewNop(),
+		observerName: "file_observer/1",
+		include:      []string{filepath.Join(dir, "*.log"), filepath.Join(dir, "app.*")},
+		exclude:      []string{filepath.Join(dir, "debug.log")},
+	}
+
+	appLog := filepath.Join(dir, "app.log")
+	appTxt := filepath.Join(dir, "app.txt")
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:      observer.EndpointID("(file_observer/1)" + appLog),
+			Target:  appLog,
+			Details: &observer.File{Path: appLog},
+		},
+		{
+			ID:      observer.EndpointID("(file_observer/1)" + appTxt),
+			Target:  appTxt,
+			Details: &observer.File{Path: appTxt},
+		},
+	}, e.ListEndpoints())
+}
+
+func TestFileObserver(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "*.log")}
+	cfg.RefreshInterval = 10 * time.Millisecond
+
+	obs := newObserver(extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, obs.Start(context.Background(), componenttest.NewNopHost()))
+
+	mn := &mockNotifier{endpoints: map[observer.EndpointID]observer.Endpoint{}}
+	obs.ListAndWatch(mn)
+
+	require.NoError(t, os.WriteFile(path, []byte("line\n"), 0600))
+	require.Eventually(t, func() bool { return mn.has(path) }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.Remove(path))
+	require.Eventually(t, func() bool { return !mn.has(path) }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, obs.Shutdown(context.Background()))
+}
+
+type mockNotifier struct {
+	mu        sync.Mutex
+	endpoints map[observer.EndpointID]observer.Endpoint
+}
+
+func (m *mockNotifier) ID() observer.NotifyID {
+	return "mockNotifier"
+}
+
+func (m *mockNotifier) OnAdd(added []observer.Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range added {
+		m.endpoints[e.ID] = e
+	}
+}
+
+func (m *mockNotifier) OnRemove(removed []observer.Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range removed {
+		delete(m.endpoints, e.ID)
+	}
+}
+
+func (m *mockNotifier) OnChange(changed []observer.Endpoint) {
+	m.OnAdd(changed)
+}
+
+func (m *mockNotifier) has(path string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.endpoints {
+		if e.Target == path {
+			return true
+		}
+	}
+	return false
+}
@@ -13,4 +13,8 @@ type Config struct {
 	// RefreshInterval determines how frequency at which the observer
 	// needs to poll for collecting information about new processes.
 	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
+	// ObserveProcesses makes the observer also emit a process endpoint for
+	// every process running on the host, in addition to its listening ports.
+	ObserveProcesses bool `mapstructure:"observe_processes"`
 }
@@ -30,7 +30,8 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "all_settings"),
 			expected: &Config{
-				RefreshInterval: 20 * time.Second,
+				RefreshInterval:  20 * time.Second,
+				ObserveProcesses: true,
 			},
 		},
 	}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"sort"
 	"syscall"
 
 	"github.com/shirou/gopsutil/v3/net"
@@ -23,11 +24,13 @@ type hostObserver struct {
 }
 
 type endpointsLister struct {
-	logger       *zap.Logger
-	observerName string
+	logger           *zap.Logger
+	observerName     string
+	observeProcesses bool
 
 	// For testing
 	getConnections        func() ([]net.ConnectionStat, error)
+	getPIDs               func() ([]int32, error)
 	getProcess            func(pid int32) (*process.Process, error)
 	collectProcessDetails func(proc *process.Process) (*processDetails, error)
 }
@@ -40,7 +43,9 @@ func newObserver(params extension.CreateSettings, config *Config) (extension.Ext
 			endpointsLister{
 				logger:                params.Logger,
 				observerName:          params.ID.String(),
+				observeProcesses:      config.ObserveProcesses,
 				getConnections:        getConnections,
+				getPIDs:               process.Pids,
 				getProcess:            process.NewProcess,
 				collectProcessDetails: collectProcessDetails,
 			},
@@ -68,7 +73,11 @@ func (e endpointsLister) ListEndpoints() []observer.Endpoint {
 		return nil
 	}
 
-	return e.collectEndpoints(conns)
+	endpoints := e.collectEndpoints(conns)
+	if e.observeProcesses {
+		endpoints = append(endpoints, e.collectProcessEndpoints(conns)...)
+	}
+	return endpoints
 }
 
 func getConnections() (conns []net.ConnectionStat, err error) {
@@ -89,14 +98,7 @@ func (e endpointsLister) collectEndpoints(conns []net.ConnectionStat) []observer
 	connsByPID := make(map[int32][]*net.ConnectionStat)
 	for i := range conns {
 		c := conns[i]
-		isIPSocket := c.Family == syscall.AF_INET || c.Family == syscall.AF_INET6
-		isTCPOrUDP := c.Type == syscall.SOCK_STREAM || c.Type == syscall.SOCK_DGRAM
-		// UDP doesn't have any status
-		isUDPOrListening := c.Type == syscall.SOCK_DGRAM || c.Status == "LISTEN"
-		// UDP is "listening" when it has a remote port of 0
-		isTCPOrHasNoRemotePort := c.Type == syscall.SOCK_STREAM || c.Raddr.Port == 0
-
-		if !isIPSocket || !isTCPOrUDP || !isUDPOrListening || !isTCPOrHasNoRemotePort {
+		if !isListening(&c) {
 			continue
 		}
 
@@ -174,6 +176,85 @@ func (e endpointsLister) collectEndpoints(conns []net.ConnectionStat) []observer
 	return endpoints
 }
 
+// collectProcessEndpoints returns a process endpoint for every process running
+// on the host, along with the ports each of them is listening on.
+func (e endpointsLister) collectProcessEndpoints(conns []net.ConnectionStat) []observer.Endpoint {
+	pids, err := e.getPIDs()
+	if err != nil {
+		e.logger.Error("Could not list processes", zap.Error(err))
+		return nil
+	}
+
+	portsByPID := make(map[int32][]uint16)
+	for i := range conns {
+		c := conns[i]
+		if c.Pid == 0 || !isListening(&c) {
+			continue
+		}
+		portsByPID[c.Pid] = append(portsByPID[c.Pid], uint16(c.Laddr.Port))
+	}
+
+	endpoints := make([]observer.Endpoint, 0, len(pids))
+	for _, pid := range pids {
+		proc, err := e.getProcess(pid)
+		if err != nil {
+			// Processes routinely exit between listing and inspection.
+			continue
+		}
+
+		pd, err := e.collectProcessDetails(proc)
+		if err != nil {
+			e.logger.Debug("Failed collecting process details (skipping)",
+				zap.Int32("pid", pid), zap.Error(err),
+			)
+			continue
+		}
+
+		endpoints = append(endpoints, observer.Endpoint{
+			ID: observer.EndpointID(fmt.Sprintf("(%s)process-%d", e.observerName, pid)),
+			Details: &observer.Process{
+				PID:        pid,
+				Name:       pd.name,
+				Command:    pd.args,
+				Executable: pd.executable,
+				User:       pd.user,
+				Ports:      uniquePorts(portsByPID[pid]),
+			},
+		})
+	}
+
+	return endpoints
+}
+
+// isListening returns whether the connection is a TCP or UDP socket
+// accepting traffic on an IP address.
+func isListening(c *net.ConnectionStat) bool {
+	isIPSocket := c.Family == syscall.AF_INET || c.Family == syscall.AF_INET6
+	isTCPOrUDP := c.Type == syscall.SOCK_STREAM || c.Type == syscall.SOCK_DGRAM
+	// UDP doesn't have any status
+	isUDPOrListening := c.Type == syscall.SOCK_DGRAM || c.Status == "LISTEN"
+	// UDP is "listening" when it has a remote port of 0
+	isTCPOrHasNoRemotePort := c.Type == syscall.SOCK_STREAM || c.Raddr.Port == 0
+
+	return isIPSocket && isTCPOrUDP && isUDPOrListening && isTCPOrHasNoRemotePort
+}
+
+// uniquePorts sorts the ports and drops duplicates, e.g. the same port
+// listened on over both IPv4 and IPv6, so endpoints compare stably.
+func uniquePorts(ports []uint16) []uint16 {
+	if len(ports) == 0 {
+		return nil
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	out := ports[:1]
+	for _, p := range ports[1:] {
+		if p != out[len(out)-1] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
 type connectionDetails struct {
 	ip        string
 	isIPv6    bool
@@ -212,8 +293,10 @@ func collectConnectionDetails(c *net.ConnectionStat) connectionDetails {
 }
 
 type processDetails struct {
-	name string
-	args string
+	name       string
+	args       string
+	executable string
+	user       string
 }
 
 func collectProcessDetails(proc *process.Process) (*processDetails, error) {
@@ -227,9 +310,16 @@ func collectProcessDetails(proc *process.Process) (*processDetails, error) {
 		return nil, fmt.Errorf("could not get process args: %w", err)
 	}
 
+	// The executable and owner are best effort since resolving them may
+	// require more privileges than the process name and arguments.
+	executable, _ := proc.Exe()
+	user, _ := proc.Username()
+
 	return &processDetails{
-		name: name,
-		args: args,
+		name:       name,
+		args:       args,
+		executable: executable,
+		user:       user,
 	}, nil
 }
 
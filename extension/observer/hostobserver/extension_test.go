@@ -538,3 +538,116 @@ func TestCollectEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectProcessEndpoints(t *testing.T) {
+	conns := []psnet.ConnectionStat{
+		{
+			Family: syscall.AF_INET,
+			Type:   syscall.SOCK_STREAM,
+			Laddr:  psnet.Addr{IP: "0.0.0.0", Port: 9999},
+			Status: "LISTEN",
+			Pid:    42,
+		},
+		{
+			Family: syscall.AF_INET6,
+			Type:   syscall.SOCK_STREAM,
+			Laddr:  psnet.Addr{IP: "::", Port: 9999},
+			Status: "LISTEN",
+			Pid:    42,
+		},
+		{
+			Family: syscall.AF_INET,
+			Type:   syscall.SOCK_DGRAM,
+			Laddr:  psnet.Addr{IP: "127.0.0.1", Port: 8125},
+			Pid:    42,
+		},
+		{
+			Family: syscall.AF_INET,
+			Type:   syscall.SOCK_STREAM,
+			Laddr:  psnet.Addr{IP: "127.0.0.1", Port: 44444},
+			Status: "ESTABLISHED",
+			Pid:    42,
+		},
+	}
+
+	e := endpointsLister{
+		logger:           zap.NewNop(),
+		observerName:     "host_observer/1",
+		observeProcesses: true,
+		getPIDs: func() ([]int32, error) {
+			return []int32{1, 42, 9999}, nil
+		},
+		getProcess: func(pid int32) (*process.Process, error) {
+			if pid == 9999 {
+				return nil, errors.New("process exited")
+			}
+			return &process.Process{Pid: pid}, nil
+		},
+		collectProcessDetails: func(proc *process.Process) (*processDetails, error) {
+			if proc.Pid == 1 {
+				return &processDetails{name: "init", args: "/sbin/init", executable: "/sbin/init", user: "root"}, nil
+			}
+			return &processDetails{name: "java", args: "java -jar app.jar", executable: "/usr/bin/java", user: "app"}, nil
+		},
+	}
+
+	want := []observer.Endpoint{
+		{
+			ID: observer.EndpointID("(host_observer/1)process-1"),
+			Details: &observer.Process{
+				PID:        1,
+				Name:       "init",
+				Command:    "/sbin/init",
+				Executable: "/sbin/init",
+				User:       "root",
+			},
+		},
+		{
+			ID: observer.EndpointID("(host_observer/1)process-42"),
+			Details: &observer.Process{
+				PID:        42,
+				Name:       "java",
+				Command:    "java -jar app.jar",
+				Executable: "/usr/bin/java",
+				User:       "app",
+				Ports:      []uint16{8125, 9999},
+			},
+		},
+	}
+	assert.Equal(t, want, e.collectProcessEndpoints(conns))
+
+	e.getPIDs = func() ([]int32, error) {
+		return nil, errors.New("always fail")
+	}
+	assert.Empty(t, e.collectProcessEndpoints(conns))
+}
+
+func TestListEndpointsObservesSelfProcess(t *testing.T) {
+	tcp, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.ParseIP("127.0.0.1")})
+	require.NoError(t, err)
+	defer tcp.Close()
+
+	e := endpointsLister{
+		logger:                zap.NewNop(),
+		observerName:          "host_observer/1",
+		observeProcesses:      true,
+		getConnections:        getConnections,
+		getPIDs:               process.Pids,
+		getProcess:            process.NewProcess,
+		collectProcessDetails: collectProcessDetails,
+	}
+
+	id := observer.EndpointID(fmt.Sprintf("(host_observer/1)process-%d", selfPid))
+	var self *observer.Process
+	for _, endpoint := range e.ListEndpoints() {
+		if endpoint.ID == id {
+			self = endpoint.Details.(*observer.Process)
+		}
+	}
+	require.NotNil(t, self)
+	assert.Equal(t, int32(selfPid), self.PID)
+	assert.Equal(t, filepath.Base(exe), self.Name)
+	if runtime.GOOS == "linux" {
+		assert.Contains(t, self.Ports, uint16(tcp.Addr().(*net.TCPAddr).Port))
+	}
+}
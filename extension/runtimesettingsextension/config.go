@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimesettingsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+var (
+	errNoSource            = errors.New("one of `file` or `http` must be set")
+	errMultipleSources     = errors.New("only one of `file` or `http` can be set")
+	errNoPath              = errors.New("`file.path` must not be empty")
+	errNoEndpoint          = errors.New("`http.endpoint` must not be empty")
+	errInvalidPollInterval = errors.New("`http.poll_interval` must be positive")
+)
+
+// Config has the configuration for the runtime settings extension.
+type Config struct {
+	// File reads the settings from a local file, reloaded whenever it changes.
+	File *FileSettings `mapstructure:"file"`
+
+	// HTTP polls the settings from an HTTP endpoint.
+	HTTP *HTTPSettings `mapstructure:"http"`
+}
+
+// FileSettings defines the file the settings are read from.
+type FileSettings struct {
+	// Path of a YAML or JSON file holding a mapping of the settings.
+	Path string `mapstructure:"path"`
+}
+
+// HTTPSettings defines the HTTP endpoint the settings are polled from.
+type HTTPSettings struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// PollInterval is the interval at which the endpoint is polled. Defaults to 30s.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	switch {
+	case cfg.File == nil && cfg.HTTP == nil:
+		return errNoSource
+	case cfg.File != nil && cfg.HTTP != nil:
+		return errMultipleSources
+	case cfg.File != nil && cfg.File.Path == "":
+		return errNoPath
+	case cfg.HTTP != nil && cfg.HTTP.Endpoint == "":
+		return errNoEndpoint
+	case cfg.HTTP != nil && cfg.HTTP.PollInterval < 0:
+		return errInvalidPollInterval
+	}
+	return nil
+}
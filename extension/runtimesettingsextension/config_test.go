@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimesettingsextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr error
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "file"),
+			expected: &Config{
+				File: &FileSettings{
+					Path: "/etc/otelcol/runtime.yaml",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "http"),
+			expected: &Config{
+				HTTP: &HTTPSettings{
+					HTTPClientSettings: confighttp.HTTPClientSettings{
+						Endpoint: "https://settings.example.com/otelcol",
+					},
+					PollInterval: 10 * time.Second,
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "none"),
+			expectedErr: errNoSource,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "both"),
+			expectedErr: errMultipleSources,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_path"),
+			expectedErr: errNoPath,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_endpoint"),
+			expectedErr: errNoEndpoint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
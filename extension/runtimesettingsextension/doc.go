@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package runtimesettingsextension implements an extension exposing settings
+// that can change while the collector is running, read from a watched file or
+// polled from an HTTP endpoint.
+package runtimesettingsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension"
@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimesettingsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
+)
+
+const (
+	defaultPollInterval   = 30 * time.Second
+	defaultRequestTimeout = 10 * time.Second
+)
+
+var errEmptySettingsFile = errors.New("empty settings file")
+
+// maxResponseSize bounds the size of the settings read from the HTTP endpoint.
+const maxResponseSize = 4 << 20
+
+type runtimeSettings struct {
+	cfg       *Config
+	id        component.ID
+	logger    *zap.Logger
+	telemetry component.TelemetrySettings
+
+	muValues sync.RWMutex
+	values   values
+
+	muSubscribers sync.Mutex
+	subscribers   map[int]func()
+	nextID        int
+	// changed wakes up the goroutine notifying the subscribers, so that a slow
+	// subscriber doesn't delay the reloads
+	changed chan struct{}
+
+	watcher    *filereload.Watcher
+	client     *http.Client
+	etag       string
+	cancelPoll context.CancelFunc
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+var (
+	_ extension.Extension = (*runtimeSettings)(nil)
+	_ Settings            = (*runtimeSettings)(nil)
+)
+
+func newRuntimeSettings(cfg *Config, set extension.CreateSettings) *runtimeSettings {
+	return &runtimeSettings{
+		cfg:         cfg,
+		id:          set.ID,
+		logger:      set.Logger,
+		telemetry:   set.TelemetrySettings,
+		values:      values{},
+		subscribers: map[int]func(){},
+		changed:     make(chan struct{}, 1),
+	}
+}
+
+func (rs *runtimeSettings) Start(ctx context.Context, host component.Host) error {
+	rs.shutdownCh = make(chan struct{})
+	rs.wg.Add(1)
+	go rs.notifySubscribers()
+
+	var err error
+	if rs.cfg.File != nil {
+		err = rs.startFile()
+	} else {
+		err = rs.startHTTP(ctx, host)
+	}
+	if err != nil {
+		_ = rs.Shutdown(ctx)
+	}
+	return err
+}
+
+func (rs *runtimeSettings) Shutdown(context.Context) error {
+	if rs.cancelPoll != nil {
+		rs.cancelPoll()
+		rs.cancelPoll = nil
+	}
+	if rs.watcher != nil {
+		rs.watcher.Stop()
+		rs.watcher = nil
+	}
+	if rs.shutdownCh != nil {
+		close(rs.shutdownCh)
+		rs.wg.Wait()
+		rs.shutdownCh = nil
+	}
+	return nil
+}
+
+func (rs *runtimeSettings) Bool(key string) (bool, bool) {
+	rs.muValues.RLock()
+	defer rs.muValues.RUnlock()
+	return rs.values.boolValue(key)
+}
+
+func (rs *runtimeSettings) Float64(key string) (float64, bool) {
+	rs.muValues.RLock()
+	defer rs.muValues.RUnlock()
+	return rs.values.float64Value(key)
+}
+
+func (rs *runtimeSettings) String(key string) (string, bool) {
+	rs.muValues.RLock()
+	defer rs.muValues.RUnlock()
+	return rs.values.stringValue(key)
+}
+
+func (rs *runtimeSettings) StringSlice(key string) ([]string, bool) {
+	rs.muValues.RLock()
+	defer rs.muValues.RUnlock()
+	return rs.values.stringSliceValue(key)
+}
+
+func (rs *runtimeSettings) Subscribe(fn func()) func() {
+	rs.muSubscribers.Lock()
+	defer rs.muSubscribers.Unlock()
+	id := rs.nextID
+	rs.nextID++
+	rs.subscribers[id] = fn
+	return func() {
+		rs.muSubscribers.Lock()
+		defer rs.muSubscribers.Unlock()
+		delete(rs.subscribers, id)
+	}
+}
+
+// update swaps the settings and schedules the notification of the subscribers, unless nothing changed.
+func (rs *runtimeSettings) update(vals values) {
+	rs.muValues.Lock()
+	if reflect.DeepEqual(rs.values, vals) {
+		rs.muValues.Unlock()
+		return
+	}
+	rs.values = vals
+	rs.muValues.Unlock()
+
+	rs.logger.Info("Runtime settings changed", zap.Int("settings", len(vals)))
+	reloadMetrics.RecordReloaded(rs.id)
+
+	// A notification already pending covers this change, as the subscribers read the current settings
+	select {
+	case rs.changed <- struct{}{}:
+	default:
+	}
+}
+
+// notifySubscribers calls the subscribers each time the settings change, until the extension is shut down.
+// The changes made while the subscribers are called are notified once they return.
+func (rs *runtimeSettings) notifySubscribers() {
+	defer rs.wg.Done()
+
+	for {
+		select {
+		case <-rs.shutdownCh:
+			return
+		case <-rs.changed:
+		}
+
+		rs.muSubscribers.Lock()
+		subscribers := make([]func(), 0, len(rs.subscribers))
+		for _, fn := range rs.subscribers {
+			subscribers = append(subscribers, fn)
+		}
+		rs.muSubscribers.Unlock()
+
+		for _, fn := range subscribers {
+			fn()
+		}
+	}
+}
+
+func (rs *runtimeSettings) startFile() error {
+	vals, err := rs.loadFile(false)
+	if err != nil {
+		return err
+	}
+	rs.update(vals)
+
+	// The settings are reloaded each time the file changes, until the extension is shut down
+	rs.watcher, err = filereload.StartWatcher(rs.cfg.File.Path, rs.logger, rs.reloadFile)
+	if err != nil {
+		return fmt.Errorf("watch settings file: %w", err)
+	}
+	return nil
+}
+
+// reloadFile swaps the settings for the ones of the file, or keeps the current ones when the file is invalid
+func (rs *runtimeSettings) reloadFile() {
+	vals, err := rs.loadFile(true)
+	if err != nil {
+		rs.logger.Warn("Failed to reload the settings file, keeping the current settings", zap.String("file", rs.cfg.File.Path), zap.Error(err))
+		reloadMetrics.RecordReloadFailed(rs.id)
+		return
+	}
+	rs.update(vals)
+}
+
+// loadFile reads and parses the settings file. An empty file holds no settings, unless strict: it is then
+// rejected, as it is most likely being written, and `{}` is expected to clear the settings instead.
+func (rs *runtimeSettings) loadFile(strict bool) (values, error) {
+	content, err := os.ReadFile(rs.cfg.File.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read settings file: %w", err)
+	}
+	if strict && len(bytes.TrimSpace(content)) == 0 {
+		return nil, errEmptySettingsFile
+	}
+	return parseValues(content)
+}
+
+func (rs *runtimeSettings) startHTTP(ctx context.Context, host component.Host) error {
+	client, err := rs.cfg.HTTP.ToClient(host, rs.telemetry)
+	if err != nil {
+		return fmt.Errorf("create settings HTTP client: %w", err)
+	}
+	rs.client = client
+
+	// An unavailable endpoint must not prevent the collector from starting:
+	// the components keep their static configuration until it can be polled.
+	rs.poll(ctx)
+
+	interval := rs.cfg.HTTP.PollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	// The requests in flight are cancelled on shutdown
+	pollCtx, cancel := context.WithCancel(context.Background())
+	rs.cancelPoll = cancel
+	rs.wg.Add(1)
+	go rs.pollEvery(pollCtx, interval)
+
+	return nil
+}
+
+func (rs *runtimeSettings) pollEvery(ctx context.Context, interval time.Duration) {
+	defer rs.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-rs.shutdownCh:
+			return
+		case <-ticker.C:
+			rs.poll(ctx)
+		}
+	}
+}
+
+func (rs *runtimeSettings) poll(ctx context.Context) {
+	vals, err := rs.fetch(ctx)
+	if err != nil {
+		rs.logger.Warn("Failed to poll the settings endpoint, keeping the current settings", zap.String("endpoint", rs.cfg.HTTP.Endpoint), zap.Error(err))
+		reloadMetrics.RecordReloadFailed(rs.id)
+		return
+	}
+	if vals != nil {
+		rs.update(vals)
+	}
+}
+
+// fetch gets the settings from the HTTP endpoint, returning nil values when they didn't change since the last poll.
+// The request is bounded by the timeout of the HTTP settings, or by defaultRequestTimeout when unset.
+func (rs *runtimeSettings) fetch(ctx context.Context) (values, error) {
+	timeout := rs.cfg.HTTP.Timeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rs.cfg.HTTP.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rs.etag != "" {
+		req.Header.Set("If-None-Match", rs.etag)
+	}
+
+	resp, err := rs.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, nil
+	case http.StatusOK:
+	default:
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("read settings: %w", err)
+	}
+	vals, err := parseValues(content)
+	if err != nil {
+		return nil, err
+	}
+	rs.etag = resp.Header.Get("ETag")
+	return vals, nil
+}
@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimesettingsextension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
+)
+
+func TestFileSettingsReload(t *testing.T) {
+	require.NoError(t, reloadMetrics.Register())
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewIDWithName(metadata.Type, "file")
+	filename := filepath.Join(t.TempDir(), "runtime.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte("sampling:\n  percentage: 10\n"), 0600))
+
+	rs := newRuntimeSettings(&Config{File: &FileSettings{Path: filename}}, set)
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, rs.Shutdown(context.Background())) }()
+
+	pct, ok := rs.Float64("sampling.percentage")
+	require.True(t, ok)
+	assert.Equal(t, float64(10), pct)
+
+	var notified atomic.Int32
+	unsubscribe := rs.Subscribe(func() { notified.Add(1) })
+
+	// The file is rotated by swapping it, as done for the config maps mounted by Kubernetes
+	rotated := filepath.Join(t.TempDir(), "runtime.yaml")
+	require.NoError(t, os.WriteFile(rotated, []byte("sampling:\n  percentage: 50\n"), 0600))
+	require.NoError(t, os.Rename(rotated, filename))
+
+	assert.Eventually(t, func() bool {
+		pct, _ = rs.Float64("sampling.percentage")
+		return pct == 50
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return notified.Load() > 0 }, 10*time.Second, 10*time.Millisecond)
+	assert.Greater(t, reloads(t, set.ID, statSettingsReloaded), float64(0))
+
+	// The current settings are kept when the file is invalid
+	for _, content := range []string{"", "- not a mapping"} {
+		failed := reloads(t, set.ID, statSettingsReloadFailed)
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+		assert.Eventually(t, func() bool {
+			return reloads(t, set.ID, statSettingsReloadFailed) > failed
+		}, 10*time.Second, 10*time.Millisecond)
+		pct, ok = rs.Float64("sampling.percentage")
+		assert.True(t, ok)
+		assert.Equal(t, float64(50), pct)
+	}
+
+	// Subscribers are no longer notified once unsubscribed
+	unsubscribe()
+	count := notified.Load()
+	require.NoError(t, os.WriteFile(filename, []byte("{}"), 0600))
+	assert.Eventually(t, func() bool {
+		_, ok = rs.Float64("sampling.percentage")
+		return !ok
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, count, notified.Load())
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "runtime.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte("{}"), 0600))
+	rs := newRuntimeSettings(&Config{File: &FileSettings{Path: filename}}, extensiontest.NewNopCreateSettings())
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, rs.Shutdown(context.Background())) }()
+
+	release := make(chan struct{})
+	var notified atomic.Int32
+	rs.Subscribe(func() {
+		notified.Add(1)
+		<-release
+	})
+
+	// The settings keep being updated while the subscriber is blocked
+	rs.update(values{"sampling.percentage": 10})
+	assert.Eventually(t, func() bool { return notified.Load() == 1 }, 10*time.Second, 10*time.Millisecond)
+	rs.update(values{"sampling.percentage": 20})
+	rs.update(values{"sampling.percentage": 30})
+	pct, ok := rs.Float64("sampling.percentage")
+	assert.True(t, ok)
+	assert.Equal(t, float64(30), pct)
+
+	// The pending changes are notified once the subscriber returns
+	release <- struct{}{}
+	assert.Eventually(t, func() bool { return notified.Load() == 2 }, 10*time.Second, 10*time.Millisecond)
+	close(release)
+}
+
+func TestFileSettingsConfigMapUpdate(t *testing.T) {
+	// The config map is mounted as done by Kubernetes: the file is a symlink to ..data/runtime.yaml, where ..data
+	// is a symlink to a versioned directory swapped on updates.
+	dir := t.TempDir()
+	writeVersion := func(version, content string) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, version), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, version, "runtime.yaml"), []byte(content), 0600))
+		require.NoError(t, os.Symlink(version, filepath.Join(dir, "..data_tmp")))
+		require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	}
+	writeVersion("..v1", "sampling:\n  percentage: 10\n")
+	filename := filepath.Join(dir, "runtime.yaml")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "runtime.yaml"), filename))
+
+	rs := newRuntimeSettings(&Config{File: &FileSettings{Path: filename}}, extensiontest.NewNopCreateSettings())
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, rs.Shutdown(context.Background())) }()
+
+	writeVersion("..v2", "sampling:\n  percentage: 20\n")
+	assert.Eventually(t, func() bool {
+		pct, _ := rs.Float64("sampling.percentage")
+		return pct == 20
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The file is still watched when it is only written back after being removed
+	require.NoError(t, os.Remove(filename))
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, os.WriteFile(filename, []byte("sampling:\n  percentage: 30\n"), 0600))
+	assert.Eventually(t, func() bool {
+		pct, _ := rs.Float64("sampling.percentage")
+		return pct == 30
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestFileSettingsStartErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	rs := newRuntimeSettings(&Config{File: &FileSettings{Path: filepath.Join(dir, "missing.yaml")}}, extensiontest.NewNopCreateSettings())
+	assert.ErrorContains(t, rs.Start(context.Background(), componenttest.NewNopHost()), "read settings file")
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("- not a mapping"), 0600))
+	rs = newRuntimeSettings(&Config{File: &FileSettings{Path: invalid}}, extensiontest.NewNopCreateSettings())
+	assert.ErrorContains(t, rs.Start(context.Background(), componenttest.NewNopHost()), "parse settings")
+
+	empty := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, os.WriteFile(empty, nil, 0600))
+	rs = newRuntimeSettings(&Config{File: &FileSettings{Path: empty}}, extensiontest.NewNopCreateSettings())
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, rs.Shutdown(context.Background()))
+}
+
+func TestHTTPSettingsPoll(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		status  = http.StatusOK
+		content = `{"filter": {"traces": {"span": ["name == \"health\""]}}}`
+		etag    = `"v1"`
+		polls   atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		mu.Lock()
+		defer mu.Unlock()
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	rs := newRuntimeSettings(&Config{HTTP: &HTTPSettings{
+		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: srv.URL},
+		PollInterval:       10 * time.Millisecond,
+	}}, extensiontest.NewNopCreateSettings())
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, rs.Shutdown(context.Background())) }()
+
+	conditions, ok := rs.StringSlice("filter.traces.span")
+	require.True(t, ok)
+	assert.Equal(t, []string{`name == "health"`}, conditions)
+
+	// The current settings are kept while the endpoint fails
+	mu.Lock()
+	status = http.StatusInternalServerError
+	mu.Unlock()
+	current := polls.Load()
+	assert.Eventually(t, func() bool { return polls.Load() > current+1 }, 10*time.Second, 10*time.Millisecond)
+	_, ok = rs.StringSlice("filter.traces.span")
+	assert.True(t, ok)
+
+	mu.Lock()
+	status = http.StatusOK
+	content = `{"filter": {"traces": {"span": []}}}`
+	etag = `"v2"`
+	mu.Unlock()
+	assert.Eventually(t, func() bool {
+		conditions, ok = rs.StringSlice("filter.traces.span")
+		return ok && len(conditions) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestHTTPSettingsUnavailableAtStart(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	rs := newRuntimeSettings(&Config{HTTP: &HTTPSettings{
+		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: srv.URL},
+	}}, extensiontest.NewNopCreateSettings())
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	_, ok := rs.String("anything")
+	assert.False(t, ok)
+	assert.NoError(t, rs.Shutdown(context.Background()))
+}
+
+func TestHTTPSettingsHungEndpoint(t *testing.T) {
+	var (
+		hang     atomic.Bool
+		inFlight = make(chan struct{}, 1)
+		release  = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hang.Load() {
+			select {
+			case inFlight <- struct{}{}:
+			default:
+			}
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"sampling": {"percentage": 10}}`))
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	// The request timeout bounds the poll done at start
+	hang.Store(true)
+	rs := newRuntimeSettings(&Config{HTTP: &HTTPSettings{
+		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: srv.URL, Timeout: 50 * time.Millisecond},
+	}}, extensiontest.NewNopCreateSettings())
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	_, ok := rs.Float64("sampling.percentage")
+	assert.False(t, ok)
+	assert.NoError(t, rs.Shutdown(context.Background()))
+
+	// The shutdown cancels the poll in flight, whatever the request timeout
+	hang.Store(false)
+	rs = newRuntimeSettings(&Config{HTTP: &HTTPSettings{
+		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: srv.URL, Timeout: time.Hour},
+		PollInterval:       10 * time.Millisecond,
+	}}, extensiontest.NewNopCreateSettings())
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	pct, ok := rs.Float64("sampling.percentage")
+	require.True(t, ok)
+	assert.Equal(t, float64(10), pct)
+
+	hang.Store(true)
+	<-inFlight
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, rs.Shutdown(context.Background()))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown blocked by the poll in flight")
+	}
+}
+
+func reloads(t *testing.T, id component.ID, measure *stats.Int64Measure) float64 {
+	rows, err := view.RetrieveData("extension/" + metadata.Type + "/" + measure.Name())
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key == filereload.TagExtensionKey && tag.Value == id.String() {
+				return row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimesettingsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension/internal/metadata"
+)
+
+// NewFactory creates a factory for the runtime settings extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
+	if err := reloadMetrics.Register(); err != nil {
+		return nil, err
+	}
+	return newRuntimeSettings(cfg.(*Config), set), nil
+}
@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimesettingsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension"
+
+import (
+	"go.opencensus.io/stats"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
+)
+
+var (
+	statSettingsReloaded     = stats.Int64("settings_reloaded", "Number of times the settings were reloaded with changes", stats.UnitDimensionless)
+	statSettingsReloadFailed = stats.Int64("settings_reload_failed", "Number of times the settings failed to be reloaded, keeping the current ones", stats.UnitDimensionless)
+
+	reloadMetrics = filereload.NewMetrics(metadata.Type, statSettingsReloaded, statSettingsReloadFailed)
+)
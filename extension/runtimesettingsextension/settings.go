@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimesettingsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/runtimesettingsextension"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings gives components typed access to the runtime settings. Nested
+// mappings are flattened, so that the key "a.b" reads the value of b in
+// the mapping a.
+//
+// Each accessor reports false when the key is missing or holds a value of
+// another type, in which case components are expected to fall back to
+// their static configuration.
+type Settings interface {
+	// Bool returns the boolean value of the key.
+	Bool(key string) (bool, bool)
+	// Float64 returns the numeric value of the key, which may be written as
+	// a string such as "12.5". NaN and infinite values are rejected.
+	Float64(key string) (float64, bool)
+	// String returns the string value of the key.
+	String(key string) (string, bool)
+	// StringSlice returns the value of the key when it is a list of strings.
+	StringSlice(key string) ([]string, bool)
+	// Subscribe registers fn to be called each time the settings change,
+	// until the returned function is called.
+	Subscribe(fn func()) (unsubscribe func())
+}
+
+// values holds the flattened settings.
+type values map[string]any
+
+// parseValues parses a YAML or JSON mapping of settings. Empty content
+// parses as no settings.
+func parseValues(content []byte) (values, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("parse settings: %w", err)
+	}
+	vals := values{}
+	if err := vals.flatten("", raw); err != nil {
+		return nil, err
+	}
+	return vals, nil
+}
+
+func (v values) flatten(prefix string, m map[string]any) error {
+	for key, value := range m {
+		if key == "" {
+			return errors.New("parse settings: empty key")
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			if err := v.flatten(key, nested); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := v[key]; ok {
+			return fmt.Errorf("parse settings: duplicate key %q", key)
+		}
+		v[key] = value
+	}
+	return nil
+}
+
+func (v values) boolValue(key string) (bool, bool) {
+	b, ok := v[key].(bool)
+	return b, ok
+}
+
+func (v values) float64Value(key string) (float64, bool) {
+	var f float64
+	switch n := v[key].(type) {
+	case int:
+		f = float64(n)
+	case int64:
+		f = float64(n)
+	case uint64:
+		f = float64(n)
+	case float64:
+		f = n
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, false
+		}
+		f = parsed
+	default:
+		return 0, false
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+func (v values) stringValue(key string) (string, bool) {
+	s, ok := v[key].(string)
+	return s, ok
+}
+
+func (v values) stringSliceValue(key string) ([]string, bool) {
+	list, ok := v[key].([]any)
+	if !ok {
+		return nil, false
+	}
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		strs = append(strs, s)
+	}
+	return strs, true
+}
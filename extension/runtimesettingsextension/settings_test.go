@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimesettingsextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValues(t *testing.T) {
+	vals, err := parseValues([]byte(`
+sampling:
+  percentage: 12.5
+  enabled: true
+  seed: 42
+filter.traces.span:
+  - 'name == "health"'
+mode: drop
+mixed: [a, 1]
+quoted: " 7.5 "
+nan: .nan
+inf: -.inf
+nanstring: NaN
+`))
+	require.NoError(t, err)
+
+	pct, ok := vals.float64Value("sampling.percentage")
+	assert.True(t, ok)
+	assert.Equal(t, 12.5, pct)
+
+	seed, ok := vals.float64Value("sampling.seed")
+	assert.True(t, ok)
+	assert.Equal(t, float64(42), seed)
+
+	enabled, ok := vals.boolValue("sampling.enabled")
+	assert.True(t, ok)
+	assert.True(t, enabled)
+
+	conditions, ok := vals.stringSliceValue("filter.traces.span")
+	assert.True(t, ok)
+	assert.Equal(t, []string{`name == "health"`}, conditions)
+
+	mode, ok := vals.stringValue("mode")
+	assert.True(t, ok)
+	assert.Equal(t, "drop", mode)
+
+	_, ok = vals.stringSliceValue("mixed")
+	assert.False(t, ok, "list with non string items")
+	quoted, ok := vals.float64Value("quoted")
+	assert.True(t, ok, "numeric string")
+	assert.Equal(t, 7.5, quoted)
+	for _, key := range []string{"nan", "inf", "nanstring"} {
+		_, ok = vals.float64Value(key)
+		assert.False(t, ok, "non-finite %s", key)
+	}
+
+	_, ok = vals.float64Value("mode")
+	assert.False(t, ok, "wrong type")
+	_, ok = vals.stringValue("sampling")
+	assert.False(t, ok, "nested mappings are flattened")
+	_, ok = vals.boolValue("missing")
+	assert.False(t, ok)
+}
+
+func TestParseValuesEmpty(t *testing.T) {
+	vals, err := parseValues(nil)
+	require.NoError(t, err)
+	assert.Empty(t, vals)
+}
+
+func TestParseValuesJSON(t *testing.T) {
+	vals, err := parseValues([]byte(`{"sampling": {"percentage": 5}}`))
+	require.NoError(t, err)
+	pct, ok := vals.float64Value("sampling.percentage")
+	assert.True(t, ok)
+	assert.Equal(t, float64(5), pct)
+}
+
+func TestParseValuesInvalid(t *testing.T) {
+	_, err := parseValues([]byte(`- not a mapping`))
+	assert.Error(t, err)
+
+	_, err = parseValues([]byte("a.b: 1\na:\n  b: 2\n"))
+	assert.ErrorContains(t, err, `duplicate key "a.b"`)
+}
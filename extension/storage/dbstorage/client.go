@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 
+	// MySQL driver
+	_ "github.com/go-sql-driver/mysql"
 	// Postgres driver
 	_ "github.com/jackc/pgx/v4/stdlib"
 	// SQLite driver
@@ -16,13 +18,6 @@ import (
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 )
 
-const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
-	deleteQueryText = "delete from %s where key=?"
-)
-
 type dbStorageClient struct {
 	db          *sql.DB
 	getQuery    *sql.Stmt
@@ -30,22 +25,20 @@ type dbStorageClient struct {
 	deleteQuery *sql.Stmt
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
-	if err != nil {
+func newClient(ctx context.Context, db *sql.DB, d *dialect, tableName string) (*dbStorageClient, error) {
+	if err := migrate(ctx, db, d, tableName); err != nil {
 		return nil, err
 	}
 
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
+	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(d.get, tableName))
 	if err != nil {
 		return nil, err
 	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
+	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(d.set, tableName))
 	if err != nil {
 		return nil, err
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
+	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(d.delete, tableName))
 	if err != nil {
 		return nil, err
 	}
@@ -54,25 +47,12 @@ func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageCli
 
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
-	if err != nil {
-		return nil, err
-	}
-	if !rows.Next() {
-		return nil, nil
-	}
-	var result []byte
-	err = rows.Scan(&result)
-	if err != nil {
-		return result, err
-	}
-	err = rows.Close()
-	return result, err
+	return get(ctx, c.getQuery, key)
 }
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
+	_, err := c.setQuery.ExecContext(ctx, key, value)
 	return err
 }
 
@@ -82,26 +62,32 @@ func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
 	return err
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
+// Batch executes the specified operations in order, within a single transaction.
+// Get operation results are updated in place
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
-	var err error
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = get(ctx, tx.StmtContext(ctx, c.getQuery), op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			_, err = tx.StmtContext(ctx, c.setQuery).ExecContext(ctx, op.Key, op.Value)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			_, err = tx.StmtContext(ctx, c.deleteQuery).ExecContext(ctx, op.Key)
 		default:
-			return errors.New("wrong operation type")
+			err = errors.New("wrong operation type")
 		}
 
 		if err != nil {
+			_ = tx.Rollback()
 			return err
 		}
 	}
-	return err
+	return tx.Commit()
 }
 
 // Close will close the database
@@ -114,3 +100,12 @@ func (c *dbStorageClient) Close(_ context.Context) error {
 	}
 	return c.getQuery.Close()
 }
+
+func get(ctx context.Context, query *sql.Stmt, key string) ([]byte, error) {
+	var result []byte
+	err := query.QueryRowContext(ctx, key).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return result, err
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Skip tests on Windows temporarily, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/11451
+//go:build !windows
+// +build !windows
+
+package dbstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newTestDB(t), "batch")
+
+	require.NoError(t, client.Set(ctx, "a", []byte("1")))
+
+	getA := storage.GetOperation("a")
+	getB := storage.GetOperation("b")
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("b", []byte("2")),
+		getA,
+		getB,
+		storage.SetOperation("a", []byte("3")),
+		storage.DeleteOperation("b"),
+	))
+	assert.Equal(t, []byte("1"), getA.Value)
+	assert.Equal(t, []byte("2"), getB.Value)
+
+	value, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+
+	value, err = client.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClientBatchRollback(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newTestDB(t), "rollback")
+
+	require.NoError(t, client.Set(ctx, "a", []byte("1")))
+
+	invalid := storage.GetOperation("c")
+	invalid.Type = -1
+	err := client.Batch(ctx,
+		storage.SetOperation("a", []byte("2")),
+		storage.SetOperation("b", []byte("2")),
+		invalid,
+	)
+	require.EqualError(t, err, "wrong operation type")
+
+	value, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	value, err = client.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClientMigration(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	client := newTestClient(t, db, "migrated")
+	require.NoError(t, client.Set(ctx, "a", []byte("1")))
+	require.NoError(t, client.Close(ctx))
+
+	var version int
+	require.NoError(t, db.QueryRowContext(ctx,
+		fmt.Sprintf("select version from %s where table_name=?", versionTable), "migrated").Scan(&version))
+	assert.Equal(t, len(dialects[dialectSQLite].migrations), version)
+
+	// Reopening the table keeps its data
+	client = newTestClient(t, db, "migrated")
+	value, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestClientMigrationExistingTable(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// Tables created before schema versions were recorded are adopted
+	_, err := db.ExecContext(ctx, "create table if not exists legacy (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "insert into legacy(key, value) values(?,?)", "a", []byte("1"))
+	require.NoError(t, err)
+
+	client := newTestClient(t, db, "legacy")
+	value, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestClientMigrationNewerVersion(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	d := dialects[dialectSQLite]
+
+	require.NoError(t, migrate(ctx, db, d, "newer"))
+	_, err := db.ExecContext(ctx, fmt.Sprintf(d.updateVersion, versionTable), len(d.migrations)+1, "newer")
+	require.NoError(t, err)
+
+	_, err = newClient(ctx, db, d, "newer")
+	require.EqualError(t, err, "table newer has schema version 2, newer than the supported version 1")
+}
+
+func TestDialectStatements(t *testing.T) {
+	for name, d := range dialects {
+		t.Run(name, func(t *testing.T) {
+			assert.NotEmpty(t, d.migrations)
+			for _, statement := range []string{d.createVersionTable, d.getVersion, d.insertVersion, d.updateVersion, d.get, d.set, d.delete} {
+				assert.Contains(t, statement, "%s")
+			}
+		})
+	}
+
+	for driver, name := range driverDialects {
+		d, err := getDialect(&Config{DriverName: driver})
+		require.NoError(t, err)
+		assert.Same(t, dialects[name], d)
+	}
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, db.Close())
+	})
+	return db
+}
+
+func newTestClient(t *testing.T, db *sql.DB, tableName string) *dbStorageClient {
+	client, err := newClient(context.Background(), db, dialects[dialectSQLite], tableName)
+	require.NoError(t, err)
+	return client
+}
@@ -5,12 +5,31 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"errors"
+	"time"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+	// Dialect is the SQL dialect of the database: sqlite, postgres or mysql.
+	// It is only required for drivers other than sqlite3, pgx, postgres and mysql.
+	Dialect string `mapstructure:"dialect,omitempty"`
+
+	ConnectionPool ConnectionPoolConfig `mapstructure:"connection_pool,omitempty"`
+}
+
+// ConnectionPoolConfig defines the limits of the pool of connections to the database.
+// Zero values keep the defaults of the database/sql package.
+type ConnectionPoolConfig struct {
+	// MaxOpen is the maximum number of open connections. Zero means unlimited.
+	MaxOpen int `mapstructure:"max_open,omitempty"`
+	// MaxIdle is the maximum number of idle connections. Zero means the default of 2.
+	MaxIdle int `mapstructure:"max_idle,omitempty"`
+	// MaxLifetime is the maximum amount of time a connection may be reused. Zero means unlimited.
+	MaxLifetime time.Duration `mapstructure:"max_lifetime,omitempty"`
+	// MaxIdleTime is the maximum amount of time a connection may be idle. Zero means unlimited.
+	MaxIdleTime time.Duration `mapstructure:"max_idle_time,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -20,6 +39,15 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return errors.New("missing driver name")
 	}
+	if _, err := getDialect(cfg); err != nil {
+		return err
+	}
+	if cfg.ConnectionPool.MaxOpen < 0 || cfg.ConnectionPool.MaxIdle < 0 {
+		return errors.New("connection pool limits cannot be less than 0")
+	}
+	if cfg.ConnectionPool.MaxLifetime < 0 || cfg.ConnectionPool.MaxIdleTime < 0 {
+		return errors.New("connection pool durations cannot be less than 0")
+	}
 
 	return nil
 }
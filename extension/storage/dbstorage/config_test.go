@@ -10,6 +10,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,8 +32,33 @@ func TestConfigValidate(t *testing.T) {
 			errors.New("missing datasource"),
 		},
 		{
-			"valid",
+			"Unknown dialect of driver",
 			Config{DriverName: "foo", DataSource: "bar"},
+			errors.New(`unknown dialect of driver "foo", a dialect must be set`),
+		},
+		{
+			"Unsupported dialect",
+			Config{DriverName: "foo", DataSource: "bar", Dialect: "oracle"},
+			errors.New(`unsupported dialect "oracle"`),
+		},
+		{
+			"Negative pool limit",
+			Config{DriverName: "pgx", DataSource: "bar", ConnectionPool: ConnectionPoolConfig{MaxIdle: -1}},
+			errors.New("connection pool limits cannot be less than 0"),
+		},
+		{
+			"Negative pool duration",
+			Config{DriverName: "pgx", DataSource: "bar", ConnectionPool: ConnectionPoolConfig{MaxLifetime: -time.Second}},
+			errors.New("connection pool durations cannot be less than 0"),
+		},
+		{
+			"valid",
+			Config{DriverName: "pgx", DataSource: "bar"},
+			nil,
+		},
+		{
+			"valid with dialect",
+			Config{DriverName: "foo", DataSource: "bar", Dialect: "mysql", ConnectionPool: ConnectionPoolConfig{MaxOpen: 10, MaxIdle: 5, MaxIdleTime: time.Minute}},
 			nil,
 		},
 	}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"fmt"
+)
+
+// The supported SQL dialects
+const (
+	dialectSQLite   = "sqlite"
+	dialectPostgres = "postgres"
+	dialectMySQL    = "mysql"
+)
+
+// driverDialects maps the names of the bundled drivers to their dialect
+var driverDialects = map[string]string{
+	"sqlite3":  dialectSQLite,
+	"pgx":      dialectPostgres,
+	"postgres": dialectPostgres,
+	"mysql":    dialectMySQL,
+}
+
+// dialect holds the statements of a SQL dialect, with %s standing for the table name
+type dialect struct {
+	// migrations are the statements creating and upgrading the table of a component,
+	// the first migration bringing the table to version 1
+	migrations [][]string
+
+	createVersionTable string
+	getVersion         string
+	insertVersion      string
+	updateVersion      string
+
+	get    string
+	set    string
+	delete string
+}
+
+var dialects = map[string]*dialect{
+	dialectSQLite: {
+		migrations: [][]string{
+			{"create table if not exists %s (key text primary key, value blob)"},
+		},
+		createVersionTable: "create table if not exists %s (table_name text primary key, version integer not null)",
+		getVersion:         "select version from %s where table_name=?",
+		insertVersion:      "insert into %s(table_name, version) values(?,?)",
+		updateVersion:      "update %s set version=? where table_name=?",
+		get:                "select value from %s where key=?",
+		set:                "insert into %s(key, value) values(?,?) on conflict(key) do update set value=excluded.value",
+		delete:             "delete from %s where key=?",
+	},
+	dialectPostgres: {
+		migrations: [][]string{
+			{"create table if not exists %s (key text primary key, value bytea)"},
+		},
+		createVersionTable: "create table if not exists %s (table_name text primary key, version integer not null)",
+		getVersion:         "select version from %s where table_name=$1",
+		insertVersion:      "insert into %s(table_name, version) values($1,$2)",
+		updateVersion:      "update %s set version=$1 where table_name=$2",
+		get:                "select value from %s where key=$1",
+		set:                "insert into %s(key, value) values($1,$2) on conflict(key) do update set value=excluded.value",
+		delete:             "delete from %s where key=$1",
+	},
+	dialectMySQL: {
+		migrations: [][]string{
+			{"create table if not exists %s (`key` varchar(255) primary key, value longblob)"},
+		},
+		createVersionTable: "create table if not exists %s (table_name varchar(255) primary key, version integer not null)",
+		getVersion:         "select version from %s where table_name=?",
+		insertVersion:      "insert into %s(table_name, version) values(?,?)",
+		updateVersion:      "update %s set version=? where table_name=?",
+		get:                "select value from %s where `key`=?",
+		set:                "insert into %s(`key`, value) values(?,?) on duplicate key update value=values(value)",
+		delete:             "delete from %s where `key`=?",
+	},
+}
+
+// getDialect returns the dialect configured, or the one of the driver if none is
+func getDialect(cfg *Config) (*dialect, error) {
+	name := cfg.Dialect
+	if name == "" {
+		var ok bool
+		if name, ok = driverDialects[cfg.DriverName]; !ok {
+			return nil, fmt.Errorf("unknown dialect of driver %q, a dialect must be set", cfg.DriverName)
+		}
+	}
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dialect %q", name)
+	}
+	return d, nil
+}
@@ -16,31 +16,46 @@ import (
 )
 
 type databaseStorage struct {
-	driverName     string
-	datasourceName string
-	logger         *zap.Logger
-	db             *sql.DB
+	cfg     *Config
+	dialect *dialect
+	logger  *zap.Logger
+	db      *sql.DB
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*databaseStorage)(nil)
 
 func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+	d, err := getDialect(config)
+	if err != nil {
+		return nil, err
+	}
 	return &databaseStorage{
-		driverName:     config.DriverName,
-		datasourceName: config.DataSource,
-		logger:         logger,
+		cfg:     config,
+		dialect: d,
+		logger:  logger,
 	}, nil
 }
 
 // Start opens a connection to the database
-func (ds *databaseStorage) Start(context.Context, component.Host) error {
-	db, err := sql.Open(ds.driverName, ds.datasourceName)
+func (ds *databaseStorage) Start(ctx context.Context, _ component.Host) error {
+	db, err := sql.Open(ds.cfg.DriverName, ds.cfg.DataSource)
 	if err != nil {
 		return err
 	}
 
-	if err := db.Ping(); err != nil {
+	pool := ds.cfg.ConnectionPool
+	if pool.MaxOpen > 0 {
+		db.SetMaxOpenConns(pool.MaxOpen)
+	}
+	if pool.MaxIdle > 0 {
+		db.SetMaxIdleConns(pool.MaxIdle)
+	}
+	db.SetConnMaxLifetime(pool.MaxLifetime)
+	db.SetConnMaxIdleTime(pool.MaxIdleTime)
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
 		return err
 	}
 	ds.db = db
@@ -64,7 +79,7 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+	return newClient(ctx, ds.db, ds.dialect, fullName)
 }
 
 func kindString(k component.Kind) string {
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// versionTable records the schema version of the table of each component
+const versionTable = "dbstorage_schema_versions"
+
+// migrate brings the table of a component to the latest schema version, applying the
+// migrations not applied yet within a transaction
+func migrate(ctx context.Context, db *sql.DB, d *dialect, tableName string) (err error) {
+	if _, err = db.ExecContext(ctx, fmt.Sprintf(d.createVersionTable, versionTable)); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var version int
+	err = tx.QueryRowContext(ctx, fmt.Sprintf(d.getVersion, versionTable), tableName).Scan(&version)
+	exists := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	latest := len(d.migrations)
+	if version > latest {
+		return fmt.Errorf("table %s has schema version %d, newer than the supported version %d", tableName, version, latest)
+	}
+	if version == latest {
+		return tx.Commit()
+	}
+
+	for _, migration := range d.migrations[version:] {
+		for _, statement := range migration {
+			if _, err = tx.ExecContext(ctx, fmt.Sprintf(statement, tableName)); err != nil {
+				return fmt.Errorf("failed to migrate table %s: %w", tableName, err)
+			}
+		}
+	}
+
+	if exists {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(d.updateVersion, versionTable), latest, tableName)
+	} else {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(d.insertVersion, versionTable), tableName, latest)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -35,6 +35,11 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+
+	// retention is nil if the keys are kept forever
+	retention *retention
+	// retentionMutex serializes the transactions updating the size of the storage
+	retentionMutex sync.Mutex
 }
 
 func bboltOptions(timeout time.Duration) *bbolt.Options {
@@ -46,7 +51,7 @@ func bboltOptions(timeout time.Duration) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, retention *retention) (*fileStorageClient, error) {
 	options := bboltOptions(timeout)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -54,17 +59,30 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 	}
 
 	initBucket := func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(defaultBucket)
-		return err
+		if _, err := tx.CreateBucketIfNotExists(defaultBucket); err != nil {
+			return err
+		}
+		if retention == nil {
+			return dropRetention(tx)
+		}
+		return retention.init(tx, time.Now())
 	}
 	if err := db.Update(initBucket); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
-	if compactionCfg.OnRebound {
-		client.startCompactionLoop(context.Background())
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, retention: retention}
+	expiration := retention != nil && retention.cfg.TTL > 0
+	if compactionCfg.OnRebound || expiration {
+		var ctx context.Context
+		ctx, client.cancel = context.WithCancel(context.Background())
+		if compactionCfg.OnRebound {
+			client.startCompactionLoop(ctx)
+		}
+		if expiration {
+			client.startExpirationLoop(ctx)
+		}
 	}
 
 	return client, nil
@@ -92,7 +110,10 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 }
 
 // Batch executes the specified operations in order. Get operation results are updated in place
-func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	now := time.Now()
+	var sizeDelta int64
+	var evicted int
 	batch := func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
@@ -100,11 +121,12 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 		}
 
 		var err error
+		var delta int64
 		for _, op := range ops {
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				if value != nil && (c.retention == nil || !c.retention.expired(tx, []byte(op.Key), now)) {
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
@@ -113,8 +135,20 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 					op.Value = nil
 				}
 			case storage.Set:
+				if c.retention != nil {
+					if delta, err = c.retention.set(tx, bucket, []byte(op.Key), op.Value, now); err != nil {
+						return err
+					}
+					sizeDelta += delta
+				}
 				err = bucket.Put([]byte(op.Key), op.Value)
 			case storage.Delete:
+				if c.retention != nil {
+					if delta, err = c.retention.remove(tx, bucket, []byte(op.Key)); err != nil {
+						return err
+					}
+					sizeDelta += delta
+				}
 				err = bucket.Delete([]byte(op.Key))
 			default:
 				return errors.New("wrong operation type")
@@ -125,12 +159,25 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 			}
 		}
 
-		return nil
+		if c.retention != nil {
+			evicted, sizeDelta, err = c.retention.evict(tx, sizeDelta)
+		}
+		return err
 	}
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	if c.retention == nil {
+		return c.db.Update(batch)
+	}
+
+	c.retentionMutex.Lock()
+	defer c.retentionMutex.Unlock()
+	if err := c.db.Update(batch); err != nil {
+		return err
+	}
+	c.retention.commit(ctx, sizeDelta, 0, evicted)
+	return nil
 }
 
 // Close will close the database
@@ -239,8 +286,6 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 
 // startCompactionLoop provides asynchronous compaction function
 func (c *fileStorageClient) startCompactionLoop(ctx context.Context) {
-	ctx, c.cancel = context.WithCancel(ctx)
-
 	go func() {
 		c.logger.Debug("starting compaction loop",
 			zap.Duration("compaction_check_interval", c.compactionCfg.CheckInterval))
@@ -267,6 +312,56 @@ func (c *fileStorageClient) startCompactionLoop(ctx context.Context) {
 	}()
 }
 
+// startExpirationLoop periodically removes the expired keys
+func (c *fileStorageClient) startExpirationLoop(ctx context.Context) {
+	go func() {
+		c.logger.Debug("starting expiration loop",
+			zap.Duration("expiration_check_interval", c.retention.cfg.CheckInterval))
+
+		expirationTicker := time.NewTicker(c.retention.cfg.CheckInterval)
+		defer expirationTicker.Stop()
+
+		for {
+			select {
+			case <-expirationTicker.C:
+				if err := c.expire(ctx); err != nil {
+					c.logger.Error("failed to remove expired keys", zap.Error(err))
+				}
+			case <-ctx.Done():
+				c.logger.Debug("shutting down expiration loop")
+				return
+			}
+		}
+	}()
+}
+
+// expire removes the keys which have outlived their time to live
+func (c *fileStorageClient) expire(ctx context.Context) error {
+	c.compactionMutex.RLock()
+	defer c.compactionMutex.RUnlock()
+	if c.closed {
+		return nil
+	}
+
+	c.retentionMutex.Lock()
+	defer c.retentionMutex.Unlock()
+	var expired int
+	var sizeDelta int64
+	err := c.db.Update(func(tx *bbolt.Tx) error {
+		var err error
+		expired, sizeDelta, err = c.retention.expire(tx, time.Now())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	c.retention.commit(ctx, sizeDelta, expired, 0)
+	if expired > 0 {
+		c.logger.Debug("removed expired keys", zap.Int("count", expired))
+	}
+	return nil
+}
+
 // shouldCompact checks whether the conditions for online compaction are met
 func (c *fileStorageClient) shouldCompact() bool {
 	if !c.compactionCfg.OnRebound {
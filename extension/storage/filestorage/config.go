@@ -17,6 +17,8 @@ type Config struct {
 	Timeout   time.Duration `mapstructure:"timeout,omitempty"`
 
 	Compaction *CompactionConfig `mapstructure:"compaction,omitempty"`
+
+	Retention *RetentionConfig `mapstructure:"retention,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -44,6 +46,18 @@ type CompactionConfig struct {
 	CheckInterval time.Duration `mapstructure:"check_interval,omitempty"`
 }
 
+// RetentionConfig defines configuration for the optional removal of the stored keys.
+type RetentionConfig struct {
+	// TTL specifies the time to live of the keys, counted from the last time they were written.
+	// Expired keys are not returned anymore and are removed in the background. Zero keeps the keys forever.
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+	// CheckInterval specifies frequency of the removal of the expired keys
+	CheckInterval time.Duration `mapstructure:"check_interval,omitempty"`
+	// MaxSize specifies the maximum total size, in bytes, of the keys and values of each client.
+	// When it is exceeded, the least recently written keys are evicted. Zero disables the eviction.
+	MaxSize int64 `mapstructure:"max_size,omitempty"`
+}
+
 func (cfg *Config) Validate() error {
 	var dirs []string
 	if cfg.Compaction.OnStart {
@@ -76,5 +90,17 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Retention != nil {
+		if cfg.Retention.TTL < 0 {
+			return errors.New("retention ttl cannot be less than 0")
+		}
+		if cfg.Retention.MaxSize < 0 {
+			return errors.New("retention max size cannot be less than 0")
+		}
+		if cfg.Retention.TTL > 0 && cfg.Retention.CheckInterval <= 0 {
+			return errors.New("retention check interval must be positive when a ttl is set")
+		}
+	}
+
 	return nil
 }
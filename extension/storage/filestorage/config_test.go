@@ -46,6 +46,11 @@ func TestLoadConfig(t *testing.T) {
 					ReboundNeededThresholdMiB:  128,
 					CheckInterval:              time.Second * 5,
 				},
+				Retention: &RetentionConfig{
+					TTL:           24 * time.Hour,
+					CheckInterval: 5 * time.Minute,
+					MaxSize:       100 * oneMiB,
+				},
 				Timeout: 2 * time.Second,
 			},
 		},
@@ -93,3 +98,35 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestRetentionConfigErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention *RetentionConfig
+		expected  string
+	}{
+		{
+			name:      "negative ttl",
+			retention: &RetentionConfig{TTL: -time.Second, CheckInterval: time.Second},
+			expected:  "retention ttl cannot be less than 0",
+		},
+		{
+			name:      "negative max size",
+			retention: &RetentionConfig{MaxSize: -1},
+			expected:  "retention max size cannot be less than 0",
+		},
+		{
+			name:      "ttl without check interval",
+			retention: &RetentionConfig{TTL: time.Hour},
+			expected:  "retention check interval must be positive when a ttl is set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Retention = tt.retention
+			require.EqualError(t, component.ValidateConfig(cfg), tt.expected)
+		})
+	}
+}
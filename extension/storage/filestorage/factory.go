@@ -22,6 +22,7 @@ const (
 	defaultReboundTriggerThresholdMib = 10
 	defaultReboundNeededThresholdMib  = 100
 	defaultCompactionInterval         = time.Second * 5
+	defaultRetentionInterval          = time.Minute
 )
 
 // NewFactory creates a factory for HostObserver extension.
@@ -46,6 +47,9 @@ func createDefaultConfig() component.Config {
 			ReboundTriggerThresholdMiB: defaultReboundTriggerThresholdMib,
 			CheckInterval:              defaultCompactionInterval,
 		},
+		Retention: &RetentionConfig{
+			CheckInterval: defaultRetentionInterval,
+		},
 		Timeout: time.Second,
 	}
 }
@@ -55,5 +59,5 @@ func createExtension(
 	params extension.CreateSettings,
 	cfg component.Config,
 ) (extension.Extension, error) {
-	return newLocalFileStorage(params.Logger, cfg.(*Config))
+	return newLocalFileStorage(params.TelemetrySettings, cfg.(*Config))
 }
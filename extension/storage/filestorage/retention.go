@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+)
+
+const (
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+	storageAttributeKey = "storage"
+	timestampSize       = 8
+)
+
+var (
+	errValueTooLarge = errors.New("value larger than the maximum size of the storage")
+
+	// metadataBucket holds the time each key was last written
+	metadataBucket = []byte(`metadata`)
+	// writeIndexBucket holds the keys ordered by the time they were last written,
+	// each key being prefixed with that time
+	writeIndexBucket = []byte(`write_index`)
+)
+
+// retentionTelemetry holds the counters of the keys removed by the retention policy
+type retentionTelemetry struct {
+	expiredKeys metric.Int64Counter
+	evictedKeys metric.Int64Counter
+}
+
+func newRetentionTelemetry(set component.TelemetrySettings) (*retentionTelemetry, error) {
+	meter := set.MeterProvider.Meter(scopeName)
+	expiredKeys, err := meter.Int64Counter(
+		metadata.Type+"_expired_keys",
+		metric.WithDescription("Number of keys removed since their time to live elapsed."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	evictedKeys, err := meter.Int64Counter(
+		metadata.Type+"_evicted_keys",
+		metric.WithDescription("Number of keys evicted to keep the storage under its maximum size."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &retentionTelemetry{expiredKeys: expiredKeys, evictedKeys: evictedKeys}, nil
+}
+
+// retention applies the retention policy of a client
+type retention struct {
+	cfg       *RetentionConfig
+	telemetry *retentionTelemetry
+	attrs     metric.MeasurementOption
+	// size is the total size of the stored keys and values, it is only updated within write transactions
+	size int64
+}
+
+func newRetention(cfg *RetentionConfig, telemetry *retentionTelemetry, storageName string) *retention {
+	if cfg == nil || (cfg.TTL == 0 && cfg.MaxSize == 0) {
+		return nil
+	}
+	return &retention{
+		cfg:       cfg,
+		telemetry: telemetry,
+		attrs:     metric.WithAttributes(attribute.String(storageAttributeKey, storageName)),
+	}
+}
+
+// init creates the buckets holding the write times, and records the keys written before the
+// retention policy was enabled as written now. It also computes the size of the storage.
+func (r *retention) init(tx *bbolt.Tx, now time.Time) error {
+	meta, err := tx.CreateBucketIfNotExists(metadataBucket)
+	if err != nil {
+		return err
+	}
+	index, err := tx.CreateBucketIfNotExists(writeIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	r.size = 0
+	ts := encodeTimestamp(now)
+	return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+		r.size += int64(len(k) + len(v))
+		if meta.Get(k) != nil {
+			return nil
+		}
+		if err := meta.Put(k, ts); err != nil {
+			return err
+		}
+		return index.Put(indexKey(ts, k), []byte{})
+	})
+}
+
+// dropRetention removes the write times recorded by a previous retention policy, since
+// they are not maintained while the retention policy is disabled.
+func dropRetention(tx *bbolt.Tx) error {
+	for _, name := range [][]byte{metadataBucket, writeIndexBucket} {
+		if tx.Bucket(name) == nil {
+			continue
+		}
+		if err := tx.DeleteBucket(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expired returns true if the key has outlived its time to live
+func (r *retention) expired(tx *bbolt.Tx, key []byte, now time.Time) bool {
+	if r.cfg.TTL == 0 {
+		return false
+	}
+	ts := tx.Bucket(metadataBucket).Get(key)
+	return ts != nil && !decodeTimestamp(ts).Add(r.cfg.TTL).After(now)
+}
+
+// set records the write of the key, and returns the change in the size of the storage. A key and value
+// larger than the maximum size are rejected, since evicting every other key could not make room for them.
+func (r *retention) set(tx *bbolt.Tx, bucket *bbolt.Bucket, key []byte, value []byte, now time.Time) (int64, error) {
+	if size := int64(len(key) + len(value)); r.cfg.MaxSize > 0 && size > r.cfg.MaxSize {
+		return 0, fmt.Errorf("%w: %d bytes for key %q, the maximum size is %d bytes", errValueTooLarge, size, key, r.cfg.MaxSize)
+	}
+	delta, err := r.remove(tx, bucket, key)
+	if err != nil {
+		return 0, err
+	}
+	ts := encodeTimestamp(now)
+	if err = tx.Bucket(metadataBucket).Put(key, ts); err != nil {
+		return 0, err
+	}
+	if err = tx.Bucket(writeIndexBucket).Put(indexKey(ts, key), []byte{}); err != nil {
+		return 0, err
+	}
+	return delta + int64(len(key)+len(value)), nil
+}
+
+// remove forgets the write time of the key, and returns the change in the size of the storage
+// once the key is deleted
+func (r *retention) remove(tx *bbolt.Tx, bucket *bbolt.Bucket, key []byte) (int64, error) {
+	var delta int64
+	if value := bucket.Get(key); value != nil {
+		delta = -int64(len(key) + len(value))
+	}
+	meta := tx.Bucket(metadataBucket)
+	ts := meta.Get(key)
+	if ts == nil {
+		return delta, nil
+	}
+	if err := tx.Bucket(writeIndexBucket).Delete(indexKey(ts, key)); err != nil {
+		return 0, err
+	}
+	return delta, meta.Delete(key)
+}
+
+// removeOldest deletes the least recently written keys while shouldRemove returns true for
+// their write time. It returns the number of deleted keys and the change in the size of the storage.
+func (r *retention) removeOldest(tx *bbolt.Tx, sizeDelta int64, shouldRemove func(written time.Time, size int64) bool) (int, int64, error) {
+	bucket := tx.Bucket(defaultBucket)
+	meta := tx.Bucket(metadataBucket)
+	cursor := tx.Bucket(writeIndexBucket).Cursor()
+
+	var removed int
+	for k, _ := cursor.First(); k != nil; k, _ = cursor.First() {
+		if !shouldRemove(decodeTimestamp(k[:timestampSize]), r.size+sizeDelta) {
+			break
+		}
+		key := k[timestampSize:]
+		if value := bucket.Get(key); value != nil {
+			sizeDelta -= int64(len(key) + len(value))
+		}
+		if err := bucket.Delete(key); err != nil {
+			return 0, 0, err
+		}
+		if err := meta.Delete(key); err != nil {
+			return 0, 0, err
+		}
+		if err := cursor.Delete(); err != nil {
+			return 0, 0, err
+		}
+		removed++
+	}
+	return removed, sizeDelta, nil
+}
+
+// evict deletes the least recently written keys until the storage fits its maximum size.
+// It returns the number of evicted keys and the change in the size of the storage.
+func (r *retention) evict(tx *bbolt.Tx, sizeDelta int64) (int, int64, error) {
+	if r.cfg.MaxSize == 0 || r.size+sizeDelta <= r.cfg.MaxSize {
+		return 0, sizeDelta, nil
+	}
+	return r.removeOldest(tx, sizeDelta, func(_ time.Time, size int64) bool {
+		return size > r.cfg.MaxSize
+	})
+}
+
+// expire deletes the keys which have outlived their time to live.
+// It returns the number of expired keys and the change in the size of the storage.
+func (r *retention) expire(tx *bbolt.Tx, now time.Time) (int, int64, error) {
+	return r.removeOldest(tx, 0, func(written time.Time, _ int64) bool {
+		return !written.Add(r.cfg.TTL).After(now)
+	})
+}
+
+// commit updates the size of the storage and the counters once a transaction is committed
+func (r *retention) commit(ctx context.Context, sizeDelta int64, expired int, evicted int) {
+	r.size += sizeDelta
+	if expired > 0 {
+		r.telemetry.expiredKeys.Add(ctx, int64(expired), r.attrs)
+	}
+	if evicted > 0 {
+		r.telemetry.evictedKeys.Add(ctx, int64(evicted), r.attrs)
+	}
+}
+
+func encodeTimestamp(t time.Time) []byte {
+	ts := make([]byte, timestampSize)
+	binary.BigEndian.PutUint64(ts, uint64(t.UnixNano()))
+	return ts
+}
+
+func decodeTimestamp(ts []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(ts)))
+}
+
+func indexKey(ts []byte, key []byte) []byte {
+	k := make([]byte, 0, len(ts)+len(key))
+	return append(append(k, ts...), key...)
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+)
+
+func TestClientExpiration(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	client := newRetentionTestClient(t, filepath.Join(t.TempDir(), "my_db"), reader, &RetentionConfig{
+		TTL:           200 * time.Millisecond,
+		CheckInterval: 10 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// The key is removed by the expiration loop once its ttl has elapsed
+	require.Eventually(t, func() bool {
+		return countKeys(t, client, defaultBucket) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, value)
+	assert.Equal(t, 0, countKeys(t, client, metadataBucket))
+	assert.Equal(t, 0, countKeys(t, client, writeIndexBucket))
+
+	assert.Equal(t, map[string]int64{"file_storage_expired_keys": 1}, collectCounters(t, reader))
+}
+
+func TestClientExpiredKeyNotReturned(t *testing.T) {
+	client := newRetentionTestClient(t, filepath.Join(t.TempDir(), "my_db"), sdkmetric.NewManualReader(), &RetentionConfig{
+		TTL:           50 * time.Millisecond,
+		CheckInterval: time.Hour,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	time.Sleep(100 * time.Millisecond)
+
+	// The key is still stored, since the expiration loop did not run yet
+	assert.Equal(t, 1, countKeys(t, client, defaultBucket))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// Setting the key again renews its ttl
+	require.NoError(t, client.Set(ctx, "key", []byte("new value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new value"), value)
+	assert.Equal(t, 1, countKeys(t, client, writeIndexBucket))
+}
+
+func TestClientEviction(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	// Each key and value pair is 9 bytes long
+	client := newRetentionTestClient(t, filepath.Join(t.TempDir(), "my_db"), reader, &RetentionConfig{
+		MaxSize: 35,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), []byte("value")))
+	}
+	// Rewriting the first key makes the second one the least recently written
+	require.NoError(t, client.Set(ctx, "key0", []byte("value")))
+	require.NoError(t, client.Set(ctx, "key3", []byte("value")))
+
+	values := map[string][]byte{}
+	for i := 0; i < 4; i++ {
+		key := fmt.Sprintf("key%d", i)
+		values[key], _ = client.Get(ctx, key)
+	}
+	assert.Equal(t, map[string][]byte{
+		"key0": []byte("value"),
+		"key1": nil,
+		"key2": []byte("value"),
+		"key3": []byte("value"),
+	}, values)
+	assert.Equal(t, int64(27), client.retention.size)
+
+	// A batch freeing space does not evict
+	require.NoError(t, client.Batch(ctx,
+		storage.DeleteOperation("key2"),
+		storage.SetOperation("key4", []byte("value")),
+	))
+	assert.Equal(t, 3, countKeys(t, client, defaultBucket))
+	assert.Equal(t, int64(27), client.retention.size)
+
+	assert.Equal(t, map[string]int64{"file_storage_evicted_keys": 1}, collectCounters(t, reader))
+
+	// A value larger than the maximum size is rejected without evicting the other keys
+	err := client.Set(ctx, "key5", make([]byte, 32))
+	assert.ErrorIs(t, err, errValueTooLarge)
+	assert.Equal(t, 3, countKeys(t, client, defaultBucket))
+	assert.Equal(t, int64(27), client.retention.size)
+	assert.Equal(t, map[string]int64{"file_storage_evicted_keys": 1}, collectCounters(t, reader))
+}
+
+func TestClientRetentionExistingKeys(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	ctx := context.Background()
+
+	// Keys written without a retention policy are recorded as written when the policy is enabled
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "old", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestRetention(t, sdkmetric.NewManualReader(), &RetentionConfig{MaxSize: 100}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), client.retention.size)
+	assert.Equal(t, 1, countKeys(t, client, writeIndexBucket))
+	require.NoError(t, client.Close(ctx))
+
+	// The write times are dropped when the policy is disabled
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		assert.Nil(t, tx.Bucket(metadataBucket))
+		assert.Nil(t, tx.Bucket(writeIndexBucket))
+		return nil
+	}))
+	value, err := client.Get(ctx, "old")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestNewRetentionDisabled(t *testing.T) {
+	assert.Nil(t, newRetention(nil, nil, "storage"))
+	assert.Nil(t, newRetention(&RetentionConfig{CheckInterval: time.Minute}, nil, "storage"))
+}
+
+func newTestRetention(t *testing.T, reader sdkmetric.Reader, cfg *RetentionConfig) *retention {
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	telemetry, err := newRetentionTelemetry(set)
+	require.NoError(t, err)
+	return newRetention(cfg, telemetry, "my_db")
+}
+
+func newRetentionTestClient(t *testing.T, dbFile string, reader sdkmetric.Reader, cfg *RetentionConfig) *fileStorageClient {
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestRetention(t, reader, cfg))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+	return client
+}
+
+func countKeys(t *testing.T, client *fileStorageClient, bucket []byte) int {
+	client.compactionMutex.RLock()
+	defer client.compactionMutex.RUnlock()
+
+	var count int
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		count = tx.Bucket(bucket).Stats().KeyN
+		return nil
+	}))
+	return count
+}
+
+func collectCounters(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	counts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				storageName, _ := dp.Attributes.Value(storageAttributeKey)
+				assert.Equal(t, "my_db", storageName.AsString())
+				counts[m.Name] += dp.Value
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+type redisStorageClient struct {
+	client     *redis.Client
+	prefix     string
+	expiration time.Duration
+}
+
+func newClient(client *redis.Client, prefix string, expiration time.Duration) *redisStorageClient {
+	return &redisStorageClient{
+		client:     client,
+		prefix:     prefix,
+		expiration: expiration,
+	}
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *redisStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.WithContext(ctx).Get(c.prefix + key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *redisStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.client.WithContext(ctx).Set(c.prefix+key, value, c.expiration).Err()
+}
+
+// Delete will delete data associated with the specified key
+func (c *redisStorageClient) Delete(ctx context.Context, key string) error {
+	return c.client.WithContext(ctx).Del(c.prefix + key).Err()
+}
+
+// Batch executes the specified operations in order, within a transaction. Get operation results are updated in place
+func (c *redisStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	cmds := make([]redis.Cmder, len(ops))
+	_, err := c.client.WithContext(ctx).TxPipelined(func(pipe redis.Pipeliner) error {
+		for i, op := range ops {
+			switch op.Type {
+			case storage.Get:
+				cmds[i] = pipe.Get(c.prefix + op.Key)
+			case storage.Set:
+				cmds[i] = pipe.Set(c.prefix+op.Key, op.Value, c.expiration)
+			case storage.Delete:
+				cmds[i] = pipe.Del(c.prefix + op.Key)
+			default:
+				return errors.New("wrong operation type")
+			}
+		}
+		return nil
+	})
+	// The error of a missing key is reported by the Get commands, and only
+	// the first error of the transaction is returned, hence each command is checked.
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	for i, op := range ops {
+		if err = cmds[i].Err(); err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if op.Type == storage.Get {
+			op.Value = nil
+			if err == nil {
+				op.Value, _ = cmds[i].(*redis.StringCmd).Bytes()
+			}
+		}
+	}
+	return nil
+}
+
+// Close does nothing, since the connections to the server are shared by all the clients
+func (c *redisStorageClient) Close(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestClientOperations(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// Deleting a missing key is not an error
+	assert.NoError(t, client.Delete(ctx, "key"))
+}
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	require.NoError(t, client.Set(ctx, "existing", []byte("old")))
+
+	getExisting := storage.GetOperation("existing")
+	getMissing := storage.GetOperation("missing")
+	getSet := storage.GetOperation("new")
+	getDeleted := storage.GetOperation("existing")
+	require.NoError(t, client.Batch(ctx,
+		getExisting,
+		getMissing,
+		storage.SetOperation("new", []byte("value")),
+		getSet,
+		storage.DeleteOperation("existing"),
+		getDeleted,
+	))
+	assert.Equal(t, []byte("old"), getExisting.Value)
+	assert.Nil(t, getMissing.Value)
+	assert.Equal(t, []byte("value"), getSet.Value)
+	assert.Nil(t, getDeleted.Value)
+
+	value, err := client.Get(ctx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	invalid := storage.GetOperation("key")
+	invalid.Type = -1
+	assert.Error(t, client.Batch(ctx, invalid))
+}
+
+func TestClientPrefix(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t, "")
+	cfg := newTestConfig(server.endpoint())
+	cfg.Prefix = "collector:"
+	se := newTestExtension(t, cfg)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	receiverClient, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("one"), "")
+	require.NoError(t, err)
+	exporterClient, err := se.GetClient(ctx, component.KindExporter, newTestEntity("one"), "queue")
+	require.NoError(t, err)
+
+	require.NoError(t, receiverClient.Set(ctx, "key", []byte("receiver")))
+	require.NoError(t, exporterClient.Set(ctx, "key", []byte("exporter")))
+
+	assert.Equal(t, map[string]string{
+		"collector:receiver_nop_one:key":       "receiver",
+		"collector:exporter_nop_one_queue:key": "exporter",
+	}, server.keys())
+}
+
+func TestClientExpiration(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t, "")
+	cfg := newTestConfig(server.endpoint())
+	cfg.Expiration = 90 * time.Second
+	se := newTestExtension(t, cfg)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("one"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("batched", []byte("value"))))
+	assert.Equal(t, 90*time.Second, server.ttl("receiver_nop_one:key"))
+	assert.Equal(t, 90*time.Second, server.ttl("receiver_nop_one:batched"))
+}
+
+func newTestClient(t *testing.T) storage.Client {
+	server := newFakeServer(t, "")
+	se := newTestExtension(t, newTestConfig(server.endpoint()))
+	require.NoError(t, se.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, se.Shutdown(context.Background()))
+	})
+
+	client, err := se.GetClient(context.Background(), component.KindReceiver, newTestEntity("client"), "")
+	require.NoError(t, err)
+	return client
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines configuration for redisstorage extension.
+type Config struct {
+	// Endpoint is the address of the Redis server, in the host:port format.
+	Endpoint string `mapstructure:"endpoint"`
+	// Password is the optional password used to authenticate to the server.
+	Password configopaque.String `mapstructure:"password"`
+	// DB is the index of the database to select on the server.
+	DB int `mapstructure:"db"`
+	// Prefix is prepended to all the keys, which allows several collector deployments to share a server.
+	Prefix string `mapstructure:"prefix"`
+	// Expiration is the optional time to live of the stored keys, renewed every time a key is set.
+	// If zero, the keys do not expire.
+	Expiration time.Duration `mapstructure:"expiration"`
+	// TLS configures the connection to the server, which is not encrypted by default.
+	TLS configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("missing endpoint")
+	}
+	if cfg.DB < 0 {
+		return errors.New("db must not be negative")
+	}
+	if cfg.Expiration < 0 {
+		return errors.New("expiration must not be negative")
+	}
+	return nil
+}
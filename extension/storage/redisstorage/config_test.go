@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    Config
+		errWanted error
+	}{
+		{
+			"Missing endpoint",
+			Config{},
+			errors.New("missing endpoint"),
+		},
+		{
+			"Negative db",
+			Config{Endpoint: "localhost:6379", DB: -1},
+			errors.New("db must not be negative"),
+		},
+		{
+			"Negative expiration",
+			Config{Endpoint: "localhost:6379", Expiration: -time.Second},
+			errors.New("expiration must not be negative"),
+		},
+		{
+			"valid",
+			Config{Endpoint: "localhost:6379", DB: 1, Prefix: "collector:", Expiration: time.Hour},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.config.Validate()
+		if test.errWanted == nil {
+			assert.NoError(t, err)
+		} else {
+			assert.Equal(t, test.errWanted, err)
+		}
+	}
+}
+
+func TestDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.Equal(t, &Config{Endpoint: defaultEndpoint, TLS: configtls.TLSClientSetting{Insecure: true}}, cfg)
+	assert.NoError(t, cfg.Validate())
+}
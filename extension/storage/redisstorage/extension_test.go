@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestExtensionIntegrity(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeServer(t, "")
+	se := newTestExtension(t, newTestConfig(server.endpoint()))
+	err := se.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		err = se.Shutdown(context.Background())
+		assert.NoError(t, err)
+	}()
+
+	type mockComponent struct {
+		kind component.Kind
+		name component.ID
+	}
+
+	components := []mockComponent{
+		{kind: component.KindReceiver, name: newTestEntity("receiver_one")},
+		{kind: component.KindReceiver, name: newTestEntity("receiver_two")},
+		{kind: component.KindProcessor, name: newTestEntity("processor_one")},
+		{kind: component.KindProcessor, name: newTestEntity("processor_two")},
+		{kind: component.KindExporter, name: newTestEntity("exporter_one")},
+		{kind: component.KindExporter, name: newTestEntity("exporter_two")},
+		{kind: component.KindExtension, name: newTestEntity("extension_one")},
+		{kind: component.KindExtension, name: newTestEntity("extension_two")},
+	}
+
+	// Make a client for each component
+	clients := make(map[component.ID]storage.Client)
+	for _, c := range components {
+		client, err := se.GetClient(ctx, c.kind, c.name, "")
+		require.NoError(t, err)
+		clients[c.name] = client
+	}
+
+	thrashClient := func(wg *sync.WaitGroup, n component.ID, c storage.Client) {
+		defer wg.Done()
+		// keys and values
+		keys := []string{"a", "b", "c", "d", "e"}
+		myBytes := []byte(n.Name())
+
+		// Set my values
+		for i := 0; i < len(keys); i++ {
+			assert.NoError(t, c.Set(ctx, keys[i], myBytes))
+		}
+
+		// Repeatedly thrash client
+		for j := 0; j < 20; j++ {
+
+			// Make sure my values are still mine
+			for i := 0; i < len(keys); i++ {
+				v, err := c.Get(ctx, keys[i])
+				assert.NoError(t, err)
+				assert.Equal(t, myBytes, v)
+			}
+
+			// Delete my values
+			for i := 0; i < len(keys); i++ {
+				assert.NoError(t, c.Delete(ctx, keys[i]))
+			}
+
+			// Reset my values
+			for i := 0; i < len(keys); i++ {
+				assert.NoError(t, c.Set(ctx, keys[i], myBytes))
+			}
+		}
+		assert.NoError(t, c.Close(ctx))
+	}
+
+	// Use clients concurrently
+	var wg sync.WaitGroup
+	for name, client := range clients {
+		wg.Add(1)
+		go thrashClient(&wg, name, client)
+	}
+	wg.Wait()
+
+	// Each component has its own keys on the server
+	assert.Len(t, server.keys(), len(components)*5)
+}
+
+func TestExtensionPassword(t *testing.T) {
+	server := newFakeServer(t, "secret")
+
+	cfg := newTestConfig(server.endpoint())
+	cfg.Password = "wrong"
+	se := newTestExtension(t, cfg)
+	assert.Error(t, se.Start(context.Background(), componenttest.NewNopHost()))
+
+	cfg = newTestConfig(server.endpoint())
+	cfg.Password = "secret"
+	se = newTestExtension(t, cfg)
+	require.NoError(t, se.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, se.Shutdown(context.Background()))
+}
+
+func TestExtensionTLS(t *testing.T) {
+	server, certFile := newFakeTLSServer(t)
+
+	se := newTestExtension(t, newTestConfig(server.endpoint()))
+	assert.Error(t, se.Start(context.Background(), componenttest.NewNopHost()), "a plain connection is rejected")
+
+	cfg := newTestConfig(server.endpoint())
+	cfg.TLS.Insecure = false
+	se = newTestExtension(t, cfg)
+	assert.Error(t, se.Start(context.Background(), componenttest.NewNopHost()), "the certificate is not trusted")
+
+	cfg = newTestConfig(server.endpoint())
+	cfg.TLS.Insecure = false
+	cfg.TLS.CAFile = certFile
+	se = newTestExtension(t, cfg)
+	require.NoError(t, se.Start(context.Background(), componenttest.NewNopHost()))
+	client, err := se.GetClient(context.Background(), component.KindReceiver, newTestEntity("tls"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(context.Background(), "key", []byte("value")))
+	assert.Equal(t, map[string]string{"receiver_nop_tls:key": "value"}, server.keys())
+	assert.NoError(t, se.Shutdown(context.Background()))
+
+	cfg = newTestConfig(server.endpoint())
+	cfg.TLS.CAFile = "missing.pem"
+	se = newTestExtension(t, cfg)
+	assert.Error(t, se.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestExtensionUnreachableServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	se := newTestExtension(t, newTestConfig(endpoint))
+	assert.Error(t, se.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, se.Shutdown(context.Background()))
+}
+
+func newTestConfig(endpoint string) *Config {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	return cfg
+}
+
+func newTestExtension(t *testing.T, cfg *Config) storage.Extension {
+	f := NewFactory()
+	extension, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	return se
+}
+
+func newTestEntity(name string) component.ID {
+	return component.NewIDWithName("nop", name)
+}
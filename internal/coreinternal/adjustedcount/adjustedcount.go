@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package adjustedcount reads the adjusted count recorded on sampled items, such as the one set by the
+// probabilistic sampler processor, so that connectors can estimate the number of items before sampling.
+package adjustedcount // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/adjustedcount"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// FromAttributes returns the number of items represented by an item with the given attributes, as recorded
+// in the attribute named key. Items without a positive and finite numeric value, or when key is empty,
+// represent only themselves.
+func FromAttributes(attrs pcommon.Map, key string) float64 {
+	if key == "" {
+		return 1
+	}
+	val, ok := attrs.Get(key)
+	if !ok {
+		return 1
+	}
+	switch val.Type() {
+	case pcommon.ValueTypeDouble:
+		if v := val.Double(); v > 0 && !math.IsInf(v, 1) {
+			return v
+		}
+	case pcommon.ValueTypeInt:
+		if val.Int() > 0 {
+			return float64(val.Int())
+		}
+	}
+	return 1
+}
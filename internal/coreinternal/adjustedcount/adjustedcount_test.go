@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adjustedcount
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestFromAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected float64
+	}{
+		{name: "double", value: 2.5, expected: 2.5},
+		{name: "int", value: int64(4), expected: 4},
+		{name: "missing", expected: 1},
+		{name: "string", value: "10", expected: 1},
+		{name: "zero", value: 0.0, expected: 1},
+		{name: "negative", value: int64(-2), expected: 1},
+		{name: "nan", value: math.NaN(), expected: 1},
+		{name: "infinite", value: math.Inf(1), expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := pcommon.NewMap()
+			if tt.value != nil {
+				require.NoError(t, attrs.PutEmpty("sampling.adjusted_count").FromRaw(tt.value))
+			}
+			assert.Equal(t, tt.expected, FromAttributes(attrs, "sampling.adjusted_count"))
+		})
+	}
+	assert.Equal(t, float64(1), FromAttributes(pcommon.NewMap(), ""))
+}
@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package filereload exposes util functionality for extensions reloading their settings or credentials
+// from a file while the collector is running, watching the file and counting the reloads.
+package filereload // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
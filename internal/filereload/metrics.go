@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereload // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+)
+
+// TagExtensionKey is the key of the tag holding the ID of the extension which reloaded its file.
+var TagExtensionKey, _ = tag.NewKey("extension")
+
+// Metrics counts the successful and failed reloads of the extensions of a type.
+type Metrics struct {
+	views    []*view.View
+	reloaded *stats.Int64Measure
+	failed   *stats.Int64Measure
+
+	registerOnce sync.Once
+	errRegister  error
+}
+
+// NewMetrics creates the metrics of the extensions of a type, counting the reloads with the given measures.
+// The views are named "extension/<type>/<measure>".
+func NewMetrics(extensionType component.Type, reloaded, failed *stats.Int64Measure) *Metrics {
+	m := &Metrics{reloaded: reloaded, failed: failed}
+	for _, measure := range []*stats.Int64Measure{reloaded, failed} {
+		m.views = append(m.views, &view.View{
+			Name:        "extension/" + string(extensionType) + "/" + measure.Name(),
+			Measure:     measure,
+			Description: measure.Description(),
+			TagKeys:     []tag.Key{TagExtensionKey},
+			Aggregation: view.Sum(),
+		})
+	}
+	return m
+}
+
+// Register registers the views of the metrics once, returning the registration error
+// to every extension created afterwards.
+func (m *Metrics) Register() error {
+	m.registerOnce.Do(func() {
+		if err := view.Register(m.views...); err != nil {
+			m.errRegister = fmt.Errorf("failed to register the metric views: %w", err)
+		}
+	})
+	return m.errRegister
+}
+
+// Views returns the views of the metrics.
+func (m *Metrics) Views() []*view.View {
+	return m.views
+}
+
+// RecordReloaded counts a successful reload of the extension.
+func (m *Metrics) RecordReloaded(id component.ID) {
+	record(id, m.reloaded)
+}
+
+// RecordReloadFailed counts a failed reload of the extension.
+func (m *Metrics) RecordReloadFailed(id component.ID) {
+	record(id, m.failed)
+}
+
+func record(id component.ID, measure *stats.Int64Measure) {
+	_ = stats.RecordWithTags(context.Background(), []tag.Mutator{tag.Upsert(TagExtensionKey, id.String())}, measure.M(1))
+}
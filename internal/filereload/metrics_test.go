@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics("test",
+		stats.Int64("test_reloaded", "Number of reloads", stats.UnitDimensionless),
+		stats.Int64("test_reload_failed", "Number of failed reloads", stats.UnitDimensionless))
+	require.NoError(t, m.Register())
+	defer view.Unregister(m.Views()...)
+	// The views are registered once
+	require.NoError(t, m.Register())
+
+	id := component.NewIDWithName("test", "1")
+	m.RecordReloaded(id)
+	m.RecordReloaded(id)
+	m.RecordReloadFailed(id)
+	m.RecordReloaded(component.NewIDWithName("test", "2"))
+
+	assert.Equal(t, float64(2), count(t, "extension/test/test_reloaded", id))
+	assert.Equal(t, float64(1), count(t, "extension/test/test_reload_failed", id))
+}
+
+func TestMetricsRegisterError(t *testing.T) {
+	measure := stats.Int64("test_conflict", "Number of reloads", stats.UnitDimensionless)
+	conflicting := &view.View{Name: "extension/conflict/test_conflict", Measure: measure, Aggregation: view.Count()}
+	require.NoError(t, view.Register(conflicting))
+	defer view.Unregister(conflicting)
+
+	m := NewMetrics("conflict", measure, stats.Int64("test_conflict_failed", "Number of failed reloads", stats.UnitDimensionless))
+	assert.Error(t, m.Register())
+	// The registration error is returned to every caller
+	assert.Error(t, m.Register())
+}
+
+func count(t *testing.T, name string, id component.ID) float64 {
+	rows, err := view.RetrieveData(name)
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key == TagExtensionKey && tag.Value == id.String() {
+				return row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereload // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/filereload"
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// Watcher calls a function each time a file changes, until it is stopped.
+type Watcher struct {
+	path     string
+	onChange func()
+	logger   *zap.Logger
+
+	watcher    *fsnotify.Watcher
+	last       os.FileInfo
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+// StartWatcher starts watching the file at path, calling onChange from a single goroutine each time it changes.
+// The directory of the file is watched, as the file may be replaced, or be a symlink swapped by Kubernetes.
+func StartWatcher(path string, logger *zap.Logger, onChange func()) (*Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		path:       filepath.Clean(path),
+		onChange:   onChange,
+		logger:     logger,
+		watcher:    watcher,
+		shutdownCh: make(chan struct{}),
+	}
+	w.last, _ = os.Stat(path)
+	w.wg.Add(1)
+	go w.watch()
+	return w, nil
+}
+
+// Stop stops watching the file, waiting for the current call of the function to return.
+func (w *Watcher) Stop() {
+	close(w.shutdownCh)
+	w.wg.Wait()
+}
+
+// watch calls the function on the events of the directory, filtered on the path of the file, or on a change of the
+// file it resolves to since last seen, as Kubernetes updates the mounted secrets and config maps by swapping the
+// symlink of a sibling directory.
+func (w *Watcher) watch() {
+	defer w.wg.Done()
+	defer w.watcher.Close()
+
+	for {
+		select {
+		case <-w.shutdownCh:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) == w.path || w.changed() {
+				w.onChange()
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Warn("Failed to watch the file", zap.String("file", w.path), zap.Error(err))
+		}
+	}
+}
+
+// changed returns whether the file changed since it was last seen, and records its current state
+func (w *Watcher) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		changed := w.last != nil
+		w.last = nil
+		return changed
+	}
+	changed := w.last == nil || !os.SameFile(w.last, info) ||
+		!info.ModTime().Equal(w.last.ModTime()) || info.Size() != w.last.Size()
+	w.last = info
+	return changed
+}
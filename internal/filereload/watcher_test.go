@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereload
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0600))
+
+	var changes atomic.Int64
+	w, err := StartWatcher(path, zaptest.NewLogger(t), func() { changes.Add(1) })
+	require.NoError(t, err)
+
+	// The file is written in place
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0600))
+	assert.Eventually(t, func() bool { return changes.Load() > 0 }, 10*time.Second, 10*time.Millisecond)
+
+	// The file is replaced
+	seen := changes.Load()
+	replaced := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(replaced, []byte("third"), 0600))
+	require.NoError(t, os.Rename(replaced, path))
+	assert.Eventually(t, func() bool { return changes.Load() > seen }, 10*time.Second, 10*time.Millisecond)
+
+	// The function isn't called anymore once stopped
+	w.Stop()
+	seen = changes.Load()
+	require.NoError(t, os.WriteFile(path, []byte("fourth"), 0600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, seen, changes.Load())
+}
+
+func TestWatcherSymlinkSwap(t *testing.T) {
+	// The file is a symlink to a data directory, swapped as done by Kubernetes for the mounted secrets
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "data1"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data1", "file"), []byte("first"), 0600))
+	require.NoError(t, os.Symlink("data1", filepath.Join(dir, "data")))
+	path := filepath.Join(dir, "file")
+	require.NoError(t, os.Symlink(filepath.Join("data", "file"), path))
+
+	var changes atomic.Int64
+	w, err := StartWatcher(path, zaptest.NewLogger(t), func() { changes.Add(1) })
+	require.NoError(t, err)
+	defer w.Stop()
+
+	// A change of an unrelated file doesn't change the file the path resolves to
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other"), []byte("other"), 0600))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(0), changes.Load())
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "data2"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data2", "file"), []byte("second"), 0600))
+	require.NoError(t, os.Symlink("data2", filepath.Join(dir, "data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "data_tmp"), filepath.Join(dir, "data")))
+	assert.Eventually(t, func() bool { return changes.Load() > 0 }, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestStartWatcherMissingDirectory(t *testing.T) {
+	_, err := StartWatcher(filepath.Join(t.TempDir(), "missing", "file"), zaptest.NewLogger(t), func() {})
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ottlexpr parses OTTL expressions returning a value, such as a path, a literal or a
+// converter invocation, for components computing values from telemetry rather than editing it.
+package ottlexpr // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlexpr"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// valueFunctionName is the name of the function wrapping the expressions, since the OTTL grammar
+// only accepts statements starting with a function invocation.
+const valueFunctionName = "value"
+
+type valueArguments[K any] struct {
+	Value ottl.Getter[K] `ottlarg:"0"`
+}
+
+// Functions returns the functions available to value expressions: the standard converters, plus the
+// function used by Parse to wrap the expressions. Parsers passed to Parse must be created with them.
+func Functions[K any]() map[string]ottl.Factory[K] {
+	functions := ottlfuncs.StandardConverters[K]()
+	valueFactory := ottl.NewFactory(valueFunctionName, &valueArguments[K]{}, createValueFunction[K])
+	functions[valueFactory.Name()] = valueFactory
+	return functions
+}
+
+func createValueFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*valueArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("valueFactory args must be of type *valueArguments[K]")
+	}
+	// The arguments are shared by all the expressions parsed with the factory, so the getter is copied.
+	value := args.Value
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		return value.Get(ctx, tCtx)
+	}, nil
+}
+
+// Parse parses a value expression into a statement returning the value of the expression when executed.
+func Parse[K any](parser ottl.Parser[K], expression string) (*ottl.Statement[K], error) {
+	return parser.ParseStatement(fmt.Sprintf("%s(%s)", valueFunctionName, expression))
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlexpr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+func TestParse(t *testing.T) {
+	parser, err := ottllog.NewParser(Functions[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	log := plog.NewLogRecord()
+	log.Attributes().PutStr("user", "alice")
+	log.Attributes().PutInt("bytes", 42)
+	tCtx := ottllog.NewTransformContext(log, pcommon.NewInstrumentationScope(), pcommon.NewResource())
+
+	tests := []struct {
+		expression string
+		expected   any
+	}{
+		{expression: `attributes["user"]`, expected: "alice"},
+		{expression: `attributes["bytes"]`, expected: int64(42)},
+		{expression: `"literal"`, expected: "literal"},
+		{expression: `Concat([attributes["user"], "x"], "-")`, expected: "alice-x"},
+		{expression: `attributes["missing"]`, expected: nil},
+	}
+	// the expressions are all parsed before being executed, since they share the arguments of the parser
+	statements := make([]*ottl.Statement[ottllog.TransformContext], 0, len(tests))
+	for _, tt := range tests {
+		statement, err := Parse(parser, tt.expression)
+		require.NoError(t, err, tt.expression)
+		statements = append(statements, statement)
+	}
+	for i, tt := range tests {
+		value, _, err := statements[i].Execute(context.Background(), tCtx)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, value, tt.expression)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	parser, err := ottllog.NewParser(Functions[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	_, err = Parse(parser, `attributes["user"`)
+	assert.Error(t, err)
+	_, err = Parse(parser, `Unknown(attributes["user"])`)
+	assert.Error(t, err)
+}
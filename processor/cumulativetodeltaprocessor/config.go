@@ -31,6 +31,14 @@ type Config struct {
 	// Cannot be used with deprecated Metrics config option.
 	Include MatchMetrics `mapstructure:"include"`
 	Exclude MatchMetrics `mapstructure:"exclude"`
+
+	// StorageID is the ID of a storage extension used to persist the tracked state across restarts.
+	// If not set, state is only kept in memory.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// SnapshotInterval is how often the tracked state is written to the storage extension.
+	// The state is always written on shutdown.
+	SnapshotInterval time.Duration `mapstructure:"snapshot_interval"`
 }
 
 type MatchMetrics struct {
@@ -52,5 +60,8 @@ func (config *Config) Validate() error {
 		(len(config.Exclude.MatchType) > 0 && len(config.Exclude.Metrics) == 0) {
 		return fmt.Errorf("metrics must be supplied if match_type is set")
 	}
+	if config.StorageID != nil && config.SnapshotInterval <= 0 {
+		return fmt.Errorf("snapshot_interval must be positive when storage is set")
+	}
 	return nil
 }
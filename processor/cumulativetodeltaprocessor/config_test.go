@@ -21,6 +21,7 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -49,8 +50,9 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
-				InitialValue: tracking.InitialValueAuto,
+				MaxStaleness:     10 * time.Second,
+				InitialValue:     tracking.InitialValueAuto,
+				SnapshotInterval: 30 * time.Second,
 			},
 		},
 		{
@@ -78,8 +80,9 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
-				InitialValue: tracking.InitialValueAuto,
+				MaxStaleness:     10 * time.Second,
+				InitialValue:     tracking.InitialValueAuto,
+				SnapshotInterval: 30 * time.Second,
 			},
 		},
 		{
@@ -93,21 +96,37 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "auto"),
 			expected: &Config{
-				InitialValue: tracking.InitialValueAuto,
+				InitialValue:     tracking.InitialValueAuto,
+				SnapshotInterval: 30 * time.Second,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "keep"),
 			expected: &Config{
-				InitialValue: tracking.InitialValueKeep,
+				InitialValue:     tracking.InitialValueKeep,
+				SnapshotInterval: 30 * time.Second,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "drop"),
 			expected: &Config{
-				InitialValue: tracking.InitialValueDrop,
+				InitialValue:     tracking.InitialValueDrop,
+				SnapshotInterval: 30 * time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "storage"),
+			expected: &Config{
+				InitialValue:     tracking.InitialValueAuto,
+				MaxStaleness:     time.Hour,
+				StorageID:        &storageID,
+				SnapshotInterval: 10 * time.Second,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_snapshot_interval"),
+			errorMessage: "snapshot_interval must be positive when storage is set",
+		},
 	}
 
 	for _, tt := range tests {
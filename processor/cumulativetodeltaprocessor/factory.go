@@ -6,6 +6,7 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -17,6 +18,8 @@ import (
 
 var processorCapabilities = consumer.Capabilities{MutatesData: true}
 
+const defaultSnapshotInterval = 30 * time.Second
+
 // NewFactory returns a new factory for the Metrics Generation processor.
 func NewFactory() processor.Factory {
 	return processor.NewFactory(
@@ -26,7 +29,9 @@ func NewFactory() processor.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		SnapshotInterval: defaultSnapshotInterval,
+	}
 }
 
 func createMetricsProcessor(
@@ -40,7 +45,7 @@ func createMetricsProcessor(
 		return nil, fmt.Errorf("configuration parsing error")
 	}
 
-	metricsProcessor := newCumulativeToDeltaProcessor(processorConfig, set.Logger)
+	metricsProcessor := newCumulativeToDeltaProcessor(processorConfig, set.ID, set.Logger)
 
 	return processorhelper.NewMetricsProcessor(
 		ctx,
@@ -49,5 +54,6 @@ func createMetricsProcessor(
 		nextConsumer,
 		metricsProcessor.processMetrics,
 		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(metricsProcessor.start),
 		processorhelper.WithShutdown(metricsProcessor.shutdown))
 }
@@ -26,7 +26,7 @@ func TestType(t *testing.T) {
 func TestCreateDefaultConfig(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
-	assert.Equal(t, cfg, &Config{})
+	assert.Equal(t, cfg, &Config{SnapshotInterval: defaultSnapshotInterval})
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
 }
 
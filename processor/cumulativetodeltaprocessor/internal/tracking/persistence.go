@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+const (
+	// snapshotVersion is bumped whenever the encoding of a snapshot changes
+	// in a way that older collectors can't read.
+	snapshotVersion = 1
+
+	// snapshotShards is the number of storage keys the tracker state is
+	// spread across, so that a single value doesn't grow with the number of
+	// tracked series.
+	snapshotShards = 16
+)
+
+// snapshot is the persisted form of one shard of the tracker state.
+type snapshot struct {
+	Version int
+	States  map[string]ValuePoint
+}
+
+func shardKey(shard int) string {
+	return fmt.Sprintf("state_%02d", shard)
+}
+
+func shardOf(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % snapshotShards)
+}
+
+// Save writes the current state of the tracker to the storage client.
+// States are grouped into shards, which are written in a single batch.
+func (t *MetricTracker) Save(ctx context.Context, client storage.Client) error {
+	shards := make([]snapshot, snapshotShards)
+	for i := range shards {
+		shards[i] = snapshot{Version: snapshotVersion, States: map[string]ValuePoint{}}
+	}
+
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+		s.Lock()
+		point := s.PrevPoint
+		if point.HistogramValue != nil {
+			hist := point.HistogramValue.Clone()
+			point.HistogramValue = &hist
+		}
+		s.Unlock()
+
+		k := key.(string)
+		shards[shardOf(k)].States[k] = point
+		return true
+	})
+
+	ops := make([]storage.Operation, 0, snapshotShards)
+	for i, shard := range shards {
+		// gob is used rather than JSON because identity keys contain raw
+		// hash bytes and histogram sums may be NaN.
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(shard); err != nil {
+			return fmt.Errorf("failed to encode state shard %d: %w", i, err)
+		}
+		ops = append(ops, storage.SetOperation(shardKey(i), buf.Bytes()))
+	}
+	return client.Batch(ctx, ops...)
+}
+
+// Load restores the tracker state previously written by Save. States that
+// were last observed longer than max_staleness ago are dropped.
+func (t *MetricTracker) Load(ctx context.Context, client storage.Client) error {
+	ops := make([]storage.Operation, 0, snapshotShards)
+	for i := 0; i < snapshotShards; i++ {
+		ops = append(ops, storage.GetOperation(shardKey(i)))
+	}
+	if err := client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+
+	var staleBefore pcommon.Timestamp
+	if t.maxStaleness > 0 {
+		staleBefore = pcommon.NewTimestampFromTime(time.Now().Add(-t.maxStaleness))
+	}
+
+	loaded, dropped := 0, 0
+	for i, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		var shard snapshot
+		if err := gob.NewDecoder(bytes.NewReader(op.Value)).Decode(&shard); err != nil {
+			return fmt.Errorf("failed to decode state shard %d: %w", i, err)
+		}
+		if shard.Version != snapshotVersion {
+			t.logger.Warn("ignoring state shard with unsupported version",
+				zap.Int("shard", i), zap.Int("version", shard.Version))
+			continue
+		}
+		for key, point := range shard.States {
+			if point.ObservedTimestamp < staleBefore {
+				dropped++
+				continue
+			}
+			// Points that arrived before the state was loaded take precedence.
+			t.states.LoadOrStore(key, &State{PrevPoint: point})
+			loaded++
+		}
+	}
+	t.logger.Debug("loaded tracker state", zap.Int("loaded", loaded), zap.Int("stale", dropped))
+	return nil
+}
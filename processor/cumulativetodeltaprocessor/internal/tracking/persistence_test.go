@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracking
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type mapClient struct {
+	storage.Client
+	values map[string][]byte
+}
+
+func newMapClient() *mapClient {
+	return &mapClient{Client: storage.NewNopClient(), values: map[string][]byte{}}
+}
+
+func (c *mapClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.values[op.Key]
+		case storage.Set:
+			c.values[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.values, op.Key)
+		}
+	}
+	return nil
+}
+
+func TestMetricTracker_SaveLoad(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sum := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		MetricName:             "sum",
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+	sum.Resource.Attributes().PutStr("host", "a")
+	hist := sum
+	hist.MetricName = "hist"
+	hist.MetricType = pmetric.MetricTypeHistogram
+
+	now := time.Now()
+	ts := func(d time.Duration) pcommon.Timestamp { return pcommon.NewTimestampFromTime(now.Add(d)) }
+
+	before := NewMetricTracker(ctx, zap.NewNop(), 0, InitialValueAuto)
+	before.Convert(MetricPoint{Identity: sum, Value: ValuePoint{ObservedTimestamp: ts(0), IntValue: 100}})
+	before.Convert(MetricPoint{Identity: hist, Value: ValuePoint{
+		ObservedTimestamp: ts(0),
+		HistogramValue:    &HistogramPoint{Count: 10, Sum: 8, Buckets: []uint64{4, 6}},
+	}})
+
+	client := newMapClient()
+	require.NoError(t, before.Save(ctx, client))
+	assert.Len(t, client.values, snapshotShards)
+
+	after := NewMetricTracker(ctx, zap.NewNop(), 0, InitialValueAuto)
+	require.NoError(t, after.Load(ctx, client))
+
+	out, valid := after.Convert(MetricPoint{Identity: sum, Value: ValuePoint{ObservedTimestamp: ts(time.Minute), IntValue: 150}})
+	assert.True(t, valid)
+	assert.Equal(t, DeltaValue{StartTimestamp: ts(0), IntValue: 50}, out)
+
+	out, valid = after.Convert(MetricPoint{Identity: hist, Value: ValuePoint{
+		ObservedTimestamp: ts(time.Minute),
+		HistogramValue:    &HistogramPoint{Count: 15, Sum: 20, Buckets: []uint64{5, 10}},
+	}})
+	assert.True(t, valid)
+	assert.Equal(t, &HistogramPoint{Count: 5, Sum: 12, Buckets: []uint64{1, 4}}, out.HistogramValue)
+}
+
+func TestMetricTracker_LoadDropsStale(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeDouble,
+	}
+	fresh, stale := mi, mi
+	fresh.MetricName = "fresh"
+	stale.MetricName = "stale"
+
+	before := NewMetricTracker(ctx, zap.NewNop(), 0, InitialValueAuto)
+	before.Convert(MetricPoint{Identity: fresh, Value: ValuePoint{ObservedTimestamp: pcommon.NewTimestampFromTime(time.Now())}})
+	before.Convert(MetricPoint{Identity: stale, Value: ValuePoint{ObservedTimestamp: pcommon.NewTimestampFromTime(time.Now().Add(-time.Hour))}})
+
+	client := newMapClient()
+	require.NoError(t, before.Save(ctx, client))
+
+	after := NewMetricTracker(ctx, zap.NewNop(), time.Minute, InitialValueAuto)
+	require.NoError(t, after.Load(ctx, client))
+
+	keys := 0
+	after.states.Range(func(_, _ interface{}) bool {
+		keys++
+		return true
+	})
+	assert.Equal(t, 1, keys)
+}
+
+func TestMetricTracker_LoadEmpty(t *testing.T) {
+	tracker := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueAuto)
+	require.NoError(t, tracker.Load(context.Background(), newMapClient()))
+}
+
+func TestMetricTracker_LoadCorrupt(t *testing.T) {
+	client := newMapClient()
+	client.values[shardKey(3)] = []byte("not a snapshot")
+	tracker := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueAuto)
+	assert.ErrorContains(t, tracker.Load(context.Background(), client), "failed to decode state shard 3")
+}
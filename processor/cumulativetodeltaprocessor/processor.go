@@ -5,9 +5,15 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
@@ -20,14 +26,24 @@ type cumulativeToDeltaProcessor struct {
 	logger          *zap.Logger
 	deltaCalculator *tracking.MetricTracker
 	cancelFunc      context.CancelFunc
+
+	id               component.ID
+	storageID        *component.ID
+	snapshotInterval time.Duration
+	storageClient    storage.Client
+	stopSnapshots    context.CancelFunc
+	snapshotWG       sync.WaitGroup
 }
 
-func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
+func newCumulativeToDeltaProcessor(config *Config, id component.ID, logger *zap.Logger) *cumulativeToDeltaProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 	p := &cumulativeToDeltaProcessor{
-		logger:          logger,
-		deltaCalculator: tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.InitialValue),
-		cancelFunc:      cancel,
+		logger:           logger,
+		deltaCalculator:  tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.InitialValue),
+		cancelFunc:       cancel,
+		id:               id,
+		storageID:        config.StorageID,
+		snapshotInterval: config.SnapshotInterval,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -104,11 +120,69 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 	return md, nil
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shutdown(context.Context) error {
-	ctdp.cancelFunc()
+// start restores the tracked state from the configured storage extension,
+// if any, and starts taking periodic snapshots of it.
+func (ctdp *cumulativeToDeltaProcessor) start(ctx context.Context, host component.Host) error {
+	if ctdp.storageID == nil {
+		return nil
+	}
+
+	ext, ok := host.GetExtensions()[*ctdp.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", ctdp.storageID)
+	}
+	storageExtension, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", ctdp.storageID)
+	}
+	client, err := storageExtension.GetClient(ctx, component.KindProcessor, ctdp.id, "")
+	if err != nil {
+		return err
+	}
+	ctdp.storageClient = client
+
+	// A snapshot that can't be read shouldn't keep the collector from starting,
+	// the processor then behaves as if it was started without any state.
+	if err = ctdp.deltaCalculator.Load(ctx, client); err != nil {
+		ctdp.logger.Warn("failed to load persisted state", zap.Error(err))
+	}
+
+	snapshotCtx, cancel := context.WithCancel(context.Background())
+	ctdp.stopSnapshots = cancel
+	ctdp.snapshotWG.Add(1)
+	go ctdp.snapshotLoop(snapshotCtx)
 	return nil
 }
 
+func (ctdp *cumulativeToDeltaProcessor) snapshotLoop(ctx context.Context) {
+	defer ctdp.snapshotWG.Done()
+
+	ticker := time.NewTicker(ctdp.snapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ctdp.deltaCalculator.Save(ctx, ctdp.storageClient); err != nil {
+				ctdp.logger.Warn("failed to persist state", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (ctdp *cumulativeToDeltaProcessor) shutdown(ctx context.Context) error {
+	ctdp.cancelFunc()
+	if ctdp.storageClient == nil {
+		return nil
+	}
+	ctdp.stopSnapshots()
+	ctdp.snapshotWG.Wait()
+
+	err := ctdp.deltaCalculator.Save(ctx, ctdp.storageClient)
+	return multierr.Append(err, ctdp.storageClient.Close(ctx))
+}
+
 func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metricName string) bool {
 	return (ctdp.includeFS == nil || ctdp.includeFS.Matches(metricName)) &&
 		(ctdp.excludeFS == nil || !ctdp.excludeFS.Matches(metricName))
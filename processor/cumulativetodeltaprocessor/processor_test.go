@@ -12,7 +12,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor"
@@ -654,3 +656,95 @@ func BenchmarkConsumeMetrics(b *testing.B) {
 		assert.NoError(b, p.ConsumeMetrics(context.Background(), metrics))
 	}
 }
+
+type storageHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h storageHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type mapStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client *mapClient
+}
+
+func (s *mapStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return s.client, nil
+}
+
+type mapClient struct {
+	storage.Client
+	values map[string][]byte
+}
+
+func (c *mapClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.values[op.Key]
+		case storage.Set:
+			c.values[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.values, op.Key)
+		}
+	}
+	return nil
+}
+
+func TestCumulativeToDeltaProcessor_Storage(t *testing.T) {
+	storageID := component.NewID("map_storage")
+	host := storageHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			storageID: &mapStorage{client: &mapClient{Client: storage.NewNopClient(), values: map[string][]byte{}}},
+		},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	start := pcommon.NewTimestampFromTime(time.Now().Add(-time.Hour))
+	consume := func(value float64) pmetric.Metrics {
+		next := new(consumertest.MetricsSink)
+		p, err := createMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, next)
+		require.NoError(t, err)
+		require.NoError(t, p.Start(context.Background(), host))
+
+		md := pmetric.NewMetrics()
+		sum := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		dp.SetDoubleValue(value)
+		require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+		require.NoError(t, p.Shutdown(context.Background()))
+		require.Len(t, next.AllMetrics(), 1)
+		return next.AllMetrics()[0]
+	}
+
+	// The first point started before the processor and is dropped.
+	assert.Equal(t, 0, consume(100).DataPointCount())
+
+	// After a restart the delta is calculated against the persisted point.
+	out := consume(150)
+	require.Equal(t, 1, out.DataPointCount())
+	dp := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, 50.0, dp.DoubleValue())
+}
+
+func TestCumulativeToDeltaProcessor_StorageNotFound(t *testing.T) {
+	storageID := component.NewID("missing")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	p, err := createMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, p.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'missing' not found")
+}
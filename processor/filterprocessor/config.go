@@ -37,6 +37,21 @@ type Config struct {
 	Spans filterconfig.MatchConfig `mapstructure:"spans"`
 
 	Traces TraceFilters `mapstructure:"traces"`
+
+	// RuntimeSettings allows the OTTL conditions to be replaced at runtime by the
+	// values held by a runtime settings extension.
+	RuntimeSettings *RuntimeSettingsConfig `mapstructure:"runtime_settings"`
+}
+
+// RuntimeSettingsConfig configures where the OTTL conditions are read from at runtime.
+type RuntimeSettingsConfig struct {
+	// Extension is the ID of the runtime settings extension.
+	Extension component.ID `mapstructure:"extension"`
+
+	// KeyPrefix is prepended to the key of each OTTL context, e.g. `<key_prefix>.traces.span`.
+	// A key that holds a list of conditions, even an empty one, replaces the conditions
+	// configured for that context; a missing key restores them.
+	KeyPrefix string `mapstructure:"key_prefix"`
 }
 
 // MetricFilters filters by Metric properties.
@@ -276,6 +291,19 @@ func (cfg *Config) Validate() error {
 	if cfg.Logs.LogConditions != nil && (cfg.Logs.Include != nil || cfg.Logs.Exclude != nil) {
 		return fmt.Errorf("cannot use ottl conditions and include/exclude for logs at the same time")
 	}
+	if cfg.RuntimeSettings != nil {
+		if cfg.Spans.Include != nil || cfg.Spans.Exclude != nil ||
+			cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil ||
+			cfg.Logs.Include != nil || cfg.Logs.Exclude != nil {
+			return fmt.Errorf("cannot use runtime_settings and include/exclude at the same time")
+		}
+		if cfg.RuntimeSettings.Extension == (component.ID{}) {
+			return fmt.Errorf("runtime_settings: extension must be set")
+		}
+		if cfg.RuntimeSettings.KeyPrefix == "" {
+			return fmt.Errorf("runtime_settings: key_prefix must be set")
+		}
+	}
 
 	var errors error
 
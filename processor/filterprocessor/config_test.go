@@ -892,6 +892,33 @@ func TestLoadingConfigOTTL(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "logs_mix_config"),
 			errorMessage: "cannot use ottl conditions and include/exclude for logs at the same time",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "runtime_settings"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Logs: LogFilters{
+					LogConditions: []string{
+						`severity_number < SEVERITY_NUMBER_WARN`,
+					},
+				},
+				RuntimeSettings: &RuntimeSettingsConfig{
+					Extension: component.NewID("runtime_settings"),
+					KeyPrefix: "filter",
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "runtime_settings_mix_config"),
+			errorMessage: "cannot use runtime_settings and include/exclude at the same time",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "runtime_settings_no_extension"),
+			errorMessage: "runtime_settings: extension must be set",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "runtime_settings_no_key_prefix"),
+			errorMessage: "runtime_settings: key_prefix must be set",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "bad_syntax_span"),
 			errorMessage: "unable to parse OTTL statement: 1:25: unexpected token \"test\" (expected (<string> | <int>) \"]\")",
@@ -50,7 +50,9 @@ func createMetricsProcessor(
 		cfg,
 		nextConsumer,
 		fp.processMetrics,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(fp.runtimeConditions.start),
+		processorhelper.WithShutdown(fp.runtimeConditions.shutdown))
 }
 
 func createLogsProcessor(
@@ -69,7 +71,9 @@ func createLogsProcessor(
 		cfg,
 		nextConsumer,
 		fp.processLogs,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(fp.runtimeConditions.start),
+		processorhelper.WithShutdown(fp.runtimeConditions.shutdown))
 }
 
 func createTracesProcessor(
@@ -88,5 +92,7 @@ func createTracesProcessor(
 		cfg,
 		nextConsumer,
 		fp.processTraces,
-		processorhelper.WithCapabilities(processorCapabilities))
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(fp.runtimeConditions.start),
+		processorhelper.WithShutdown(fp.runtimeConditions.shutdown))
 }
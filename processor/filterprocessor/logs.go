@@ -21,13 +21,25 @@ import (
 )
 
 type filterLogProcessor struct {
-	skipExpr expr.BoolExpr[ottllog.TransformContext]
-	logger   *zap.Logger
+	skipExpr          expr.BoolExpr[ottllog.TransformContext]
+	runtimeConditions *runtimeConditions
+	logger            *zap.Logger
 }
 
 func newFilterLogsProcessor(set component.TelemetrySettings, cfg *Config) (*filterLogProcessor, error) {
 	flp := &filterLogProcessor{
-		logger: set.Logger,
+		runtimeConditions: newRuntimeConditions(cfg, set.Logger),
+		logger:            set.Logger,
+	}
+	if cfg.RuntimeSettings != nil {
+		skipExpr, err := newReloadableExpr(flp.runtimeConditions, "logs.log_record", cfg.Logs.LogConditions, func(conditions []string) (expr.BoolExpr[ottllog.TransformContext], error) {
+			return filterottl.NewBoolExprForLog(conditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set)
+		})
+		if err != nil {
+			return nil, err
+		}
+		flp.skipExpr = skipExpr
+		return flp, nil
 	}
 	if cfg.Logs.LogConditions != nil {
 		skipExpr, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set)
@@ -29,13 +29,30 @@ type filterMetricProcessor struct {
 	skipResourceExpr  expr.BoolExpr[ottlresource.TransformContext]
 	skipMetricExpr    expr.BoolExpr[ottlmetric.TransformContext]
 	skipDataPointExpr expr.BoolExpr[ottldatapoint.TransformContext]
+	runtimeConditions *runtimeConditions
 	logger            *zap.Logger
 }
 
 func newFilterMetricProcessor(set component.TelemetrySettings, cfg *Config) (*filterMetricProcessor, error) {
 	var err error
 	fsp := &filterMetricProcessor{
-		logger: set.Logger,
+		runtimeConditions: newRuntimeConditions(cfg, set.Logger),
+		logger:            set.Logger,
+	}
+	if cfg.RuntimeSettings != nil {
+		fsp.skipMetricExpr, err = newReloadableExpr(fsp.runtimeConditions, "metrics.metric", cfg.Metrics.MetricConditions, func(conditions []string) (expr.BoolExpr[ottlmetric.TransformContext], error) {
+			return filterottl.NewBoolExprForMetric(conditions, common.MetricFunctions(), cfg.ErrorMode, set)
+		})
+		if err != nil {
+			return nil, err
+		}
+		fsp.skipDataPointExpr, err = newReloadableExpr(fsp.runtimeConditions, "metrics.datapoint", cfg.Metrics.DataPointConditions, func(conditions []string) (expr.BoolExpr[ottldatapoint.TransformContext], error) {
+			return filterottl.NewBoolExprForDataPoint(conditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return fsp, nil
 	}
 	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
 		if cfg.Metrics.MetricConditions != nil {
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+)
+
+// runtimeSettings is the part of the runtime settings extension API used by the processor.
+type runtimeSettings interface {
+	StringSlice(key string) ([]string, bool)
+	Subscribe(fn func()) (unsubscribe func())
+}
+
+// runtimeConditions keeps the OTTL conditions of a processor in sync with the
+// runtime settings extension configured in RuntimeSettingsConfig.
+type runtimeConditions struct {
+	cfg    *RuntimeSettingsConfig
+	logger *zap.Logger
+
+	mu          sync.Mutex
+	updaters    []func(settings runtimeSettings)
+	unsubscribe func()
+}
+
+func newRuntimeConditions(cfg *Config, logger *zap.Logger) *runtimeConditions {
+	return &runtimeConditions{
+		cfg:    cfg.RuntimeSettings,
+		logger: logger,
+	}
+}
+
+func (rc *runtimeConditions) start(_ context.Context, host component.Host) error {
+	if rc.cfg == nil {
+		return nil
+	}
+	ext, ok := host.GetExtensions()[rc.cfg.Extension]
+	if !ok {
+		return fmt.Errorf("extension %q not found", rc.cfg.Extension)
+	}
+	settings, ok := ext.(runtimeSettings)
+	if !ok {
+		return fmt.Errorf("extension %q does not provide runtime settings", rc.cfg.Extension)
+	}
+	update := func() { rc.update(settings) }
+	rc.unsubscribe = settings.Subscribe(update)
+	update()
+	return nil
+}
+
+func (rc *runtimeConditions) shutdown(context.Context) error {
+	if rc.unsubscribe != nil {
+		rc.unsubscribe()
+		rc.unsubscribe = nil
+	}
+	return nil
+}
+
+func (rc *runtimeConditions) update(settings runtimeSettings) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, updater := range rc.updaters {
+		updater(settings)
+	}
+}
+
+// newReloadableExpr compiles the static conditions of an OTTL context and registers the
+// returned expression to be recompiled whenever the conditions held by the runtime
+// settings extension under `<key_prefix>.<name>` change.
+func newReloadableExpr[K any](rc *runtimeConditions, name string, conditions []string, compile func([]string) (expr.BoolExpr[K], error)) (*reloadableExpr[K], error) {
+	re := &reloadableExpr[K]{compile: compile}
+	if _, err := re.set(conditions); err != nil {
+		return nil, err
+	}
+	key := rc.cfg.KeyPrefix + "." + name
+	rc.updaters = append(rc.updaters, func(settings runtimeSettings) {
+		current, ok := settings.StringSlice(key)
+		if !ok {
+			current = conditions
+		}
+		changed, err := re.set(current)
+		if err != nil {
+			rc.logger.Error("Failed to compile filter conditions, keeping the previous ones", zap.String("key", key), zap.Error(err))
+			return
+		}
+		if changed {
+			rc.logger.Info("Filter conditions changed", zap.String("key", key), zap.Strings("conditions", current))
+		}
+	})
+	return re, nil
+}
+
+// reloadableExpr is an expr.BoolExpr whose conditions can be replaced while the processor
+// is running. It never matches while there are no conditions.
+type reloadableExpr[K any] struct {
+	compile func([]string) (expr.BoolExpr[K], error)
+	current atomic.Pointer[compiledConditions[K]]
+}
+
+type compiledConditions[K any] struct {
+	conditions []string
+	expr       expr.BoolExpr[K]
+}
+
+func (re *reloadableExpr[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
+	current := re.current.Load()
+	if current.expr == nil {
+		return false, nil
+	}
+	return current.expr.Eval(ctx, tCtx)
+}
+
+// set compiles the given conditions unless they are the ones already in use.
+func (re *reloadableExpr[K]) set(conditions []string) (bool, error) {
+	if current := re.current.Load(); current != nil && equalConditions(current.conditions, conditions) {
+		return false, nil
+	}
+	var boolExpr expr.BoolExpr[K]
+	if len(conditions) > 0 {
+		var err error
+		if boolExpr, err = re.compile(conditions); err != nil {
+			return false, err
+		}
+	}
+	re.current.Store(&compiledConditions[K]{conditions: conditions, expr: boolExpr})
+	return true, nil
+}
+
+func equalConditions(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestRuntimeSettingsLogConditions(t *testing.T) {
+	settingsID := component.NewID("runtime_settings")
+	settings := &fakeRuntimeSettings{values: map[string][]string{}}
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{settingsID: settings},
+	}
+
+	cfg := &Config{
+		ErrorMode: ottl.IgnoreError,
+		Logs: LogFilters{
+			LogConditions: []string{`severity_number < SEVERITY_NUMBER_WARN`},
+		},
+		RuntimeSettings: &RuntimeSettingsConfig{
+			Extension: settingsID,
+			KeyPrefix: "filter",
+		},
+	}
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	sink := new(consumertest.LogsSink)
+	flp, err := NewFactory().CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, flp.Start(context.Background(), host))
+
+	consume := func() []string {
+		sink.Reset()
+		ld := plog.NewLogs()
+		lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		info := lrs.AppendEmpty()
+		info.SetSeverityNumber(plog.SeverityNumberInfo)
+		info.Body().SetStr("info")
+		errorRecord := lrs.AppendEmpty()
+		errorRecord.SetSeverityNumber(plog.SeverityNumberError)
+		errorRecord.Body().SetStr("error")
+		require.NoError(t, flp.ConsumeLogs(context.Background(), ld))
+
+		var bodies []string
+		for _, logs := range sink.AllLogs() {
+			lrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			for i := 0; i < lrs.Len(); i++ {
+				bodies = append(bodies, lrs.At(i).Body().Str())
+			}
+		}
+		return bodies
+	}
+
+	assert.Equal(t, []string{"error"}, consume(), "the configured conditions apply without runtime settings")
+
+	settings.set("filter.logs.log_record", []string{`severity_number >= SEVERITY_NUMBER_WARN`})
+	assert.Equal(t, []string{"info"}, consume())
+
+	settings.set("filter.logs.log_record", []string{`severity_number >=`})
+	assert.Equal(t, []string{"info"}, consume(), "invalid conditions keep the previous ones")
+
+	settings.set("filter.logs.log_record", []string{})
+	assert.Equal(t, []string{"info", "error"}, consume(), "an empty list of conditions drops nothing")
+
+	settings.delete("filter.logs.log_record")
+	assert.Equal(t, []string{"error"}, consume(), "a missing key restores the configured conditions")
+
+	require.NoError(t, flp.Shutdown(context.Background()))
+	assert.Empty(t, settings.subscribers)
+}
+
+func TestRuntimeSettingsStartErrors(t *testing.T) {
+	cfg := &Config{
+		ErrorMode: ottl.PropagateError,
+		RuntimeSettings: &RuntimeSettingsConfig{
+			Extension: component.NewID("runtime_settings"),
+			KeyPrefix: "filter",
+		},
+	}
+
+	fsp, err := NewFactory().CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, fsp.Start(context.Background(), componenttest.NewNopHost()), `extension "runtime_settings" not found`)
+
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{component.NewID("runtime_settings"): &nopExtension{}},
+	}
+	assert.EqualError(t, fsp.Start(context.Background(), host), `extension "runtime_settings" does not provide runtime settings`)
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type fakeRuntimeSettings struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu          sync.Mutex
+	values      map[string][]string
+	subscribers map[int]func()
+	nextID      int
+}
+
+func (s *fakeRuntimeSettings) StringSlice(key string) ([]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+func (s *fakeRuntimeSettings) Subscribe(fn func()) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscribers == nil {
+		s.subscribers = map[int]func(){}
+	}
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = fn
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers, id)
+	}
+}
+
+func (s *fakeRuntimeSettings) set(key string, value []string) {
+	s.mu.Lock()
+	s.values[key] = value
+	s.mu.Unlock()
+	s.notify()
+}
+
+func (s *fakeRuntimeSettings) delete(key string) {
+	s.mu.Lock()
+	delete(s.values, key)
+	s.mu.Unlock()
+	s.notify()
+}
+
+func (s *fakeRuntimeSettings) notify() {
+	s.mu.Lock()
+	subscribers := make([]func(), 0, len(s.subscribers))
+	for _, fn := range s.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	s.mu.Unlock()
+	for _, fn := range subscribers {
+		fn()
+	}
+}
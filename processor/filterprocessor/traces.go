@@ -22,13 +22,30 @@ import (
 type filterSpanProcessor struct {
 	skipSpanExpr      expr.BoolExpr[ottlspan.TransformContext]
 	skipSpanEventExpr expr.BoolExpr[ottlspanevent.TransformContext]
+	runtimeConditions *runtimeConditions
 	logger            *zap.Logger
 }
 
 func newFilterSpansProcessor(set component.TelemetrySettings, cfg *Config) (*filterSpanProcessor, error) {
 	var err error
 	fsp := &filterSpanProcessor{
-		logger: set.Logger,
+		runtimeConditions: newRuntimeConditions(cfg, set.Logger),
+		logger:            set.Logger,
+	}
+	if cfg.RuntimeSettings != nil {
+		fsp.skipSpanExpr, err = newReloadableExpr(fsp.runtimeConditions, "traces.span", cfg.Traces.SpanConditions, func(conditions []string) (expr.BoolExpr[ottlspan.TransformContext], error) {
+			return filterottl.NewBoolExprForSpan(conditions, filterottl.StandardSpanFuncs(), cfg.ErrorMode, set)
+		})
+		if err != nil {
+			return nil, err
+		}
+		fsp.skipSpanEventExpr, err = newReloadableExpr(fsp.runtimeConditions, "traces.spanevent", cfg.Traces.SpanEventConditions, func(conditions []string) (expr.BoolExpr[ottlspanevent.TransformContext], error) {
+			return filterottl.NewBoolExprForSpanEvent(conditions, filterottl.StandardSpanEventFuncs(), cfg.ErrorMode, set)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return fsp, nil
 	}
 	if cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil {
 		if cfg.Traces.SpanConditions != nil {
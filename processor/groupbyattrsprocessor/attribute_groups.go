@@ -12,89 +12,193 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
 
+// resourceIndex keeps track of the Resources of a group and of the number of records they hold.
+type resourceIndex struct {
+	maxRecords int
+	// latest maps the hash of the Resource attributes to the index of the latest Resource with these attributes.
+	latest  map[[16]byte]int
+	records []int
+}
+
+func newResourceIndex(maxRecords int) resourceIndex {
+	return resourceIndex{maxRecords: maxRecords, latest: map[[16]byte]int{}}
+}
+
+// find returns the index of the Resource with the given hash, unless it doesn't exist or is full,
+// and accounts for the record that is about to be added to it.
+func (ri *resourceIndex) find(hash [16]byte) (int, bool) {
+	i, ok := ri.latest[hash]
+	if !ok || (ri.maxRecords > 0 && ri.records[i] >= ri.maxRecords) {
+		return 0, false
+	}
+	ri.records[i]++
+	return i, true
+}
+
+// add registers a new Resource, holding the record that is about to be added to it.
+func (ri *resourceIndex) add(hash [16]byte) {
+	ri.latest[hash] = len(ri.records)
+	ri.records = append(ri.records, 1)
+}
+
 type tracesGroup struct {
-	traces         ptrace.Traces
-	resourceHashes [][16]byte
+	traces    ptrace.Traces
+	resources resourceIndex
 }
 
-func newTracesGroup() *tracesGroup {
-	return &tracesGroup{traces: ptrace.NewTraces()}
+func newTracesGroup(maxRecords int) *tracesGroup {
+	return &tracesGroup{traces: ptrace.NewTraces(), resources: newResourceIndex(maxRecords)}
 }
 
-// findOrCreateResource searches for a Resource with matching attributes and returns it. If nothing is found, it is being created
+// findOrCreateResource searches for a Resource with matching attributes and returns it. If nothing is found,
+// or if the matching Resource holds the maximum number of records, it is being created
 func (tg *tracesGroup) findOrCreateResourceSpans(originResource pcommon.Resource, requiredAttributes pcommon.Map) ptrace.ResourceSpans {
 	referenceResource := buildReferenceResource(originResource, requiredAttributes)
 	referenceResourceHash := pdatautil.MapHash(referenceResource.Attributes())
 
-	rss := tg.traces.ResourceSpans()
-	for i := 0; i < rss.Len(); i++ {
-		if tg.resourceHashes[i] == referenceResourceHash {
-			return rss.At(i)
-		}
+	if i, ok := tg.resources.find(referenceResourceHash); ok {
+		return tg.traces.ResourceSpans().At(i)
 	}
 
 	rs := tg.traces.ResourceSpans().AppendEmpty()
 	referenceResource.MoveTo(rs.Resource())
-	tg.resourceHashes = append(tg.resourceHashes, referenceResourceHash)
+	tg.resources.add(referenceResourceHash)
 	return rs
 }
 
+// merge adds the spans of td to the group, merging those with identical Resource and InstrumentationLibrary
+func (tg *tracesGroup) merge(td ptrace.Traces) {
+	noAttributes := pcommon.NewMap()
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				groupedResourceSpans := tg.findOrCreateResourceSpans(rs.Resource(), noAttributes)
+				ss.Spans().At(k).CopyTo(matchingScopeSpans(groupedResourceSpans, ss.Scope()).Spans().AppendEmpty())
+			}
+		}
+	}
+}
+
 type metricsGroup struct {
-	metrics        pmetric.Metrics
-	resourceHashes [][16]byte
+	metrics   pmetric.Metrics
+	resources resourceIndex
 }
 
-func newMetricsGroup() *metricsGroup {
-	return &metricsGroup{metrics: pmetric.NewMetrics()}
+func newMetricsGroup(maxRecords int) *metricsGroup {
+	return &metricsGroup{metrics: pmetric.NewMetrics(), resources: newResourceIndex(maxRecords)}
 }
 
-// findOrCreateResourceMetrics searches for a Resource with matching attributes and returns it. If nothing is found, it is being created
+// findOrCreateResourceMetrics searches for a Resource with matching attributes and returns it. If nothing is found,
+// or if the matching Resource holds the maximum number of data points, it is being created
 func (mg *metricsGroup) findOrCreateResourceMetrics(originResource pcommon.Resource, requiredAttributes pcommon.Map) pmetric.ResourceMetrics {
 	referenceResource := buildReferenceResource(originResource, requiredAttributes)
 	referenceResourceHash := pdatautil.MapHash(referenceResource.Attributes())
 
-	rms := mg.metrics.ResourceMetrics()
-	for i := 0; i < rms.Len(); i++ {
-		if mg.resourceHashes[i] == referenceResourceHash {
-			return rms.At(i)
-		}
+	if i, ok := mg.resources.find(referenceResourceHash); ok {
+		return mg.metrics.ResourceMetrics().At(i)
 	}
 
 	rm := mg.metrics.ResourceMetrics().AppendEmpty()
 	referenceResource.MoveTo(rm.Resource())
-	mg.resourceHashes = append(mg.resourceHashes, referenceResourceHash)
+	mg.resources.add(referenceResourceHash)
 	return rm
 
 }
 
+// merge adds the data points of md to the group, merging those with identical Resource, InstrumentationLibrary and Metric
+func (mg *metricsGroup) merge(md pmetric.Metrics) {
+	noAttributes := pcommon.NewMap()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				for pointIndex := 0; pointIndex < dataPointCount(metric); pointIndex++ {
+					groupedResourceMetrics := mg.findOrCreateResourceMetrics(rm.Resource(), noAttributes)
+					groupedMetric := getMetricInInstrumentationLibrary(matchingScopeMetrics(groupedResourceMetrics, sm.Scope()), metric)
+					copyDataPoint(metric, pointIndex, groupedMetric)
+				}
+			}
+		}
+	}
+}
+
+func dataPointCount(metric pmetric.Metric) int {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return metric.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return metric.Sum().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return metric.Summary().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return metric.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len()
+	}
+	return 0
+}
+
+// copyDataPoint appends the data point at the given index of src to dest, which must be of the same type
+func copyDataPoint(src pmetric.Metric, pointIndex int, dest pmetric.Metric) {
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		src.Gauge().DataPoints().At(pointIndex).CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeSum:
+		src.Sum().DataPoints().At(pointIndex).CopyTo(dest.Sum().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeSummary:
+		src.Summary().DataPoints().At(pointIndex).CopyTo(dest.Summary().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeHistogram:
+		src.Histogram().DataPoints().At(pointIndex).CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeExponentialHistogram:
+		src.ExponentialHistogram().DataPoints().At(pointIndex).CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+	}
+}
+
 type logsGroup struct {
-	logs           plog.Logs
-	resourceHashes [][16]byte
+	logs      plog.Logs
+	resources resourceIndex
 }
 
 // newLogsGroup returns new logsGroup with predefined capacity
-func newLogsGroup() *logsGroup {
-	return &logsGroup{logs: plog.NewLogs()}
+func newLogsGroup(maxRecords int) *logsGroup {
+	return &logsGroup{logs: plog.NewLogs(), resources: newResourceIndex(maxRecords)}
 }
 
-// findOrCreateResourceLogs searches for a Resource with matching attributes and returns it. If nothing is found, it is being created
+// findOrCreateResourceLogs searches for a Resource with matching attributes and returns it. If nothing is found,
+// or if the matching Resource holds the maximum number of log records, it is being created
 func (lg *logsGroup) findOrCreateResourceLogs(originResource pcommon.Resource, requiredAttributes pcommon.Map) plog.ResourceLogs {
 	referenceResource := buildReferenceResource(originResource, requiredAttributes)
 	referenceResourceHash := pdatautil.MapHash(referenceResource.Attributes())
 
-	rls := lg.logs.ResourceLogs()
-	for i := 0; i < rls.Len(); i++ {
-		if lg.resourceHashes[i] == referenceResourceHash {
-			return rls.At(i)
-		}
+	if i, ok := lg.resources.find(referenceResourceHash); ok {
+		return lg.logs.ResourceLogs().At(i)
 	}
 
 	rl := lg.logs.ResourceLogs().AppendEmpty()
 	referenceResource.MoveTo(rl.Resource())
-	lg.resourceHashes = append(lg.resourceHashes, referenceResourceHash)
+	lg.resources.add(referenceResourceHash)
 	return rl
 }
 
+// merge adds the log records of ld to the group, merging those with identical Resource and InstrumentationLibrary
+func (lg *logsGroup) merge(ld plog.Logs) {
+	noAttributes := pcommon.NewMap()
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				groupedResourceLogs := lg.findOrCreateResourceLogs(rl.Resource(), noAttributes)
+				sl.LogRecords().At(k).CopyTo(matchingScopeLogs(groupedResourceLogs, sl.Scope()).LogRecords().AppendEmpty())
+			}
+		}
+	}
+}
+
 func instrumentationLibrariesEqual(il1, il2 pcommon.InstrumentationScope) bool {
 	return il1.Name() == il2.Name() && il1.Version() == il2.Version()
 }
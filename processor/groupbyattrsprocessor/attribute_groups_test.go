@@ -95,7 +95,7 @@ func TestResourceAttributeScenarios(t *testing.T) {
 		},
 	}
 
-	lg := newLogsGroup()
+	lg := newLogsGroup(0)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			recordAttributeMap := pcommon.NewMap()
@@ -147,7 +147,7 @@ func TestInstrumentationLibraryMatching(t *testing.T) {
 }
 
 func BenchmarkAttrGrouping(b *testing.B) {
-	lg := newLogsGroup()
+	lg := newLogsGroup(0)
 	b.ReportAllocs()
 	for n := 0; n < b.N; n++ {
 		lg.findOrCreateResourceLogs(res, groups[rand.Intn(count)])
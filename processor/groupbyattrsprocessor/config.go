@@ -3,10 +3,81 @@
 
 package groupbyattrsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor"
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
 // Config is the configuration for the processor.
 type Config struct {
 
 	// GroupByKeys describes the attribute names that are going to be used for grouping.
 	// Empty value is allowed, since processor in such case can compact data
 	GroupByKeys []string `mapstructure:"keys"`
+
+	// SpanKeys, LogKeys and DataPointKeys describe grouping keys whose values are computed
+	// by OTTL expressions, evaluated for each span, log record or metric data point respectively.
+	SpanKeys      []OTTLKey `mapstructure:"span_keys"`
+	LogKeys       []OTTLKey `mapstructure:"log_keys"`
+	DataPointKeys []OTTLKey `mapstructure:"datapoint_keys"`
+
+	// ErrorMode determines how errors returned by the OTTL expressions of the keys are handled.
+	// With "ignore", the key is left out for the record that caused the error.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+
+	// MaxRecordsPerGroup is the maximum number of spans, log records or metric data points
+	// under a single output Resource. When it is reached, a new Resource with the same
+	// attributes is started. Zero means no limit.
+	MaxRecordsPerGroup int `mapstructure:"max_records_per_group"`
+
+	// MergeWindow is the duration during which the grouped data of consecutive batches is
+	// accumulated, so that identical Resources are merged, before being sent to the next consumer.
+	// Zero sends each batch as soon as it is processed.
+	MergeWindow time.Duration `mapstructure:"merge_window"`
+}
+
+// OTTLKey is a grouping key whose value is computed by an OTTL expression.
+type OTTLKey struct {
+	// Key is the name of the Resource attribute holding the computed value.
+	Key string `mapstructure:"key"`
+
+	// Value is the OTTL expression computing the value, e.g. `name` or `ParseJSON(body)["tenant"]`.
+	// Records for which it evaluates to nil are not grouped by this key.
+	Value string `mapstructure:"value"`
+}
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	for _, keys := range [][]OTTLKey{cfg.SpanKeys, cfg.LogKeys, cfg.DataPointKeys} {
+		if err := validateKeys(keys); err != nil {
+			return err
+		}
+	}
+	if cfg.MaxRecordsPerGroup < 0 {
+		return errors.New("max_records_per_group must not be negative")
+	}
+	if cfg.MergeWindow < 0 {
+		return errors.New("merge_window must not be negative")
+	}
+	return nil
+}
+
+func validateKeys(keys []OTTLKey) error {
+	present := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		if k.Key == "" {
+			return errors.New("the key of an OTTL key must not be empty")
+		}
+		if k.Value == "" {
+			return fmt.Errorf("the value of OTTL key %q must not be empty", k.Key)
+		}
+		if _, ok := present[k.Key]; ok {
+			return fmt.Errorf("duplicate OTTL key %q", k.Key)
+		}
+		present[k.Key] = struct{}{}
+	}
+	return nil
 }
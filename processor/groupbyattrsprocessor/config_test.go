@@ -6,12 +6,14 @@ package groupbyattrsprocessor
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor/internal/metadata"
 )
 
@@ -26,12 +28,25 @@ func TestLoadingConfig(t *testing.T) {
 			id: component.NewIDWithName(metadata.Type, "grouping"),
 			expected: &Config{
 				GroupByKeys: []string{"key1", "key2"},
+				ErrorMode:   ottl.PropagateError,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "compaction"),
 			expected: &Config{
 				GroupByKeys: []string{},
+				ErrorMode:   ottl.PropagateError,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ottl"),
+			expected: &Config{
+				GroupByKeys:        []string{"host.name"},
+				LogKeys:            []OTTLKey{{Key: "tenant", Value: `ParseJSON(body)["tenant"]`}},
+				SpanKeys:           []OTTLKey{{Key: "operation", Value: "name"}},
+				ErrorMode:          ottl.IgnoreError,
+				MaxRecordsPerGroup: 1000,
+				MergeWindow:        time.Second,
 			},
 		},
 	}
@@ -53,3 +68,43 @@ func TestLoadingConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		error  string
+	}{
+		{
+			name:   "empty key",
+			config: &Config{LogKeys: []OTTLKey{{Value: "body"}}},
+			error:  "the key of an OTTL key must not be empty",
+		},
+		{
+			name:   "empty value",
+			config: &Config{SpanKeys: []OTTLKey{{Key: "operation"}}},
+			error:  `the value of OTTL key "operation" must not be empty`,
+		},
+		{
+			name:   "duplicate key",
+			config: &Config{DataPointKeys: []OTTLKey{{Key: "k", Value: "metric.name"}, {Key: "k", Value: "metric.unit"}}},
+			error:  `duplicate OTTL key "k"`,
+		},
+		{
+			name:   "negative max records",
+			config: &Config{MaxRecordsPerGroup: -1},
+			error:  "max_records_per_group must not be negative",
+		},
+		{
+			name:   "negative merge window",
+			config: &Config{MergeWindow: -time.Second},
+			error:  "merge_window must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, component.ValidateConfig(tt.config), tt.error)
+		})
+	}
+}
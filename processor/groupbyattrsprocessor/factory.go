@@ -10,10 +10,14 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor/internal/metadata"
 )
 
@@ -42,6 +46,7 @@ func NewFactory() processor.Factory {
 func createDefaultConfig() component.Config {
 	return &Config{
 		GroupByKeys: []string{},
+		ErrorMode:   ottl.PropagateError,
 	}
 }
 
@@ -64,6 +69,13 @@ func createGroupByAttrsProcessor(logger *zap.Logger, attributes []string) *group
 	return &groupByAttrsProcessor{logger: logger, groupByKeys: nonEmptyAttributes}
 }
 
+func newGroupByAttrsProcessor(set processor.CreateSettings, cfg *Config) *groupByAttrsProcessor {
+	gap := createGroupByAttrsProcessor(set.Logger, cfg.GroupByKeys)
+	gap.errorMode = cfg.ErrorMode
+	gap.maxRecords = cfg.MaxRecordsPerGroup
+	return gap
+}
+
 // createTracesProcessor creates a trace processor based on this config.
 func createTracesProcessor(
 	ctx context.Context,
@@ -72,15 +84,42 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces) (processor.Traces, error) {
 
 	oCfg := cfg.(*Config)
-	gap := createGroupByAttrsProcessor(set.Logger, oCfg.GroupByKeys)
+	gap := newGroupByAttrsProcessor(set, oCfg)
+	var err error
+	if gap.spanKeys, err = newSpanKeys(set.TelemetrySettings, oCfg.SpanKeys); err != nil {
+		return nil, err
+	}
+
+	if oCfg.MergeWindow <= 0 {
+		return processorhelper.NewTracesProcessor(
+			ctx,
+			set,
+			cfg,
+			nextConsumer,
+			gap.processTraces,
+			processorhelper.WithCapabilities(consumerCapabilities))
+	}
+
+	m := newMerger(set.Logger, oCfg.MergeWindow,
+		func() *tracesGroup { return newTracesGroup(oCfg.MaxRecordsPerGroup) },
+		func(ctx context.Context, tg *tracesGroup) error { return nextConsumer.ConsumeTraces(ctx, tg.traces) })
 
 	return processorhelper.NewTracesProcessor(
 		ctx,
 		set,
 		cfg,
 		nextConsumer,
-		gap.processTraces,
-		processorhelper.WithCapabilities(consumerCapabilities))
+		func(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+			grouped, err := gap.processTraces(ctx, td)
+			if err != nil {
+				return td, err
+			}
+			m.add(func(tg *tracesGroup) { tg.merge(grouped) })
+			return td, processorhelper.ErrSkipProcessingData
+		},
+		processorhelper.WithCapabilities(consumerCapabilities),
+		processorhelper.WithStart(m.start),
+		processorhelper.WithShutdown(m.shutdown))
 }
 
 // createLogsProcessor creates a logs processor based on this config.
@@ -91,15 +130,42 @@ func createLogsProcessor(
 	nextConsumer consumer.Logs) (processor.Logs, error) {
 
 	oCfg := cfg.(*Config)
-	gap := createGroupByAttrsProcessor(set.Logger, oCfg.GroupByKeys)
+	gap := newGroupByAttrsProcessor(set, oCfg)
+	var err error
+	if gap.logKeys, err = newLogKeys(set.TelemetrySettings, oCfg.LogKeys); err != nil {
+		return nil, err
+	}
+
+	if oCfg.MergeWindow <= 0 {
+		return processorhelper.NewLogsProcessor(
+			ctx,
+			set,
+			cfg,
+			nextConsumer,
+			gap.processLogs,
+			processorhelper.WithCapabilities(consumerCapabilities))
+	}
+
+	m := newMerger(set.Logger, oCfg.MergeWindow,
+		func() *logsGroup { return newLogsGroup(oCfg.MaxRecordsPerGroup) },
+		func(ctx context.Context, lg *logsGroup) error { return nextConsumer.ConsumeLogs(ctx, lg.logs) })
 
 	return processorhelper.NewLogsProcessor(
 		ctx,
 		set,
 		cfg,
 		nextConsumer,
-		gap.processLogs,
-		processorhelper.WithCapabilities(consumerCapabilities))
+		func(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+			grouped, err := gap.processLogs(ctx, ld)
+			if err != nil {
+				return ld, err
+			}
+			m.add(func(lg *logsGroup) { lg.merge(grouped) })
+			return ld, processorhelper.ErrSkipProcessingData
+		},
+		processorhelper.WithCapabilities(consumerCapabilities),
+		processorhelper.WithStart(m.start),
+		processorhelper.WithShutdown(m.shutdown))
 }
 
 // createMetricsProcessor creates a metrics processor based on this config.
@@ -110,13 +176,40 @@ func createMetricsProcessor(
 	nextConsumer consumer.Metrics) (processor.Metrics, error) {
 
 	oCfg := cfg.(*Config)
-	gap := createGroupByAttrsProcessor(set.Logger, oCfg.GroupByKeys)
+	gap := newGroupByAttrsProcessor(set, oCfg)
+	var err error
+	if gap.dataPointKeys, err = newDataPointKeys(set.TelemetrySettings, oCfg.DataPointKeys); err != nil {
+		return nil, err
+	}
+
+	if oCfg.MergeWindow <= 0 {
+		return processorhelper.NewMetricsProcessor(
+			ctx,
+			set,
+			cfg,
+			nextConsumer,
+			gap.processMetrics,
+			processorhelper.WithCapabilities(consumerCapabilities))
+	}
+
+	m := newMerger(set.Logger, oCfg.MergeWindow,
+		func() *metricsGroup { return newMetricsGroup(oCfg.MaxRecordsPerGroup) },
+		func(ctx context.Context, mg *metricsGroup) error { return nextConsumer.ConsumeMetrics(ctx, mg.metrics) })
 
 	return processorhelper.NewMetricsProcessor(
 		ctx,
 		set,
 		cfg,
 		nextConsumer,
-		gap.processMetrics,
-		processorhelper.WithCapabilities(consumerCapabilities))
+		func(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+			grouped, err := gap.processMetrics(ctx, md)
+			if err != nil {
+				return md, err
+			}
+			m.add(func(mg *metricsGroup) { mg.merge(grouped) })
+			return md, processorhelper.ErrSkipProcessingData
+		},
+		processorhelper.WithCapabilities(consumerCapabilities),
+		processorhelper.WithStart(m.start),
+		processorhelper.WithShutdown(m.shutdown))
 }
@@ -11,6 +11,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 func TestDefaultConfiguration(t *testing.T) {
@@ -39,6 +41,16 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.Equal(t, true, mp.Capabilities().MutatesData)
 }
 
+func TestCreateProcessorInvalidKey(t *testing.T) {
+	cfg := &Config{
+		LogKeys:   []OTTLKey{{Key: "tenant", Value: "unknown"}},
+		ErrorMode: ottl.PropagateError,
+	}
+
+	_, err := createLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	assert.ErrorContains(t, err, `invalid value of key "tenant"`)
+}
+
 func TestNoKeys(t *testing.T) {
 	// This is allowed since can be used for compacting data
 	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{})
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbyattrsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// merger accumulates the grouped data of the batches received during a window into a
+// single group, and sends it to the next consumer at the end of the window.
+type merger[G any] struct {
+	logger   *zap.Logger
+	window   time.Duration
+	newGroup func() G
+	export   func(context.Context, G) error
+
+	mu      sync.Mutex
+	group   G
+	pending bool
+
+	// flushCtx is the context of the exports at the end of the windows,
+	// canceled if the shutdown times out while an export is in progress.
+	flushCtx    context.Context
+	cancelFlush context.CancelFunc
+	shutdownC   chan struct{}
+	done        sync.WaitGroup
+}
+
+func newMerger[G any](logger *zap.Logger, window time.Duration, newGroup func() G, export func(context.Context, G) error) *merger[G] {
+	flushCtx, cancelFlush := context.WithCancel(context.Background())
+	return &merger[G]{
+		logger:      logger,
+		window:      window,
+		newGroup:    newGroup,
+		export:      export,
+		group:       newGroup(),
+		flushCtx:    flushCtx,
+		cancelFlush: cancelFlush,
+		shutdownC:   make(chan struct{}),
+	}
+}
+
+// add merges data into the current group.
+func (m *merger[G]) add(merge func(G)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	merge(m.group)
+	m.pending = true
+}
+
+func (m *merger[G]) start(context.Context, component.Host) error {
+	m.done.Add(1)
+	go func() {
+		defer m.done.Done()
+		ticker := time.NewTicker(m.window)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := m.flush(m.flushCtx); err != nil {
+					m.logger.Error("failed to send merged data", zap.Error(err))
+				}
+			case <-m.shutdownC:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// flush sends the current group to the next consumer, unless it is empty.
+func (m *merger[G]) flush(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.pending {
+		m.mu.Unlock()
+		return nil
+	}
+	group := m.group
+	m.group = m.newGroup()
+	m.pending = false
+	m.mu.Unlock()
+
+	return m.export(ctx, group)
+}
+
+// shutdown stops the window and sends the data accumulated so far, with the shutdown context.
+// An export of the window in progress is canceled once the shutdown context is done.
+func (m *merger[G]) shutdown(ctx context.Context) error {
+	select {
+	case <-m.shutdownC:
+		return nil
+	default:
+		close(m.shutdownC)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		m.done.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		m.cancelFlush()
+		<-stopped
+	}
+	m.cancelFlush()
+	return m.flush(ctx)
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbyattrsprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestMergeWindow(t *testing.T) {
+	cfg := &Config{
+		GroupByKeys: []string{},
+		ErrorMode:   ottl.PropagateError,
+		MergeWindow: time.Hour,
+	}
+
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, tracesSink)
+	require.NoError(t, err)
+	logsSink := new(consumertest.LogsSink)
+	lp, err := createLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, logsSink)
+	require.NoError(t, err)
+	metricsSink := new(consumertest.MetricsSink)
+	mp, err := createMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, metricsSink)
+	require.NoError(t, err)
+
+	require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, tp.ConsumeTraces(context.Background(), someSpans(attrMap, 2, 2)))
+		require.NoError(t, lp.ConsumeLogs(context.Background(), someLogs(attrMap, 2, 2)))
+		require.NoError(t, mp.ConsumeMetrics(context.Background(), someGaugeMetrics(attrMap, 2, 2)))
+	}
+
+	// nothing is sent before the end of the window
+	assert.Empty(t, tracesSink.AllTraces())
+	assert.Empty(t, logsSink.AllLogs())
+	assert.Empty(t, metricsSink.AllMetrics())
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+	require.NoError(t, lp.Shutdown(context.Background()))
+	require.NoError(t, mp.Shutdown(context.Background()))
+
+	// the batches are merged into a single Resource
+	require.Len(t, tracesSink.AllTraces(), 1)
+	assert.Equal(t, 1, tracesSink.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, 2, tracesSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, 12, tracesSink.SpanCount())
+
+	require.Len(t, logsSink.AllLogs(), 1)
+	assert.Equal(t, 1, logsSink.AllLogs()[0].ResourceLogs().Len())
+	assert.Equal(t, 12, logsSink.LogRecordCount())
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Equal(t, 1, metricsSink.AllMetrics()[0].ResourceMetrics().Len())
+	assert.Equal(t, 12, metricsSink.DataPointCount())
+}
+
+func TestMergeWindowFlush(t *testing.T) {
+	cfg := &Config{
+		GroupByKeys:        []string{},
+		ErrorMode:          ottl.PropagateError,
+		MaxRecordsPerGroup: 5,
+		MergeWindow:        10 * time.Millisecond,
+	}
+
+	sink := new(consumertest.LogsSink)
+	lp, err := createLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, lp.Shutdown(context.Background())) }()
+
+	require.NoError(t, lp.ConsumeLogs(context.Background(), someLogs(attrMap, 1, 4)))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), someLogs(attrMap, 1, 4)))
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 8
+	}, time.Second, 5*time.Millisecond)
+
+	// the merged log records are capped per Resource
+	for _, logs := range sink.AllLogs() {
+		for i := 0; i < logs.ResourceLogs().Len(); i++ {
+			assert.LessOrEqual(t, resourceLogsCount(logs.ResourceLogs().At(i)), 5)
+		}
+	}
+}
+
+func TestMergerShutdownCancelsFlush(t *testing.T) {
+	exporting := make(chan struct{})
+	m := newMerger(zap.NewNop(), time.Millisecond, func() *int { return new(int) }, func(ctx context.Context, _ *int) error {
+		close(exporting)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, m.start(context.Background(), componenttest.NewNopHost()))
+	m.add(func(n *int) { *n++ })
+	<-exporting
+
+	// the export at the end of the window is blocked until the shutdown times out
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, m.shutdown(ctx))
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbyattrsprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlexpr"
+)
+
+// ottlKey is a grouping key with its parsed OTTL expression.
+type ottlKey[K any] struct {
+	key       string
+	statement *ottl.Statement[K]
+}
+
+func parseKeys[K any](parser ottl.Parser[K], keys []OTTLKey) ([]ottlKey[K], error) {
+	parsed := make([]ottlKey[K], 0, len(keys))
+	for _, k := range keys {
+		statement, err := ottlexpr.Parse(parser, k.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of key %q: %w", k.Key, err)
+		}
+		parsed = append(parsed, ottlKey[K]{key: k.Key, statement: statement})
+	}
+	return parsed, nil
+}
+
+func newSpanKeys(set component.TelemetrySettings, keys []OTTLKey) ([]ottlKey[ottlspan.TransformContext], error) {
+	parser, err := ottlspan.NewParser(ottlexpr.Functions[ottlspan.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeys(parser, keys)
+}
+
+func newLogKeys(set component.TelemetrySettings, keys []OTTLKey) ([]ottlKey[ottllog.TransformContext], error) {
+	parser, err := ottllog.NewParser(ottlexpr.Functions[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeys(parser, keys)
+}
+
+func newDataPointKeys(set component.TelemetrySettings, keys []OTTLKey) ([]ottlKey[ottldatapoint.TransformContext], error) {
+	parser, err := ottldatapoint.NewParser(ottlexpr.Functions[ottldatapoint.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeys(parser, keys)
+}
+
+// computeKeys evaluates the keys for a record and returns the non-nil values, keyed by the key names.
+func computeKeys[K any](ctx context.Context, gap *groupByAttrsProcessor, keys []ottlKey[K], tCtx K) (pcommon.Map, error) {
+	computed := pcommon.NewMap()
+	for _, k := range keys {
+		value, _, err := k.statement.Execute(ctx, tCtx)
+		if err == nil && value != nil {
+			err = setValue(computed, k.key, value)
+		}
+		if err != nil {
+			if gap.errorMode == ottl.PropagateError {
+				return computed, fmt.Errorf("failed to compute key %q: %w", k.key, err)
+			}
+			gap.logger.Warn("failed to compute key", zap.String("key", k.key), zap.Error(err))
+		}
+	}
+	return computed, nil
+}
+
+// setValue puts the value returned by an OTTL expression into the map.
+func setValue(m pcommon.Map, key string, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		m.PutStr(key, v)
+	case bool:
+		m.PutBool(key, v)
+	case int64:
+		m.PutInt(key, v)
+	case float64:
+		m.PutDouble(key, v)
+	case []byte:
+		m.PutEmptyBytes(key).FromRaw(v)
+	case pcommon.Map:
+		v.CopyTo(m.PutEmptyMap(key))
+	case pcommon.Slice:
+		v.CopyTo(m.PutEmptySlice(key))
+	case pcommon.Value:
+		v.CopyTo(m.PutEmpty(key))
+	default:
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+	return nil
+}
@@ -12,17 +12,27 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 type groupByAttrsProcessor struct {
-	logger      *zap.Logger
-	groupByKeys []string
+	logger        *zap.Logger
+	groupByKeys   []string
+	spanKeys      []ottlKey[ottlspan.TransformContext]
+	logKeys       []ottlKey[ottllog.TransformContext]
+	dataPointKeys []ottlKey[ottldatapoint.TransformContext]
+	errorMode     ottl.ErrorMode
+	maxRecords    int
 }
 
 // ProcessTraces process traces and groups traces by attribute.
 func (gap *groupByAttrsProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	rss := td.ResourceSpans()
-	tg := newTracesGroup()
+	tg := newTracesGroup(gap.maxRecords)
 
 	for i := 0; i < rss.Len(); i++ {
 		rs := rss.At(i)
@@ -33,12 +43,26 @@ func (gap *groupByAttrsProcessor) processTraces(ctx context.Context, td ptrace.T
 			for k := 0; k < ils.Spans().Len(); k++ {
 				span := ils.Spans().At(k)
 
+				var computedAttributes pcommon.Map
+				if len(gap.spanKeys) > 0 {
+					var err error
+					computedAttributes, err = computeKeys(ctx, gap, gap.spanKeys, ottlspan.NewTransformContext(span, ils.Scope(), rs.Resource()))
+					if err != nil {
+						return td, err
+					}
+				}
+
 				toBeGrouped, requiredAttributes := gap.extractGroupingAttributes(span.Attributes())
 				if toBeGrouped {
-					stats.Record(ctx, mNumGroupedSpans.M(1))
 					// Some attributes are going to be moved from span to resource level,
 					// so we can delete those on the record level
 					deleteAttributes(requiredAttributes, span.Attributes())
+				}
+				if len(gap.spanKeys) > 0 && addComputedAttributes(requiredAttributes, computedAttributes) {
+					toBeGrouped = true
+				}
+				if toBeGrouped {
+					stats.Record(ctx, mNumGroupedSpans.M(1))
 				} else {
 					stats.Record(ctx, mNumNonGroupedSpans.M(1))
 				}
@@ -60,7 +84,7 @@ func (gap *groupByAttrsProcessor) processTraces(ctx context.Context, td ptrace.T
 
 func (gap *groupByAttrsProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
 	rl := ld.ResourceLogs()
-	lg := newLogsGroup()
+	lg := newLogsGroup(gap.maxRecords)
 
 	for i := 0; i < rl.Len(); i++ {
 		ls := rl.At(i)
@@ -71,12 +95,26 @@ func (gap *groupByAttrsProcessor) processLogs(ctx context.Context, ld plog.Logs)
 			for k := 0; k < sl.LogRecords().Len(); k++ {
 				log := sl.LogRecords().At(k)
 
+				var computedAttributes pcommon.Map
+				if len(gap.logKeys) > 0 {
+					var err error
+					computedAttributes, err = computeKeys(ctx, gap, gap.logKeys, ottllog.NewTransformContext(log, sl.Scope(), ls.Resource()))
+					if err != nil {
+						return ld, err
+					}
+				}
+
 				toBeGrouped, requiredAttributes := gap.extractGroupingAttributes(log.Attributes())
 				if toBeGrouped {
-					stats.Record(ctx, mNumGroupedLogs.M(1))
 					// Some attributes are going to be moved from log record to resource level,
 					// so we can delete those on the record level
 					deleteAttributes(requiredAttributes, log.Attributes())
+				}
+				if len(gap.logKeys) > 0 && addComputedAttributes(requiredAttributes, computedAttributes) {
+					toBeGrouped = true
+				}
+				if toBeGrouped {
+					stats.Record(ctx, mNumGroupedLogs.M(1))
 				} else {
 					stats.Record(ctx, mNumNonGroupedLogs.M(1))
 				}
@@ -99,7 +137,7 @@ func (gap *groupByAttrsProcessor) processLogs(ctx context.Context, ld plog.Logs)
 
 func (gap *groupByAttrsProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	rms := md.ResourceMetrics()
-	mg := newMetricsGroup()
+	mg := newMetricsGroup(gap.maxRecords)
 
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
@@ -115,35 +153,50 @@ func (gap *groupByAttrsProcessor) processMetrics(ctx context.Context, md pmetric
 				case pmetric.MetricTypeGauge:
 					for pointIndex := 0; pointIndex < metric.Gauge().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.Gauge().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric, err := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint, dataPoint.Attributes())
+						if err != nil {
+							return md, err
+						}
 						dataPoint.CopyTo(groupedMetric.Gauge().DataPoints().AppendEmpty())
 					}
 
 				case pmetric.MetricTypeSum:
 					for pointIndex := 0; pointIndex < metric.Sum().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.Sum().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric, err := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint, dataPoint.Attributes())
+						if err != nil {
+							return md, err
+						}
 						dataPoint.CopyTo(groupedMetric.Sum().DataPoints().AppendEmpty())
 					}
 
 				case pmetric.MetricTypeSummary:
 					for pointIndex := 0; pointIndex < metric.Summary().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.Summary().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric, err := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint, dataPoint.Attributes())
+						if err != nil {
+							return md, err
+						}
 						dataPoint.CopyTo(groupedMetric.Summary().DataPoints().AppendEmpty())
 					}
 
 				case pmetric.MetricTypeHistogram:
 					for pointIndex := 0; pointIndex < metric.Histogram().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.Histogram().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric, err := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint, dataPoint.Attributes())
+						if err != nil {
+							return md, err
+						}
 						dataPoint.CopyTo(groupedMetric.Histogram().DataPoints().AppendEmpty())
 					}
 
 				case pmetric.MetricTypeExponentialHistogram:
 					for pointIndex := 0; pointIndex < metric.ExponentialHistogram().DataPoints().Len(); pointIndex++ {
 						dataPoint := metric.ExponentialHistogram().DataPoints().At(pointIndex)
-						groupedMetric := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint.Attributes())
+						groupedMetric, err := gap.getGroupedMetricsFromAttributes(ctx, mg, rm, ilm, metric, dataPoint, dataPoint.Attributes())
+						if err != nil {
+							return md, err
+						}
 						dataPoint.CopyTo(groupedMetric.ExponentialHistogram().DataPoints().AppendEmpty())
 					}
 
@@ -164,6 +217,19 @@ func deleteAttributes(attrsForRemoval, targetAttrs pcommon.Map) {
 	})
 }
 
+// addComputedAttributes adds the values computed by the OTTL keys to the grouping attributes.
+// Returns whether any value was added.
+func addComputedAttributes(requiredAttributes, computedAttributes pcommon.Map) bool {
+	if computedAttributes.Len() == 0 {
+		return false
+	}
+	computedAttributes.Range(func(k string, v pcommon.Value) bool {
+		v.CopyTo(requiredAttributes.PutEmpty(k))
+		return true
+	})
+	return true
+}
+
 // extractGroupingAttributes extracts the keys and values of the specified Attributes
 // that match with the attributes keys that is used for grouping
 // Returns:
@@ -234,15 +300,31 @@ func (gap *groupByAttrsProcessor) getGroupedMetricsFromAttributes(
 	originResourceMetrics pmetric.ResourceMetrics,
 	ilm pmetric.ScopeMetrics,
 	metric pmetric.Metric,
+	dataPoint interface{},
 	attributes pcommon.Map,
-) pmetric.Metric {
+) (pmetric.Metric, error) {
+
+	var computedAttributes pcommon.Map
+	if len(gap.dataPointKeys) > 0 {
+		var err error
+		tCtx := ottldatapoint.NewTransformContext(dataPoint, metric, ilm.Metrics(), ilm.Scope(), originResourceMetrics.Resource())
+		computedAttributes, err = computeKeys(ctx, gap, gap.dataPointKeys, tCtx)
+		if err != nil {
+			return metric, err
+		}
+	}
 
 	toBeGrouped, requiredAttributes := gap.extractGroupingAttributes(attributes)
 	if toBeGrouped {
-		stats.Record(ctx, mNumGroupedMetrics.M(1))
 		// These attributes are going to be moved from datapoint to resource level,
 		// so we can delete those on the datapoint
 		deleteAttributes(requiredAttributes, attributes)
+	}
+	if len(gap.dataPointKeys) > 0 && addComputedAttributes(requiredAttributes, computedAttributes) {
+		toBeGrouped = true
+	}
+	if toBeGrouped {
+		stats.Record(ctx, mNumGroupedMetrics.M(1))
 	} else {
 		stats.Record(ctx, mNumNonGroupedMetrics.M(1))
 	}
@@ -254,6 +336,6 @@ func (gap *groupByAttrsProcessor) getGroupedMetricsFromAttributes(
 	groupedInstrumentationLibrary := matchingScopeMetrics(groupedResourceMetrics, ilm.Scope())
 
 	// Return the metric in this resource
-	return getMetricInInstrumentationLibrary(groupedInstrumentationLibrary, metric)
+	return getMetricInInstrumentationLibrary(groupedInstrumentationLibrary, metric), nil
 
 }
@@ -12,11 +12,15 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 var (
@@ -881,3 +885,169 @@ func BenchmarkCompacting(bb *testing.B) {
 		})
 	}
 }
+
+func TestOTTLKeys(t *testing.T) {
+	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{"host.name"})
+	var err error
+	gap.logKeys, err = newLogKeys(componenttest.NewNopTelemetrySettings(), []OTTLKey{{Key: "tenant", Value: `ParseJSON(body)["tenant"]`}})
+	require.NoError(t, err)
+	gap.spanKeys, err = newSpanKeys(componenttest.NewNopTelemetrySettings(), []OTTLKey{{Key: "operation", Value: "name"}})
+	require.NoError(t, err)
+	gap.dataPointKeys, err = newDataPointKeys(componenttest.NewNopTelemetrySettings(), []OTTLKey{{Key: "metric", Value: "metric.name"}})
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "api")
+	sl := rl.ScopeLogs().AppendEmpty()
+	for _, body := range []string{`{"tenant":"acme"}`, `{"tenant":"globex"}`, `{"tenant":"acme"}`, `{}`} {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.Body().SetStr(body)
+		lr.Attributes().PutStr("host.name", "host-A")
+	}
+
+	processedLogs, err := gap.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	expected := map[string]int{"acme": 2, "globex": 1, "": 1}
+	require.Equal(t, len(expected), processedLogs.ResourceLogs().Len())
+	for i := 0; i < processedLogs.ResourceLogs().Len(); i++ {
+		rl := processedLogs.ResourceLogs().At(i)
+		host, _ := rl.Resource().Attributes().Get("host.name")
+		assert.Equal(t, "host-A", host.Str())
+		service, _ := rl.Resource().Attributes().Get("service.name")
+		assert.Equal(t, "api", service.Str())
+		tenant, _ := rl.Resource().Attributes().Get("tenant")
+		assert.Equal(t, expected[tenant.Str()], rl.ScopeLogs().At(0).LogRecords().Len(), tenant.Str())
+		// the grouping attributes are removed from the records, the body is kept as is
+		lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, 0, lr.Attributes().Len())
+		assert.NotEmpty(t, lr.Body().Str())
+	}
+
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	ss.Spans().AppendEmpty().SetName("GET /cart")
+	ss.Spans().AppendEmpty().SetName("POST /cart")
+	ss.Spans().AppendEmpty().SetName("GET /cart")
+
+	processedTraces, err := gap.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	require.Equal(t, 2, processedTraces.ResourceSpans().Len())
+	operation, _ := processedTraces.ResourceSpans().At(0).Resource().Attributes().Get("operation")
+	assert.Equal(t, "GET /cart", operation.Str())
+	assert.Equal(t, 2, processedTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().Len())
+
+	metrics := someGaugeMetrics(attrMap, 1, 2)
+	processedMetrics, err := gap.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+	require.Equal(t, 2, processedMetrics.ResourceMetrics().Len())
+	for i := 0; i < processedMetrics.ResourceMetrics().Len(); i++ {
+		rm := processedMetrics.ResourceMetrics().At(i)
+		name, _ := rm.Resource().Attributes().Get("metric")
+		assert.Equal(t, rm.ScopeMetrics().At(0).Metrics().At(0).Name(), name.Str())
+	}
+}
+
+func TestMultipleOTTLKeys(t *testing.T) {
+	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{})
+	var err error
+	gap.spanKeys, err = newSpanKeys(componenttest.NewNopTelemetrySettings(), []OTTLKey{
+		{Key: "operation", Value: "name"},
+		{Key: "kind", Value: "kind"},
+	})
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+
+	processedTraces, err := gap.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	require.Equal(t, 1, processedTraces.ResourceSpans().Len())
+	// Each key keeps its own expression.
+	assert.Equal(t, map[string]any{
+		"operation": "GET /cart",
+		"kind":      int64(ptrace.SpanKindServer),
+	}, processedTraces.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+}
+
+func TestOTTLKeysErrorMode(t *testing.T) {
+	newLogs := func() plog.Logs {
+		logs := plog.NewLogs()
+		lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.Body().SetStr("not json")
+		return logs
+	}
+
+	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{})
+	var err error
+	gap.logKeys, err = newLogKeys(componenttest.NewNopTelemetrySettings(), []OTTLKey{{Key: "tenant", Value: `ParseJSON(body)["tenant"]`}})
+	require.NoError(t, err)
+
+	gap.errorMode = ottl.PropagateError
+	_, err = gap.processLogs(context.Background(), newLogs())
+	assert.ErrorContains(t, err, `failed to compute key "tenant"`)
+
+	gap.errorMode = ottl.IgnoreError
+	processedLogs, err := gap.processLogs(context.Background(), newLogs())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processedLogs.LogRecordCount())
+	assert.Equal(t, 0, processedLogs.ResourceLogs().At(0).Resource().Attributes().Len())
+}
+
+func TestMaxRecordsPerGroup(t *testing.T) {
+	spans := someSpans(attrMap, 2, 5)
+	logs := someLogs(attrMap, 2, 5)
+	metrics := someGaugeMetrics(attrMap, 2, 5)
+
+	gap := createGroupByAttrsProcessor(zap.NewNop(), []string{})
+	gap.maxRecords = 3
+
+	processedSpans, err := gap.processTraces(context.Background(), spans)
+	require.NoError(t, err)
+	processedLogs, err := gap.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	processedMetrics, err := gap.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+
+	// 10 records of the same Resource are split into groups of at most 3 records
+	require.Equal(t, 4, processedSpans.ResourceSpans().Len())
+	require.Equal(t, 4, processedLogs.ResourceLogs().Len())
+	require.Equal(t, 4, processedMetrics.ResourceMetrics().Len())
+	for i, expected := range []int{3, 3, 3, 1} {
+		assert.Equal(t, expected, resourceSpansCount(processedSpans.ResourceSpans().At(i)))
+		assert.Equal(t, expected, resourceLogsCount(processedLogs.ResourceLogs().At(i)))
+		assert.Equal(t, expected, resourceDataPointCount(processedMetrics.ResourceMetrics().At(i)))
+	}
+	assert.Equal(t, 10, processedSpans.SpanCount())
+	assert.Equal(t, 10, processedLogs.LogRecordCount())
+	assert.Equal(t, 10, processedMetrics.DataPointCount())
+}
+
+func resourceSpansCount(rs ptrace.ResourceSpans) int {
+	count := 0
+	for i := 0; i < rs.ScopeSpans().Len(); i++ {
+		count += rs.ScopeSpans().At(i).Spans().Len()
+	}
+	return count
+}
+
+func resourceLogsCount(rl plog.ResourceLogs) int {
+	count := 0
+	for i := 0; i < rl.ScopeLogs().Len(); i++ {
+		count += rl.ScopeLogs().At(i).LogRecords().Len()
+	}
+	return count
+}
+
+func resourceDataPointCount(rm pmetric.ResourceMetrics) int {
+	count := 0
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		for j := 0; j < rm.ScopeMetrics().At(i).Metrics().Len(); j++ {
+			count += dataPointCount(rm.ScopeMetrics().At(i).Metrics().At(j))
+		}
+	}
+	return count
+}
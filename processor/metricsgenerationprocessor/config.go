@@ -26,6 +26,12 @@ const (
 
 	// operationFieldName is the mapstructure field name for Operation field
 	operationFieldName = "operation"
+
+	// expressionFieldName is the mapstructure field name for Expression field
+	expressionFieldName = "expression"
+
+	// outputTypeFieldName is the mapstructure field name for OutputType field
+	outputTypeFieldName = "output_type"
 )
 
 // Config defines the configuration for the processor.
@@ -45,7 +51,7 @@ type Rule struct {
 	// The rule type following which the new metric will be generated. This is a required field.
 	Type GenerationType `mapstructure:"type"`
 
-	// First operand metric to use in the calculation. This is a required field.
+	// First operand metric to use in the calculation. A required field unless the type is expression.
 	Metric1 string `mapstructure:"metric1"`
 
 	// Second operand metric to use in the calculation. A required field if the type is calculate.
@@ -56,6 +62,20 @@ type Rule struct {
 
 	// A constant number by which the first operand will be scaled. A required field if the type is scale.
 	ScaleBy float64 `mapstructure:"scale_by"`
+
+	// The arithmetic expression to evaluate, e.g. "(a - b) / c * 100". A required field if the type is expression.
+	Expression string `mapstructure:"expression"`
+
+	// Maps the variables used in the expression to metric names. Variables that are not
+	// listed here refer to the metric with the same name.
+	Metrics map[string]string `mapstructure:"metrics"`
+
+	// The datapoint attributes used to join the datapoints of the expression metrics.
+	// If empty, datapoints are joined on all of their attributes.
+	MatchAttributes []string `mapstructure:"match_attributes"`
+
+	// The type of the generated metric for generation type expression, either gauge (default) or sum.
+	OutputType OutputType `mapstructure:"output_type"`
 }
 
 type GenerationType string
@@ -67,9 +87,12 @@ const (
 
 	// Generates a new metric scaling the value of s given metric with a provided constant
 	scale GenerationType = "scale"
+
+	// Generates a new metric evaluating an arithmetic expression over several metrics
+	expression GenerationType = "expression"
 )
 
-var generationTypes = map[GenerationType]struct{}{calculate: {}, scale: {}}
+var generationTypes = map[GenerationType]struct{}{calculate: {}, scale: {}, expression: {}}
 
 func (gt GenerationType) isValid() bool {
 	_, ok := generationTypes[gt]
@@ -131,6 +154,35 @@ var operationTypeKeys = func() []string {
 	return ret
 }
 
+type OutputType string
+
+const (
+
+	// Generates a gauge metric
+	gauge OutputType = "gauge"
+
+	// Generates a non-monotonic sum metric
+	sum OutputType = "sum"
+)
+
+var outputTypes = map[OutputType]struct{}{gauge: {}, sum: {}}
+
+func (ot OutputType) isValid() bool {
+	_, ok := outputTypes[ot]
+	return ok
+}
+
+var outputTypeKeys = func() []string {
+	ret := make([]string, len(outputTypes))
+	i := 0
+	for k := range outputTypes {
+		ret[i] = string(k)
+		i++
+	}
+	sort.Strings(ret)
+	return ret
+}
+
 // Validate checks whether the input configuration has all of the required fields for the processor.
 // An error is returned if there are any invalid inputs.
 func (config *Config) Validate() error {
@@ -147,6 +199,13 @@ func (config *Config) Validate() error {
 			return fmt.Errorf("%q must be in %q", typeFieldName, generationTypeKeys())
 		}
 
+		if rule.Type == expression {
+			if err := rule.validateExpression(); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if rule.Metric1 == "" {
 			return fmt.Errorf("missing required field %q", metric1FieldName)
 		}
@@ -165,3 +224,20 @@ func (config *Config) Validate() error {
 	}
 	return nil
 }
+
+func (rule Rule) validateExpression() error {
+	if rule.Expression == "" {
+		return fmt.Errorf("missing required field %q for generation type %q", expressionFieldName, expression)
+	}
+	expr, err := parseExpression(rule.Expression)
+	if err != nil {
+		return fmt.Errorf("invalid %q for metric %q: %w", expressionFieldName, rule.Name, err)
+	}
+	if len(expr.variables) == 0 {
+		return fmt.Errorf("%q for metric %q must reference at least one metric", expressionFieldName, rule.Name)
+	}
+	if rule.OutputType != "" && !rule.OutputType.isValid() {
+		return fmt.Errorf("%q must be in %q", outputTypeFieldName, outputTypeKeys())
+	}
+	return nil
+}
@@ -75,6 +75,37 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "invalid_operation"),
 			errorMessage: fmt.Sprintf("%q must be in %q", operationFieldName, operationTypeKeys()),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "expression"),
+			expected: &Config{
+				Rules: []Rule{
+					{
+						Name:       "disk.utilization",
+						Unit:       "%",
+						Type:       "expression",
+						Expression: "(total - free) / total * 100",
+						Metrics: map[string]string{
+							"total": "system.filesystem.total",
+							"free":  "system.filesystem.free",
+						},
+						MatchAttributes: []string{"device"},
+						OutputType:      "gauge",
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_expression"),
+			errorMessage: fmt.Sprintf("missing required field %q for generation type %q", expressionFieldName, expression),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_expression"),
+			errorMessage: fmt.Sprintf("invalid %q for metric %q: missing ')' at position 6", expressionFieldName, "new_metric"),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_output_type"),
+			errorMessage: fmt.Sprintf("%q must be in %q", outputTypeFieldName, outputTypeKeys()),
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsgenerationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsgenerationprocessor"
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// expr is a parsed arithmetic expression over named variables.
+// It supports the binary operators +, -, * and /, unary minus, parentheses
+// and numeric constants.
+type expr struct {
+	root exprNode
+	// variables lists the referenced variables in order of first appearance.
+	variables []string
+}
+
+type exprNode interface {
+	// eval returns the value of the node, or false if it is undefined
+	// because of a division by zero.
+	eval(vars map[string]float64) (float64, bool)
+}
+
+type constNode float64
+
+func (n constNode) eval(map[string]float64) (float64, bool) {
+	return float64(n), true
+}
+
+type varNode string
+
+func (n varNode) eval(vars map[string]float64) (float64, bool) {
+	return vars[string(n)], true
+}
+
+type negNode struct {
+	operand exprNode
+}
+
+func (n negNode) eval(vars map[string]float64) (float64, bool) {
+	v, ok := n.operand.eval(vars)
+	return -v, ok
+}
+
+type binaryNode struct {
+	op          byte
+	left, right exprNode
+}
+
+func (n binaryNode) eval(vars map[string]float64) (float64, bool) {
+	l, ok := n.left.eval(vars)
+	if !ok {
+		return 0, false
+	}
+	r, ok := n.right.eval(vars)
+	if !ok {
+		return 0, false
+	}
+	switch n.op {
+	case '+':
+		return l + r, true
+	case '-':
+		return l - r, true
+	case '*':
+		return l * r, true
+	case '/':
+		if r == 0 {
+			return 0, false
+		}
+		return l / r, true
+	}
+	return 0, false
+}
+
+// parseExpression parses the given expression. Variables are identifiers
+// made of letters, digits, '_' and '.', so metric names can be used as is.
+func parseExpression(s string) (*expr, error) {
+	p := &exprParser{input: s}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos)
+	}
+	return &expr{root: root, variables: p.variables}, nil
+}
+
+func (e *expr) eval(vars map[string]float64) (float64, bool) {
+	return e.root.eval(vars)
+}
+
+type exprParser struct {
+	input     string
+	pos       int
+	variables []string
+}
+
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space character, or 0 at the end of the input.
+func (p *exprParser) peek() byte {
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// parseSum parses terms separated by '+' or '-'.
+func (p *exprParser) parseSum() (exprNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+// parseProduct parses factors separated by '*' or '/'.
+func (p *exprParser) parseProduct() (exprNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+// parseFactor parses a number, a variable, a negated factor or a
+// parenthesized expression.
+func (p *exprParser) parseFactor() (exprNode, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of expression")
+	case c == '-':
+		p.pos++
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return negNode{operand: operand}, nil
+	case c == '(':
+		p.pos++
+		node, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ')' at position %d", p.pos)
+		}
+		p.pos++
+		return node, nil
+	case isDigit(c):
+		start := p.pos
+		for p.pos < len(p.input) && (isDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		value, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", p.input[start:p.pos], start)
+		}
+		return constNode(value), nil
+	case isIdentStart(c):
+		start := p.pos
+		for p.pos < len(p.input) && isIdentPart(p.input[p.pos]) {
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		p.addVariable(name)
+		return varNode(name), nil
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", c, p.pos)
+}
+
+func (p *exprParser) addVariable(name string) {
+	for _, v := range p.variables {
+		if v == name {
+			return
+		}
+	}
+	p.variables = append(p.variables, name)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.'
+}
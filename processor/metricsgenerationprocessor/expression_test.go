@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsgenerationprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	vars := map[string]float64{"a": 10, "b": 4, "c": 2, "system.memory.usage": 3}
+	tests := []struct {
+		expression string
+		variables  []string
+		want       float64
+		undefined  bool
+	}{
+		{expression: "a", variables: []string{"a"}, want: 10},
+		{expression: "1.5", want: 1.5},
+		{expression: "a + b * c", variables: []string{"a", "b", "c"}, want: 18},
+		{expression: "(a - b) / c * 100", variables: []string{"a", "b", "c"}, want: 300},
+		{expression: "a - b - c", variables: []string{"a", "b", "c"}, want: 4},
+		{expression: "-a + -(b - c)", variables: []string{"a", "b", "c"}, want: -12},
+		{expression: "a / a", variables: []string{"a"}, want: 1},
+		{expression: "system.memory.usage * 2", variables: []string{"system.memory.usage"}, want: 6},
+		{expression: "a / (b - 4)", variables: []string{"a", "b"}, undefined: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := parseExpression(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.variables, e.variables)
+			got, ok := e.eval(vars)
+			assert.Equal(t, !tt.undefined, ok)
+			if !tt.undefined {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := map[string]string{
+		"":        "unexpected end of expression",
+		"a +":     "unexpected end of expression",
+		"(a - b":  "missing ')' at position 6",
+		"a b":     `unexpected 'b' at position 2`,
+		"a % b":   `unexpected '%' at position 2`,
+		"1.2.3":   `invalid number "1.2.3" at position 0`,
+		"a * (b)": "",
+	}
+	for expression, wantErr := range tests {
+		t.Run(expression, func(t *testing.T) {
+			_, err := parseExpression(expression)
+			if wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, wantErr)
+		})
+	}
+}
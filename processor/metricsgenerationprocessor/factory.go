@@ -65,6 +65,13 @@ func buildInternalConfig(config *Config) []internalRule {
 			operation: string(rule.Operation),
 			scaleBy:   rule.ScaleBy,
 		}
+		if rule.Type == expression {
+			// The expression is known to be valid at this point.
+			customRule.expr, _ = parseExpression(rule.Expression)
+			customRule.metrics = rule.Metrics
+			customRule.matchAttributes = rule.MatchAttributes
+			customRule.outputType = string(rule.OutputType)
+		}
 		internalRules[i] = customRule
 	}
 	return internalRules
@@ -24,6 +24,12 @@ type internalRule struct {
 	metric2   string
 	operation string
 	scaleBy   float64
+
+	// expression rules
+	expr            *expr
+	metrics         map[string]string
+	matchAttributes []string
+	outputType      string
 }
 
 func newMetricsGenerationProcessor(rules []internalRule, logger *zap.Logger) *metricsGenerationProcessor {
@@ -47,6 +53,11 @@ func (mgp *metricsGenerationProcessor) processMetrics(_ context.Context, md pmet
 		nameToMetricMap := getNameToMetricMap(rm)
 
 		for _, rule := range mgp.rules {
+			if rule.ruleType == string(expression) {
+				generateExpressionMetrics(rm, nameToMetricMap, rule, mgp.logger)
+				continue
+			}
+
 			operand2 := float64(0)
 			_, ok := nameToMetricMap[rule.metric1]
 			if !ok {
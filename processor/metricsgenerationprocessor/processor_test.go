@@ -384,3 +384,115 @@ func getOutputForIntGaugeTest() pmetric.Metrics {
 
 	return intGaugeOutputMetrics
 }
+
+func TestMetricsGenerationProcessorExpression(t *testing.T) {
+	start := pcommon.NewTimestampFromTime(time.Now().Add(-time.Minute))
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	addPoints := func(name string, points ...map[string]any) {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		for _, point := range points {
+			dp := sum.DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(now)
+			dp.SetIntValue(int64(point["value"].(int)))
+			delete(point, "value")
+			require.NoError(t, dp.Attributes().FromRaw(point))
+		}
+	}
+	addPoints("disk.total",
+		map[string]any{"device": "sda", "value": 200},
+		map[string]any{"device": "sdb", "value": 100},
+		map[string]any{"device": "sdc", "value": 0},
+	)
+	addPoints("disk.free",
+		map[string]any{"device": "sda", "mode": "user", "value": 40},
+		map[string]any{"device": "sda", "mode": "root", "value": 10},
+		map[string]any{"device": "sdb", "mode": "user", "value": 25},
+		map[string]any{"device": "sdc", "mode": "user", "value": 0},
+	)
+	addPoints("disk.reserved",
+		map[string]any{"device": "sda", "value": 50},
+		map[string]any{"device": "sdc", "value": 0},
+	)
+
+	next := new(consumertest.MetricsSink)
+	cfg := &Config{
+		Rules: []Rule{
+			{
+				Name:       "disk.utilization",
+				Unit:       "%",
+				Type:       expression,
+				Expression: "(total - free - disk.reserved) / total * 100",
+				Metrics: map[string]string{
+					"total": "disk.total",
+					"free":  "disk.free",
+				},
+				MatchAttributes: []string{"device"},
+				OutputType:      sum,
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	mgp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, next.AllMetrics(), 1)
+	metrics := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 4, metrics.Len())
+
+	generated := metrics.At(3)
+	assert.Equal(t, "disk.utilization", generated.Name())
+	assert.Equal(t, "%", generated.Unit())
+	require.Equal(t, pmetric.MetricTypeSum, generated.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, generated.Sum().AggregationTemporality())
+
+	// sdb has no reserved datapoint and sdc would divide by zero.
+	dps := generated.Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 50.0, dps.At(0).DoubleValue())
+	assert.Equal(t, map[string]any{"device": "sda"}, dps.At(0).Attributes().AsRaw())
+	assert.Equal(t, start, dps.At(0).StartTimestamp())
+	assert.Equal(t, now, dps.At(0).Timestamp())
+}
+
+func TestMetricsGenerationProcessorExpressionAllAttributes(t *testing.T) {
+	md := generateTestMetrics(testMetric{
+		metricNames:  []string{"metric_1", "metric_2"},
+		metricValues: [][]float64{{100, 50}, {4, 5}},
+	})
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		ms.At(i).Gauge().DataPoints().At(0).Attributes().PutStr("host", "a")
+		ms.At(i).Gauge().DataPoints().At(1).Attributes().PutStr("host", "b")
+	}
+
+	next := new(consumertest.MetricsSink)
+	cfg := &Config{
+		Rules: []Rule{
+			{
+				Name:       "metric_sum",
+				Type:       expression,
+				Expression: "metric_1 + metric_2",
+			},
+		},
+	}
+	mgp, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), md))
+
+	generated := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2)
+	require.Equal(t, pmetric.MetricTypeGauge, generated.Type())
+	dps := generated.Gauge().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, 104.0, dps.At(0).DoubleValue())
+	assert.Equal(t, map[string]any{"host": "a"}, dps.At(0).Attributes().AsRaw())
+	assert.Equal(t, 55.0, dps.At(1).DoubleValue())
+	assert.Equal(t, map[string]any{"host": "b"}, dps.At(1).Attributes().AsRaw())
+}
@@ -4,6 +4,11 @@
 package metricsgenerationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsgenerationprocessor"
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 )
@@ -109,3 +114,180 @@ func calculateValue(operand1 float64, operand2 float64, operation string, logger
 	}
 	return 0
 }
+
+// joinedDataPoint is the aggregated value of the datapoints of one metric that share the same join key.
+type joinedDataPoint struct {
+	attributes pcommon.Map
+	start      pcommon.Timestamp
+	timestamp  pcommon.Timestamp
+	value      float64
+}
+
+// generateExpressionMetrics creates a new metric evaluating the rule expression for every set of
+// datapoints that are joined on the rule's match attributes, and adds it to the scope of the first
+// metric referenced by the expression.
+func generateExpressionMetrics(rm pmetric.ResourceMetrics, nameToMetricMap map[string]pmetric.Metric, rule internalRule, logger *zap.Logger) {
+	joined := make([]map[string]*joinedDataPoint, len(rule.expr.variables))
+	var keys []string
+	for i, variable := range rule.expr.variables {
+		metricName := expressionMetricName(rule, variable)
+		metric, ok := nameToMetricMap[metricName]
+		if !ok {
+			logger.Debug("Missing expression metric", zap.String("metric_name", metricName))
+			return
+		}
+		var dataPoints pmetric.NumberDataPointSlice
+		switch metric.Type() {
+		case pmetric.MetricTypeGauge:
+			dataPoints = metric.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			dataPoints = metric.Sum().DataPoints()
+		default:
+			logger.Debug("Unsupported expression metric type", zap.String("metric_name", metricName))
+			return
+		}
+		var points []string
+		joined[i], points = joinDataPoints(dataPoints, rule.matchAttributes)
+		if i == 0 {
+			keys = points
+		}
+	}
+
+	first := nameToMetricMap[expressionMetricName(rule, rule.expr.variables[0])]
+
+	var newDataPoints pmetric.NumberDataPointSlice
+	created := false
+	vars := make(map[string]float64, len(rule.expr.variables))
+	for _, key := range keys {
+		point := joined[0][key]
+		missing := false
+		for i, variable := range rule.expr.variables {
+			p, ok := joined[i][key]
+			if !ok {
+				missing = true
+				break
+			}
+			vars[variable] = p.value
+			if p.timestamp > point.timestamp {
+				point.timestamp = p.timestamp
+			}
+		}
+		if missing {
+			continue
+		}
+		value, ok := rule.expr.eval(vars)
+		if !ok {
+			logger.Debug("Divide by zero was attempted while calculating metric", zap.String("metric_name", rule.name))
+			continue
+		}
+
+		if !created {
+			newDataPoints = appendExpressionMetric(rm, first, rule)
+			created = true
+		}
+		dp := newDataPoints.AppendEmpty()
+		point.attributes.CopyTo(dp.Attributes())
+		dp.SetStartTimestamp(point.start)
+		dp.SetTimestamp(point.timestamp)
+		dp.SetDoubleValue(value)
+	}
+}
+
+// expressionMetricName returns the name of the metric referenced by an expression variable.
+func expressionMetricName(rule internalRule, variable string) string {
+	if name, ok := rule.metrics[variable]; ok {
+		return name
+	}
+	return variable
+}
+
+// joinDataPoints groups the datapoints by the values of the given attributes, or by all of
+// their attributes if none are given. The values of datapoints in the same group are summed.
+// The returned keys are in order of first appearance.
+func joinDataPoints(dataPoints pmetric.NumberDataPointSlice, matchAttributes []string) (map[string]*joinedDataPoint, []string) {
+	joined := make(map[string]*joinedDataPoint, dataPoints.Len())
+	var keys []string
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+		attributes := pcommon.NewMap()
+		if len(matchAttributes) == 0 {
+			dp.Attributes().CopyTo(attributes)
+		} else {
+			for _, name := range matchAttributes {
+				if v, ok := dp.Attributes().Get(name); ok {
+					v.CopyTo(attributes.PutEmpty(name))
+				}
+			}
+		}
+
+		var value float64
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			value = dp.DoubleValue()
+		case pmetric.NumberDataPointValueTypeInt:
+			value = float64(dp.IntValue())
+		}
+
+		key := attributesKey(attributes)
+		if p, ok := joined[key]; ok {
+			p.value += value
+			if dp.Timestamp() > p.timestamp {
+				p.timestamp = dp.Timestamp()
+			}
+			continue
+		}
+		joined[key] = &joinedDataPoint{
+			attributes: attributes,
+			start:      dp.StartTimestamp(),
+			timestamp:  dp.Timestamp(),
+			value:      value,
+		}
+		keys = append(keys, key)
+	}
+	return joined, keys
+}
+
+func attributesKey(attributes pcommon.Map) string {
+	keys := make([]string, 0, attributes.Len())
+	attributes.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		v, _ := attributes.Get(k)
+		b.WriteString(strconv.Quote(k))
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(v.AsString()))
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// appendExpressionMetric adds the metric generated by an expression rule next to the given metric
+// and returns its datapoints.
+func appendExpressionMetric(rm pmetric.ResourceMetrics, next pmetric.Metric, rule internalRule) pmetric.NumberDataPointSlice {
+	ilms := rm.ScopeMetrics()
+	ilm := ilms.At(0)
+	for i := 0; i < ilms.Len(); i++ {
+		metrics := ilms.At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			if metrics.At(j).Name() == next.Name() {
+				ilm = ilms.At(i)
+			}
+		}
+	}
+
+	newMetric := appendMetric(ilm, rule.name, rule.unit)
+	if rule.outputType != string(sum) {
+		return newMetric.SetEmptyGauge().DataPoints()
+	}
+	newSum := newMetric.SetEmptySum()
+	newSum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	if next.Type() == pmetric.MetricTypeSum {
+		newSum.SetAggregationTemporality(next.Sum().AggregationTemporality())
+	}
+	return newSum.DataPoints()
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// adjustedCount returns the number of items represented by an item that was sampled
+// with the given scaled sampling rate, i.e. the inverse of its sampling probability.
+func adjustedCount(scaledSamplingRate uint32) float64 {
+	if scaledSamplingRate == 0 || scaledSamplingRate >= numHashBuckets {
+		return 1
+	}
+	return float64(numHashBuckets) / float64(scaledSamplingRate)
+}
+
+// setAdjustedCount stores the adjusted count of a sampled item in its attributes. If the
+// attribute was already set, e.g. by a previous sampling tier, the counts are multiplied
+// so that the attribute reflects the overall sampling probability.
+func setAdjustedCount(attrs pcommon.Map, key string, count float64) {
+	if key == "" {
+		return
+	}
+	if previous, ok := attrs.Get(key); ok {
+		switch previous.Type() {
+		case pcommon.ValueTypeDouble:
+			count *= previous.Double()
+		case pcommon.ValueTypeInt:
+			count *= float64(previous.Int())
+		}
+	}
+	attrs.PutDouble(key, count)
+}
@@ -4,7 +4,9 @@
 package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
 
 import (
+	"errors"
 	"fmt"
+	"math"
 
 	"go.opentelemetry.io/collector/component"
 )
@@ -46,6 +48,24 @@ type Config struct {
 	// SamplingPriority (logs only) allows to use a log record attribute designed by the `sampling_priority` key
 	// to be used as the sampling priority of the log record.
 	SamplingPriority string `mapstructure:"sampling_priority"`
+
+	// AdjustedCountAttribute is the optional name of an attribute that is set on every sampled span or log record
+	// to the number of items it represents, i.e. the inverse of the sampling probability applied to it. If the
+	// attribute is already present, e.g. from a previous sampling tier, its value is multiplied accordingly.
+	AdjustedCountAttribute string `mapstructure:"adjusted_count_attribute"`
+
+	// RuntimeSettings optionally reads the sampling percentage from a runtime settings extension, so that it can
+	// be changed without restarting the collector. SamplingPercentage applies while the setting is absent.
+	RuntimeSettings *RuntimeSettingsConfig `mapstructure:"runtime_settings"`
+}
+
+// RuntimeSettingsConfig defines where the sampling percentage is read from at runtime.
+type RuntimeSettingsConfig struct {
+	// Extension is the ID of the runtime settings extension.
+	Extension component.ID `mapstructure:"extension"`
+
+	// SamplingPercentageKey is the key of the sampling percentage in the runtime settings.
+	SamplingPercentageKey string `mapstructure:"sampling_percentage_key"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -55,8 +75,19 @@ func (cfg *Config) Validate() error {
 	if cfg.SamplingPercentage < 0 {
 		return fmt.Errorf("negative sampling rate: %.2f", cfg.SamplingPercentage)
 	}
+	if math.IsNaN(float64(cfg.SamplingPercentage)) {
+		return errors.New("invalid sampling rate: NaN")
+	}
 	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
 		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
 	}
+	if cfg.RuntimeSettings != nil {
+		if cfg.RuntimeSettings.Extension.Type() == "" {
+			return errors.New("runtime_settings: extension must be set")
+		}
+		if cfg.RuntimeSettings.SamplingPercentageKey == "" {
+			return errors.New("runtime_settings: sampling_percentage_key must be set")
+		}
+	}
 	return nil
 }
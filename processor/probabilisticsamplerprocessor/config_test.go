@@ -33,11 +33,23 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "logs"),
 			expected: &Config{
-				SamplingPercentage: 15.3,
-				HashSeed:           22,
-				AttributeSource:    "record",
-				FromAttribute:      "foo",
-				SamplingPriority:   "bar",
+				SamplingPercentage:     15.3,
+				HashSeed:               22,
+				AttributeSource:        "record",
+				FromAttribute:          "foo",
+				SamplingPriority:       "bar",
+				AdjustedCountAttribute: "sampling.adjusted_count",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "runtime_settings"),
+			expected: &Config{
+				SamplingPercentage: 100,
+				AttributeSource:    "traceID",
+				RuntimeSettings: &RuntimeSettingsConfig{
+					Extension:             component.NewID("runtime_settings"),
+					SamplingPercentageKey: "sampling.percentage",
+				},
 			},
 		},
 	}
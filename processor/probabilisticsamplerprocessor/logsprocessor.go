@@ -18,12 +18,13 @@ import (
 )
 
 type logSamplerProcessor struct {
-	scaledSamplingRate uint32
-	hashSeed           uint32
-	traceIDEnabled     bool
-	samplingSource     string
-	samplingPriority   string
-	logger             *zap.Logger
+	samplingRate           *samplingRate
+	hashSeed               uint32
+	traceIDEnabled         bool
+	samplingSource         string
+	samplingPriority       string
+	adjustedCountAttribute string
+	logger                 *zap.Logger
 }
 
 // newLogsProcessor returns a processor.LogsProcessor that will perform head sampling according to the given
@@ -31,12 +32,13 @@ type logSamplerProcessor struct {
 func newLogsProcessor(ctx context.Context, set processor.CreateSettings, nextConsumer consumer.Logs, cfg *Config) (processor.Logs, error) {
 
 	lsp := &logSamplerProcessor{
-		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
-		hashSeed:           cfg.HashSeed,
-		traceIDEnabled:     cfg.AttributeSource == traceIDAttributeSource,
-		samplingPriority:   cfg.SamplingPriority,
-		samplingSource:     cfg.FromAttribute,
-		logger:             set.Logger,
+		samplingRate:           newSamplingRate(cfg, set.Logger),
+		hashSeed:               cfg.HashSeed,
+		traceIDEnabled:         cfg.AttributeSource == traceIDAttributeSource,
+		samplingPriority:       cfg.SamplingPriority,
+		samplingSource:         cfg.FromAttribute,
+		adjustedCountAttribute: cfg.AdjustedCountAttribute,
+		logger:                 set.Logger,
 	}
 
 	return processorhelper.NewLogsProcessor(
@@ -45,10 +47,13 @@ func newLogsProcessor(ctx context.Context, set processor.CreateSettings, nextCon
 		cfg,
 		nextConsumer,
 		lsp.processLogs,
-		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		processorhelper.WithStart(lsp.samplingRate.start),
+		processorhelper.WithShutdown(lsp.samplingRate.shutdown))
 }
 
 func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	scaledSamplingRate := lsp.samplingRate.load()
 	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
 		rl.ScopeLogs().RemoveIf(func(ill plog.ScopeLogs) bool {
 			ill.LogRecords().RemoveIf(func(l plog.LogRecord) bool {
@@ -67,7 +72,7 @@ func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (
 						lidBytes = value.Bytes().AsRaw()
 					}
 				}
-				priority := lsp.scaledSamplingRate
+				priority := scaledSamplingRate
 				if lsp.samplingPriority != "" {
 					if localPriority, ok := l.Attributes().Get(lsp.samplingPriority); ok {
 						switch localPriority.Type() {
@@ -89,6 +94,10 @@ func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (
 					lsp.logger.Error(err.Error())
 				}
 
+				if sampled {
+					setAdjustedCount(l.Attributes(), lsp.adjustedCountAttribute, adjustedCount(priority))
+				}
+
 				return !sampled
 			})
 			// Filter out empty ScopeLogs
@@ -170,3 +170,52 @@ func TestLogsSampling(t *testing.T) {
 		})
 	}
 }
+
+func TestLogsAdjustedCount(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := &Config{
+		SamplingPercentage:     50,
+		AttributeSource:        traceIDAttributeSource,
+		SamplingPriority:       "priority",
+		AdjustedCountAttribute: "sampling.adjusted_count",
+	}
+	processor, err := newLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), sink, cfg)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 100; i++ {
+		record := lr.AppendEmpty()
+		ib := byte(i)
+		record.SetTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 0, ib, ib, ib, ib, ib, ib, ib, ib})
+		switch i % 4 {
+		case 0:
+			record.Attributes().PutDouble("priority", 100)
+		case 1:
+			// Sampled by a previous tier at 50%.
+			record.Attributes().PutBool("previous_tier", true)
+			record.Attributes().PutDouble("sampling.adjusted_count", 2)
+		}
+	}
+	require.NoError(t, processor.ConsumeLogs(context.Background(), logs))
+
+	sunk := sink.AllLogs()
+	require.Len(t, sunk, 1)
+	records := sunk[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Greater(t, records.Len(), 25)
+	for i := 0; i < records.Len(); i++ {
+		record := records.At(i)
+		count, ok := record.Attributes().Get("sampling.adjusted_count")
+		require.True(t, ok)
+		_, hasPriority := record.Attributes().Get("priority")
+		_, hasPreviousTier := record.Attributes().Get("previous_tier")
+		switch {
+		case hasPriority:
+			assert.Equal(t, float64(1), count.Double())
+		case hasPreviousTier:
+			assert.Equal(t, float64(4), count.Double())
+		default:
+			assert.Equal(t, float64(2), count.Double())
+		}
+	}
+}
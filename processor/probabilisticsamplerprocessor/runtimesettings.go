@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// runtimeSettings is the part of the runtime settings extension API used by the processor.
+type runtimeSettings interface {
+	Float64(key string) (float64, bool)
+	Subscribe(fn func()) (unsubscribe func())
+}
+
+// samplingRate holds the scaled sampling rate, which follows the runtime settings when configured.
+type samplingRate struct {
+	configured uint32
+	current    atomic.Uint32
+
+	cfg         *RuntimeSettingsConfig
+	settings    runtimeSettings
+	unsubscribe func()
+	logger      *zap.Logger
+}
+
+func newSamplingRate(cfg *Config, logger *zap.Logger) *samplingRate {
+	// The configured percentage is valid once the configuration is validated
+	configured, _ := scaleSamplingPercentage(float64(cfg.SamplingPercentage))
+	r := &samplingRate{
+		configured: configured,
+		cfg:        cfg.RuntimeSettings,
+		logger:     logger,
+	}
+	r.current.Store(r.configured)
+	return r
+}
+
+// scaleSamplingPercentage adjusts the sampling percentage to the hash buckets so recalculations are avoided.
+// It reports false for a negative or NaN percentage, which has no sampling rate.
+func scaleSamplingPercentage(pct float64) (uint32, bool) {
+	if pct < 0 || math.IsNaN(pct) {
+		return 0, false
+	}
+	if pct > 100 {
+		pct = 100
+	}
+	return uint32(pct * percentageScaleFactor), true
+}
+
+func (r *samplingRate) load() uint32 {
+	return r.current.Load()
+}
+
+func (r *samplingRate) start(_ context.Context, host component.Host) error {
+	if r.cfg == nil {
+		return nil
+	}
+	ext, ok := host.GetExtensions()[r.cfg.Extension]
+	if !ok {
+		return fmt.Errorf("extension %q not found", r.cfg.Extension)
+	}
+	settings, ok := ext.(runtimeSettings)
+	if !ok {
+		return fmt.Errorf("extension %q does not provide runtime settings", r.cfg.Extension)
+	}
+	r.settings = settings
+	r.unsubscribe = settings.Subscribe(r.update)
+	r.update()
+	return nil
+}
+
+func (r *samplingRate) shutdown(context.Context) error {
+	if r.unsubscribe != nil {
+		r.unsubscribe()
+		r.unsubscribe = nil
+	}
+	return nil
+}
+
+// update applies the sampling percentage of the runtime settings, or the configured one when it is absent or invalid.
+func (r *samplingRate) update() {
+	rate := r.configured
+	if pct, ok := r.settings.Float64(r.cfg.SamplingPercentageKey); ok {
+		if scaled, valid := scaleSamplingPercentage(pct); valid {
+			rate = scaled
+		} else {
+			r.logger.Warn("Ignoring invalid sampling percentage from the runtime settings",
+				zap.String("key", r.cfg.SamplingPercentageKey), zap.Float64("sampling_percentage", pct))
+		}
+	}
+	if r.current.Swap(rate) != rate {
+		r.logger.Info("Sampling percentage changed", zap.Float64("sampling_percentage", float64(rate)/percentageScaleFactor))
+	}
+}
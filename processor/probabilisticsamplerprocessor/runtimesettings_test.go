@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestRuntimeSettingsSamplingPercentage(t *testing.T) {
+	settingsID := component.NewID("runtime_settings")
+	settings := &fakeRuntimeSettings{values: map[string]float64{"sampling.percentage": 0}}
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{settingsID: settings},
+	}
+
+	cfg := &Config{
+		SamplingPercentage: 100,
+		RuntimeSettings: &RuntimeSettingsConfig{
+			Extension:             settingsID,
+			SamplingPercentageKey: "sampling.percentage",
+		},
+	}
+	sink := new(consumertest.TracesSink)
+	tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tsp.Start(context.Background(), host))
+
+	consume := func() int {
+		sink.Reset()
+		for _, td := range genRandomTestData(10, 100, "test-svc", 1) {
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+		}
+		return sink.SpanCount()
+	}
+
+	assert.Zero(t, consume(), "the runtime setting overrides the configured percentage")
+
+	settings.set("sampling.percentage", 100)
+	assert.Equal(t, 1000, consume())
+
+	settings.set("sampling.percentage", -1)
+	assert.Equal(t, 1000, consume(), "an invalid runtime setting falls back to the configured percentage")
+
+	settings.set("sampling.percentage", 0)
+	assert.Zero(t, consume())
+	settings.set("sampling.percentage", math.NaN())
+	assert.Equal(t, 1000, consume(), "a NaN runtime setting falls back to the configured percentage")
+
+	settings.set("sampling.percentage", 0)
+	settings.delete("sampling.percentage")
+	assert.Equal(t, 1000, consume(), "a missing runtime setting falls back to the configured percentage")
+
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Empty(t, settings.subscribers)
+}
+
+func TestRuntimeSettingsStartErrors(t *testing.T) {
+	cfg := &Config{
+		RuntimeSettings: &RuntimeSettingsConfig{
+			Extension:             component.NewID("runtime_settings"),
+			SamplingPercentageKey: "sampling.percentage",
+		},
+	}
+
+	lsp, err := newLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	assert.EqualError(t, lsp.Start(context.Background(), componenttest.NewNopHost()), `extension "runtime_settings" not found`)
+
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{component.NewID("runtime_settings"): &nopExtension{}},
+	}
+	assert.EqualError(t, lsp.Start(context.Background(), host), `extension "runtime_settings" does not provide runtime settings`)
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type fakeRuntimeSettings struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu          sync.Mutex
+	values      map[string]float64
+	subscribers map[int]func()
+	nextID      int
+}
+
+func (s *fakeRuntimeSettings) Float64(key string) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+func (s *fakeRuntimeSettings) Subscribe(fn func()) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscribers == nil {
+		s.subscribers = map[int]func(){}
+	}
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = fn
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers, id)
+	}
+}
+
+func (s *fakeRuntimeSettings) set(key string, value float64) {
+	s.mu.Lock()
+	s.values[key] = value
+	s.mu.Unlock()
+	s.notify()
+}
+
+func (s *fakeRuntimeSettings) delete(key string) {
+	s.mu.Lock()
+	delete(s.values, key)
+	s.mu.Unlock()
+	s.notify()
+}
+
+func (s *fakeRuntimeSettings) notify() {
+	s.mu.Lock()
+	subscribers := make([]func(), 0, len(s.subscribers))
+	for _, fn := range s.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	s.mu.Unlock()
+	for _, fn := range subscribers {
+		fn()
+	}
+}
@@ -42,19 +42,20 @@ const (
 )
 
 type traceSamplerProcessor struct {
-	scaledSamplingRate uint32
-	hashSeed           uint32
-	logger             *zap.Logger
+	samplingRate           *samplingRate
+	hashSeed               uint32
+	adjustedCountAttribute string
+	logger                 *zap.Logger
 }
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform head sampling according to the given
 // configuration.
 func newTracesProcessor(ctx context.Context, set processor.CreateSettings, cfg *Config, nextConsumer consumer.Traces) (processor.Traces, error) {
 	tsp := &traceSamplerProcessor{
-		// Adjust sampling percentage on private so recalculations are avoided.
-		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
-		hashSeed:           cfg.HashSeed,
-		logger:             set.Logger,
+		samplingRate:           newSamplingRate(cfg, set.Logger),
+		hashSeed:               cfg.HashSeed,
+		adjustedCountAttribute: cfg.AdjustedCountAttribute,
+		logger:                 set.Logger,
 	}
 
 	return processorhelper.NewTracesProcessor(
@@ -63,10 +64,13 @@ func newTracesProcessor(ctx context.Context, set processor.CreateSettings, cfg *
 		cfg,
 		nextConsumer,
 		tsp.processTraces,
-		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		processorhelper.WithStart(tsp.samplingRate.start),
+		processorhelper.WithShutdown(tsp.samplingRate.shutdown))
 }
 
 func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	scaledSamplingRate := tsp.samplingRate.load()
 	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
 		rs.ScopeSpans().RemoveIf(func(ils ptrace.ScopeSpans) bool {
 			ils.Spans().RemoveIf(func(s ptrace.Span) bool {
@@ -94,13 +98,23 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 				// Hashing here prevents bias due to such systems.
 				tidBytes := s.TraceID()
 				sampled := sp == mustSampleSpan ||
-					computeHash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
+					computeHash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < scaledSamplingRate
 
 				_ = stats.RecordWithTags(
 					ctx,
 					[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
 					statCountTracesSampled.M(int64(1)),
 				)
+
+				if sampled {
+					// Spans forced by "sampling.priority" are not subject to the probabilistic
+					// decision, so each of them represents only itself.
+					count := float64(1)
+					if sp != mustSampleSpan {
+						count = adjustedCount(scaledSamplingRate)
+					}
+					setAdjustedCount(s.Attributes(), tsp.adjustedCountAttribute, count)
+				}
 				return !sampled
 			})
 			// Filter out empty ScopeMetrics
@@ -310,6 +310,77 @@ func Test_tracesamplerprocessor_SpanSamplingPriority(t *testing.T) {
 	}
 }
 
+// Test_tracesamplerprocessor_AdjustedCount checks that sampled spans carry the inverse of their sampling probability.
+func Test_tracesamplerprocessor_AdjustedCount(t *testing.T) {
+	const adjustedCountKey = "sampling.adjusted_count"
+	tests := []struct {
+		name          string
+		percentage    float32
+		priority      any
+		previousCount any
+		want          float64
+	}{
+		{
+			name:       "sampled_by_hash",
+			percentage: 100,
+			want:       1,
+		},
+		{
+			name:          "previous_tier_int",
+			percentage:    100,
+			previousCount: int64(10),
+			want:          10,
+		},
+		{
+			name:       "sampling_priority",
+			percentage: 0,
+			priority:   int64(1),
+			want:       1,
+		},
+		{
+			name:          "sampling_priority_previous_tier",
+			percentage:    0,
+			priority:      int64(1),
+			previousCount: float64(4),
+			want:          4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				SamplingPercentage:     tt.percentage,
+				AdjustedCountAttribute: adjustedCountKey,
+			}
+			sink := new(consumertest.TracesSink)
+			tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+
+			td := ptrace.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			if tt.priority != nil {
+				require.NoError(t, span.Attributes().PutEmpty("sampling.priority").FromRaw(tt.priority))
+			}
+			if tt.previousCount != nil {
+				require.NoError(t, span.Attributes().PutEmpty(adjustedCountKey).FromRaw(tt.previousCount))
+			}
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+
+			require.Equal(t, 1, sink.SpanCount())
+			sampled := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			count, ok := sampled.Attributes().Get(adjustedCountKey)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, count.Double())
+		})
+	}
+}
+
+func Test_adjustedCount(t *testing.T) {
+	assert.Equal(t, float64(1), adjustedCount(numHashBuckets))
+	assert.Equal(t, float64(1), adjustedCount(2*numHashBuckets))
+	assert.Equal(t, float64(4), adjustedCount(uint32(25*percentageScaleFactor)))
+	assert.InDelta(t, float64(10), adjustedCount(1638), 0.01)
+}
+
 // Test_parseSpanSamplingPriority ensures that the function parsing the attributes is taking "sampling.priority"
 // attribute correctly.
 func Test_parseSpanSamplingPriority(t *testing.T) {
@@ -3,6 +3,43 @@
 
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// Action defines how a value matching a rule is redacted.
+type Action string
+
+const (
+	// ActionMask replaces the value with a fixed number of asterisks.
+	ActionMask Action = "mask"
+	// ActionHash replaces the value with its hex encoded HMAC-SHA256 digest.
+	ActionHash Action = "hash"
+	// ActionTruncate keeps only the first Length characters of the value.
+	ActionTruncate Action = "truncate"
+	// ActionPartialMask replaces all but the last Length characters of the value with asterisks.
+	ActionPartialMask Action = "partial_mask"
+)
+
+// Detector is a built-in detector of well-known sensitive values.
+type Detector string
+
+const (
+	// DetectorCreditCard detects credit card numbers passing the Luhn checksum.
+	DetectorCreditCard Detector = "credit_card"
+	// DetectorEmail detects email addresses.
+	DetectorEmail Detector = "email"
+	// DetectorIPv4 detects IPv4 addresses.
+	DetectorIPv4 Detector = "ipv4"
+	// DetectorIPv6 detects IPv6 addresses.
+	DetectorIPv6 Detector = "ipv6"
+	// DetectorJWT detects JSON Web Tokens.
+	DetectorJWT Detector = "jwt"
+)
+
 type Config struct {
 
 	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
@@ -24,6 +61,11 @@ type Config struct {
 	// allowed span attributes. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// Rules is a list of rules for redacting values of allowed span
+	// attributes, each with its own redaction action. Rules are applied
+	// after BlockedValues.
+	Rules []RuleConfig `mapstructure:"rules"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -31,3 +73,67 @@ type Config struct {
 	// configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+// RuleConfig defines which values to redact and how to redact them.
+type RuleConfig struct {
+	// Pattern is a regular expression matching the values to redact.
+	// Exactly one of Pattern and Detector must be set.
+	Pattern string `mapstructure:"pattern"`
+
+	// Detector is the name of a built-in detector matching the values to
+	// redact. Possible values are `credit_card`, `email`, `ipv4`, `ipv6`
+	// and `jwt`.
+	Detector Detector `mapstructure:"detector"`
+
+	// Action defines how matching values are redacted. Possible values are
+	// `mask`, `hash`, `truncate` and `partial_mask`. Defaults to `mask`.
+	Action Action `mapstructure:"action"`
+
+	// HashKey is the key used to compute the HMAC-SHA256 digest of matching
+	// values with the `hash` action.
+	HashKey configopaque.String `mapstructure:"hash_key"`
+
+	// Length is the number of leading characters kept by the `truncate`
+	// action, or the number of trailing characters left unmasked by the
+	// `partial_mask` action.
+	Length int `mapstructure:"length"`
+}
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	for i, rule := range cfg.Rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *RuleConfig) validate() error {
+	if (r.Pattern == "") == (r.Detector == "") {
+		return errors.New("exactly one of pattern or detector must be set")
+	}
+	if r.Detector != "" {
+		if _, ok := detectors[r.Detector]; !ok {
+			return fmt.Errorf("unknown detector %q", r.Detector)
+		}
+	}
+	switch r.Action {
+	case "", ActionMask:
+	case ActionHash:
+		if r.HashKey == "" {
+			return errors.New("hash_key must be set for the hash action")
+		}
+	case ActionTruncate:
+		if r.Length < 1 {
+			return fmt.Errorf("length must be positive for the truncate action: %d", r.Length)
+		}
+	case ActionPartialMask:
+		if r.Length < 0 {
+			return fmt.Errorf("negative length: %d", r.Length)
+		}
+	default:
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	return nil
+}
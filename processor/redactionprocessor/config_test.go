@@ -29,7 +29,12 @@ func TestLoadConfig(t *testing.T) {
 				AllowedKeys:   []string{"description", "group", "id", "name"},
 				IgnoredKeys:   []string{"safe_attribute"},
 				BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?", "(5[1-5][0-9]{14})"},
-				Summary:       debug,
+				Rules: []RuleConfig{
+					{Detector: DetectorEmail, Action: ActionHash, HashKey: "my-secret-key"},
+					{Detector: DetectorCreditCard, Action: ActionPartialMask, Length: 4},
+					{Pattern: "sess-[a-f0-9]{16}"},
+				},
+				Summary: debug,
 			},
 		},
 		{
@@ -6,11 +6,12 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"sort"
 	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -22,8 +23,8 @@ type redaction struct {
 	allowList map[string]string
 	// Attribute keys ignored in a span
 	ignoreList map[string]string
-	// Attribute values blocked in a span
-	blockRegexList map[string]*regexp.Regexp
+	// Rules for redacting attribute values and log bodies
+	valueRules []*valueRule
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -31,21 +32,21 @@ type redaction struct {
 }
 
 // newRedaction creates a new instance of the redaction processor
-func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
+func newRedaction(_ context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
 	allowList := makeAllowList(config)
 	ignoreList := makeIgnoreList(config)
-	blockRegexList, err := makeBlockRegexList(ctx, config)
+	valueRules, err := makeValueRules(config)
 	if err != nil {
 		// TODO: Placeholder for an error metric in the next PR
 		return nil, fmt.Errorf("failed to process block list: %w", err)
 	}
 
 	return &redaction{
-		allowList:      allowList,
-		ignoreList:     ignoreList,
-		blockRegexList: blockRegexList,
-		config:         config,
-		logger:         logger,
+		allowList:  allowList,
+		ignoreList: ignoreList,
+		valueRules: valueRules,
+		config:     config,
+		logger:     logger,
 	}, nil
 }
 
@@ -79,12 +80,97 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 	}
 }
 
-// processAttrs redacts the attributes of a resource span or a span
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.processResourceLog(ctx, rl)
+	}
+	return logs, nil
+}
+
+// processResourceLog processes the RL and all of its log records
+func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs) {
+	// Attributes can be part of a resource log
+	s.processAttrs(ctx, rl.Resource().Attributes())
+
+	for j := 0; j < rl.ScopeLogs().Len(); j++ {
+		sl := rl.ScopeLogs().At(j)
+		for k := 0; k < sl.LogRecords().Len(); k++ {
+			log := sl.LogRecords().At(k)
+
+			// Attributes and the body can also be part of a log record
+			s.processAttrs(ctx, log.Attributes())
+			s.processLogBody(ctx, log.Body(), log.Attributes())
+		}
+	}
+}
+
+// processMetrics implements ProcessMetricsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processMetrics(ctx context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		s.processResourceMetric(ctx, rm)
+	}
+	return metrics, nil
+}
+
+// processResourceMetric processes the RM and the data points of all of its metrics
+func (s *redaction) processResourceMetric(ctx context.Context, rm pmetric.ResourceMetrics) {
+	// Attributes can be part of a resource metric
+	s.processAttrs(ctx, rm.Resource().Attributes())
+
+	for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+		sm := rm.ScopeMetrics().At(j)
+		for k := 0; k < sm.Metrics().Len(); k++ {
+			metric := sm.Metrics().At(k)
+
+			// Attributes can also be part of a data point
+			switch metric.Type() {
+			case pmetric.MetricTypeGauge:
+				dps := metric.Gauge().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeSum:
+				dps := metric.Sum().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeHistogram:
+				dps := metric.Histogram().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeExponentialHistogram:
+				dps := metric.ExponentialHistogram().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeSummary:
+				dps := metric.Summary().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			}
+		}
+	}
+}
+
+// redactionSummary collects the names of the attributes or body fields that
+// were removed, masked or ignored
+type redactionSummary struct {
+	redacted []string
+	masked   []string
+	ignored  []string
+}
+
+// processAttrs redacts the attributes of a resource, a span, a log record or a data point
 func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	// TODO: Use the context for recording metrics
-	var toDelete []string
-	var toBlock []string
-	var ignoring []string
+	var summary redactionSummary
 
 	// Identify attributes to redact and mask in the following sequence
 	// 1. Make a list of attribute keys to redact
@@ -94,10 +180,41 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	// This sequence satisfies these performance constraints:
 	// - Only range through all attributes once
 	// - Don't mask any values if the whole attribute is slated for deletion
-	attributes.Range(func(k string, value pcommon.Value) bool {
+	s.redactMap(attributes, "", false, &summary)
+
+	// Add diagnostic information to the attributes
+	s.addMetaAttrs(summary.redacted, attributes, redactedKeys, redactedKeyCount)
+	s.addMetaAttrs(summary.masked, attributes, maskedValues, maskedValueCount)
+	s.addMetaAttrs(summary.ignored, attributes, "", ignoredKeyCount)
+}
+
+// processLogBody redacts the body of a log record. String bodies are masked
+// with the blocked values, while map bodies are walked recursively applying the
+// same rules as for attributes. The summary is added to the log record attributes,
+// naming the body fields by their path, e.g. `body.user.email`.
+func (s *redaction) processLogBody(_ context.Context, body pcommon.Value, attributes pcommon.Map) {
+	var summary redactionSummary
+	s.redactValue(body, "body", &summary)
+
+	s.addMetaAttrs(summary.redacted, attributes, redactedKeys, redactedKeyCount)
+	s.addMetaAttrs(summary.masked, attributes, maskedValues, maskedValueCount)
+	s.addMetaAttrs(summary.ignored, attributes, "", ignoredKeyCount)
+}
+
+// redactMap removes the keys of the map that are not allowed and masks blocked
+// values of the remaining ones. Nested maps and slices are only walked if
+// recursive is set. The names of the changed keys are prefixed with path.
+func (s *redaction) redactMap(m pcommon.Map, path string, recursive bool, summary *redactionSummary) {
+	var toDelete []string
+	m.Range(func(k string, value pcommon.Value) bool {
+		name := k
+		if path != "" {
+			name = path + "." + k
+		}
+
 		// don't delete or redact the attribute if it should be ignored
 		if _, ignored := s.ignoreList[k]; ignored {
-			ignoring = append(ignoring, k)
+			summary.ignored = append(summary.ignored, name)
 			// Skip to the next attribute
 			return true
 		}
@@ -106,33 +223,62 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 		if !s.config.AllowAllKeys {
 			if _, allowed := s.allowList[k]; !allowed {
 				toDelete = append(toDelete, k)
+				summary.redacted = append(summary.redacted, name)
 				// Skip to the next attribute
 				return true
 			}
 		}
 
+		if recursive {
+			s.redactValue(value, name, summary)
+			return true
+		}
+
 		// Mask any blocked values for the other attributes
-		strVal := value.Str()
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(strVal)
-			if match {
-				toBlock = append(toBlock, k)
-
-				maskedValue := compiledRE.ReplaceAllString(strVal, "****")
-				value.SetStr(maskedValue)
-			}
+		if s.maskValue(value) {
+			summary.masked = append(summary.masked, name)
 		}
 		return true
 	})
 
 	// Delete the attributes on the redaction list
 	for _, k := range toDelete {
-		attributes.Remove(k)
+		m.Remove(k)
 	}
-	// Add diagnostic information to the span
-	s.addMetaAttrs(toDelete, attributes, redactedKeys, redactedKeyCount)
-	s.addMetaAttrs(toBlock, attributes, maskedValues, maskedValueCount)
-	s.addMetaAttrs(ignoring, attributes, "", ignoredKeyCount)
+}
+
+// redactValue masks blocked string values and walks maps and slices recursively
+func (s *redaction) redactValue(value pcommon.Value, path string, summary *redactionSummary) {
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		if s.maskValue(value) {
+			summary.masked = append(summary.masked, path)
+		}
+	case pcommon.ValueTypeMap:
+		s.redactMap(value.Map(), path, true, summary)
+	case pcommon.ValueTypeSlice:
+		values := value.Slice()
+		for i := 0; i < values.Len(); i++ {
+			s.redactValue(values.At(i), path, summary)
+		}
+	}
+}
+
+// maskValue redacts the parts of the value that match any of the blocked
+// values or rules and reports whether the value was changed
+func (s *redaction) maskValue(value pcommon.Value) bool {
+	strVal := value.Str()
+	masked := false
+	for _, rule := range s.valueRules {
+		var replaced bool
+		if strVal, replaced = rule.apply(strVal); replaced {
+			masked = true
+		}
+	}
+	if masked {
+		value.SetStr(strVal)
+	}
+	return masked
 }
 
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
@@ -201,17 +347,3 @@ func makeIgnoreList(c *Config) map[string]string {
 	}
 	return ignoreList
 }
-
-// makeBlockRegexList precompiles all the blocked regex patterns
-func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
-	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
-	for _, pattern := range config.BlockedValues {
-		re, err := regexp.Compile(pattern)
-		if err != nil {
-			// TODO: Placeholder for an error metric in the next PR
-			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
-		}
-		blockRegexList[pattern] = re
-	}
-	return blockRegexList, nil
-}
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
 )
@@ -328,6 +330,34 @@ func TestMultipleBlockValues(t *testing.T) {
 	assert.Equal(t, "mystery ****", mysteryValue.Str())
 }
 
+// TestRedactRules validates that the processor applies the action of each
+// rule after masking the blocked values
+func TestRedactRules(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"secret"},
+		Rules: []RuleConfig{
+			{Detector: DetectorEmail, Action: ActionHash, HashKey: "key"},
+			{Detector: DetectorCreditCard, Action: ActionPartialMask, Length: 4},
+		},
+		Summary: "debug",
+	}
+	masked := map[string]pcommon.Value{
+		"user":  pcommon.NewValueStr("john@example.com"),
+		"notes": pcommon.NewValueStr("secret card 4111111111111111"),
+	}
+
+	outTraces := runTest(t, nil, nil, masked, nil, config)
+
+	attr := outTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	value, _ := attr.Get("user")
+	assert.Equal(t, hmacSHA256([]byte("key"))("john@example.com"), value.Str())
+	value, _ = attr.Get("notes")
+	assert.Equal(t, "**** card ************1111", value.Str())
+	value, _ = attr.Get(maskedValues)
+	assert.Equal(t, "notes,user", value.Str())
+}
+
 // TestProcessAttrsAppliedTwice validates a use case when data is coming through redaction processor more than once.
 // Existing attributes must be updated, not overridden or ignored.
 func TestProcessAttrsAppliedTwice(t *testing.T) {
@@ -367,6 +397,134 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 	assert.Equal(t, int64(2), val.Int())
 }
 
+// TestRedactLogs validates that the processor redacts resource attributes,
+// log record attributes, string bodies and map bodies
+func TestRedactLogs(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id", "user", "notes", "tags"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		IgnoredKeys:   []string{"safe_attribute"},
+		Summary:       "debug",
+	}
+	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("id", "4111111111111111")
+	rl.Resource().Attributes().PutStr("host.name", "localhost")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	strBody := records.AppendEmpty()
+	strBody.Body().SetStr("payment with 4111111111111111 accepted")
+	strBody.Attributes().PutStr("credit_card", "4111111111111111")
+
+	mapBody := records.AppendEmpty()
+	assert.NoError(t, mapBody.Body().SetEmptyMap().FromRaw(map[string]interface{}{
+		"id":             5,
+		"password":       "secret",
+		"safe_attribute": "4111111111111112",
+		"user": map[string]interface{}{
+			"notes":   "card 4111111111111111",
+			"address": "somewhere",
+		},
+		"tags": []interface{}{"4111111111111111", "other"},
+	}))
+
+	outLogs, err := processor.processLogs(context.TODO(), logs)
+	require.NoError(t, err)
+
+	resourceAttrs := outLogs.ResourceLogs().At(0).Resource().Attributes()
+	val, ok := resourceAttrs.Get("id")
+	assert.True(t, ok)
+	assert.Equal(t, "****", val.Str())
+	_, ok = resourceAttrs.Get("host.name")
+	assert.False(t, ok)
+
+	outRecords := outLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	strRecord := outRecords.At(0)
+	assert.Equal(t, "payment with **** accepted", strRecord.Body().Str())
+	_, ok = strRecord.Attributes().Get("credit_card")
+	assert.False(t, ok)
+	val, ok = strRecord.Attributes().Get(maskedValues)
+	assert.True(t, ok)
+	assert.Equal(t, "body", val.Str())
+	val, ok = strRecord.Attributes().Get(redactedKeys)
+	assert.True(t, ok)
+	assert.Equal(t, "credit_card", val.Str())
+
+	mapRecord := outRecords.At(1)
+	assert.Equal(t, map[string]interface{}{
+		"id":             int64(5),
+		"safe_attribute": "4111111111111112",
+		"user": map[string]interface{}{
+			"notes": "card ****",
+		},
+		"tags": []interface{}{"****", "other"},
+	}, mapRecord.Body().Map().AsRaw())
+	val, ok = mapRecord.Attributes().Get(redactedKeys)
+	assert.True(t, ok)
+	assert.Equal(t, "body.password,body.user.address", val.Str())
+	val, ok = mapRecord.Attributes().Get(maskedValues)
+	assert.True(t, ok)
+	assert.Equal(t, "body.tags,body.user.notes", val.Str())
+	val, ok = mapRecord.Attributes().Get(ignoredKeyCount)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), val.Int())
+}
+
+// TestRedactMetrics validates that the processor redacts resource attributes
+// and the data point attributes of all metric types
+func TestRedactMetrics(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id", "name"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       "info",
+	}
+	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("name", "placeholder 4111111111111111")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	fillAttrs := func(attrs pcommon.Map) {
+		attrs.PutInt("id", 5)
+		attrs.PutStr("name", "placeholder 4111111111111111")
+		attrs.PutStr("credit_card", "4111111111111111")
+	}
+	fillAttrs(ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().Attributes())
+	fillAttrs(ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes())
+	fillAttrs(ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes())
+	fillAttrs(ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes())
+	fillAttrs(ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().Attributes())
+
+	outMetrics, err := processor.processMetrics(context.TODO(), metrics)
+	require.NoError(t, err)
+
+	resourceAttrs := outMetrics.ResourceMetrics().At(0).Resource().Attributes()
+	val, ok := resourceAttrs.Get("name")
+	assert.True(t, ok)
+	assert.Equal(t, "placeholder ****", val.Str())
+
+	outMs := outMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	dpAttrs := []pcommon.Map{
+		outMs.At(0).Gauge().DataPoints().At(0).Attributes(),
+		outMs.At(1).Sum().DataPoints().At(0).Attributes(),
+		outMs.At(2).Histogram().DataPoints().At(0).Attributes(),
+		outMs.At(3).ExponentialHistogram().DataPoints().At(0).Attributes(),
+		outMs.At(4).Summary().DataPoints().At(0).Attributes(),
+	}
+	for _, attrs := range dpAttrs {
+		assert.Equal(t, map[string]interface{}{
+			"id":             int64(5),
+			"name":           "placeholder ****",
+			redactedKeyCount: int64(1),
+			maskedValueCount: int64(1),
+		}, attrs.AsRaw())
+	}
+}
+
 // runTest transforms the test input data and passes it through the processor
 func runTest(
 	t *testing.T,
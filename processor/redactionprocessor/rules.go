@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+const defaultMask = "****"
+
+// valueRule finds values to redact in attribute values and log bodies, and
+// replaces them according to its action
+type valueRule struct {
+	// name identifies the rule in error messages
+	name string
+	// re finds candidate values
+	re *regexp.Regexp
+	// validate optionally filters out false positives among the candidates
+	validate func(string) bool
+	// isBoundary optionally requires the characters around a candidate to be
+	// boundaries, as the regular expressions don't support lookarounds
+	isBoundary func(byte) bool
+	// redact computes the replacement of a matching value
+	redact func(string) string
+}
+
+// apply redacts all the matches of the rule in value. It returns the redacted
+// value and reports whether anything was changed.
+func (r *valueRule) apply(value string) (string, bool) {
+	if !r.re.MatchString(value) {
+		return value, false
+	}
+	replaced := false
+	var redacted strings.Builder
+	last := 0
+	for _, loc := range r.re.FindAllStringIndex(value, -1) {
+		match := value[loc[0]:loc[1]]
+		if !r.isBounded(value, loc[0], loc[1]) || (r.validate != nil && !r.validate(match)) {
+			continue
+		}
+		replacement := r.redact(match)
+		if replacement == match {
+			continue
+		}
+		replaced = true
+		redacted.WriteString(value[last:loc[0]])
+		redacted.WriteString(replacement)
+		last = loc[1]
+	}
+	if !replaced {
+		return value, false
+	}
+	redacted.WriteString(value[last:])
+	return redacted.String(), true
+}
+
+// isBounded reports whether the match at value[start:end] is surrounded by
+// boundaries, or by the start and end of the value.
+func (r *valueRule) isBounded(value string, start, end int) bool {
+	if r.isBoundary == nil {
+		return true
+	}
+	return (start == 0 || r.isBoundary(value[start-1])) && (end == len(value) || r.isBoundary(value[end]))
+}
+
+// makeValueRules compiles the blocked values and the configured rules. The
+// blocked values are always masked with a fixed number of asterisks.
+func makeValueRules(config *Config) ([]*valueRule, error) {
+	rules := make([]*valueRule, 0, len(config.BlockedValues)+len(config.Rules))
+	for _, pattern := range config.BlockedValues {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
+		}
+		rules = append(rules, &valueRule{name: pattern, re: re, redact: maskAll})
+	}
+	for i, cfg := range config.Rules {
+		rule, err := newValueRule(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func newValueRule(cfg RuleConfig) (*valueRule, error) {
+	rule := &valueRule{name: cfg.Pattern}
+	if cfg.Detector != "" {
+		d := detectors[cfg.Detector]
+		rule.name = string(cfg.Detector)
+		rule.re = d.re
+		rule.validate = d.validate
+		rule.isBoundary = d.isBoundary
+	} else {
+		re, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling regex: %w", err)
+		}
+		rule.re = re
+	}
+
+	switch cfg.Action {
+	case ActionHash:
+		rule.redact = hmacSHA256([]byte(cfg.HashKey))
+	case ActionTruncate:
+		rule.redact = truncate(cfg.Length)
+	case ActionPartialMask:
+		rule.redact = maskAllButLast(cfg.Length)
+	default:
+		rule.redact = maskAll
+	}
+	return rule, nil
+}
+
+// maskAll replaces the value with a fixed number of asterisks, so that the
+// length of the original value isn't leaked
+func maskAll(string) string {
+	return defaultMask
+}
+
+// hmacSHA256 replaces the value with its hex encoded HMAC-SHA256 digest, so
+// that the same value can still be correlated across records
+func hmacSHA256(key []byte) func(string) string {
+	return func(value string) string {
+		mac := hmac.New(sha256.New, key)
+		// the implementation of hash.Hash.Write() never returns an error
+		_, _ = mac.Write([]byte(value))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+}
+
+// truncate keeps only the first length characters of the value
+func truncate(length int) func(string) string {
+	return func(value string) string {
+		runes := []rune(value)
+		if len(runes) <= length {
+			return value
+		}
+		return string(runes[:length])
+	}
+}
+
+// maskAllButLast replaces every character of the value with an asterisk
+// except the last length ones, preserving the length of the value
+func maskAllButLast(length int) func(string) string {
+	return func(value string) string {
+		runes := []rune(value)
+		masked := len(runes) - length
+		if masked <= 0 {
+			return value
+		}
+		return strings.Repeat("*", masked) + string(runes[masked:])
+	}
+}
+
+type detector struct {
+	re         *regexp.Regexp
+	validate   func(string) bool
+	isBoundary func(byte) bool
+}
+
+// detectors are the built-in detectors of well-known sensitive values
+var detectors = map[Detector]detector{
+	DetectorCreditCard: {
+		re:       regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		validate: isLuhnValid,
+	},
+	DetectorEmail: {
+		re: regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+	},
+	DetectorIPv4: {
+		re:       regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`),
+		validate: isIPv4,
+	},
+	DetectorIPv6: {
+		re:       regexp.MustCompile(`(?:[0-9a-fA-F]{0,4}:){2,7}(?:(?:\d{1,3}\.){3}\d{1,3}|[0-9a-fA-F]{0,4})`),
+		validate: isIPv6,
+		// Scoped names such as std::vector or core::fmt contain valid
+		// addresses like d:: or e::f once taken out of their word.
+		isBoundary: func(c byte) bool {
+			return c != ':' && c != '_' && !('0' <= c && c <= '9') && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z')
+		},
+	},
+	DetectorJWT: {
+		re: regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`),
+	},
+}
+
+// isLuhnValid reports whether the digits of the value pass the Luhn checksum
+// used by credit card numbers. Spaces and dashes are ignored.
+func isLuhnValid(value string) bool {
+	sum := 0
+	digits := 0
+	for i := len(value) - 1; i >= 0; i-- {
+		c := value[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits >= 13 && sum%10 == 0
+}
+
+func isIPv4(value string) bool {
+	ip := net.ParseIP(value)
+	return ip != nil && ip.To4() != nil
+}
+
+func isIPv6(value string) bool {
+	ip := net.ParseIP(value)
+	return ip != nil && strings.Contains(value, ":")
+}
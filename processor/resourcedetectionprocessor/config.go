@@ -4,6 +4,9 @@
 package resourcedetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
@@ -16,8 +19,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/file"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
@@ -31,6 +36,10 @@ type Config struct {
 	// Override indicates whether any existing resource attributes
 	// should be overridden or preserved. Defaults to true.
 	Override bool `mapstructure:"override"`
+	// RefreshInterval is the interval at which the detectors are re-run in the background
+	// to pick up changes of the detected resource. Set to 0 (default) to only detect once at startup.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
 	// DetectorConfig is a list of settings specific to all detectors
 	DetectorConfig DetectorConfig `mapstructure:",squash"`
 	// HTTP client settings for the detector
@@ -42,6 +51,14 @@ type Config struct {
 	Attributes []string `mapstructure:"attributes"`
 }
 
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.RefreshInterval < 0 {
+		return errors.New("refresh_interval must not be negative")
+	}
+	return nil
+}
+
 // DetectorConfig contains user-specified configurations unique to all individual detectors
 type DetectorConfig struct {
 	// EC2Config contains user-specified configurations for the EC2 detector
@@ -77,6 +94,12 @@ type DetectorConfig struct {
 	// HerokuConfig contains user-specified configurations for the heroku detector
 	HerokuConfig heroku.Config `mapstructure:"heroku"`
 
+	// FileConfig contains user-specified configurations for the file detector
+	FileConfig file.Config `mapstructure:"file"`
+
+	// K8sNodeConfig contains user-specified configurations for the k8snode detector
+	K8sNodeConfig k8snode.Config `mapstructure:"k8snode"`
+
 	// SystemConfig contains user-specified configurations for the System detector
 	SystemConfig system.Config `mapstructure:"system"`
 
@@ -97,6 +120,8 @@ func detectorCreateDefaultConfig() DetectorConfig {
 		DockerConfig:           docker.CreateDefaultConfig(),
 		GcpConfig:              gcp.CreateDefaultConfig(),
 		HerokuConfig:           heroku.CreateDefaultConfig(),
+		FileConfig:             file.CreateDefaultConfig(),
+		K8sNodeConfig:          k8snode.CreateDefaultConfig(),
 		SystemConfig:           system.CreateDefaultConfig(),
 		OpenShiftConfig:        openshift.CreateDefaultConfig(),
 	}
@@ -124,6 +149,10 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.GcpConfig
 	case heroku.TypeStr:
 		return d.HerokuConfig
+	case file.TypeStr:
+		return d.FileConfig
+	case k8snode.TypeStr:
+		return d.K8sNodeConfig
 	case system.TypeStr:
 		return d.SystemConfig
 	case openshift.TypeStr:
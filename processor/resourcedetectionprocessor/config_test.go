@@ -15,9 +15,11 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/lambda"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/file"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
@@ -51,6 +53,19 @@ func TestLoadConfig(t *testing.T) {
 		ResourceAttributes: system.CreateDefaultConfig().ResourceAttributes,
 	}
 
+	fileK8sNodeConfig := detectorCreateDefaultConfig()
+	fileK8sNodeConfig.FileConfig = file.Config{
+		Path:   "/etc/otel/resource.properties",
+		Format: "keyvalue",
+		ResourceAttributes: map[string]file.ResourceAttributeConfig{
+			"secret.token": {Enabled: false},
+		},
+	}
+	fileK8sNodeConfig.K8sNodeConfig.AuthType = k8sconfig.AuthTypeKubeConfig
+	fileK8sNodeConfig.K8sNodeConfig.LabelPrefixes = []string{"topology.kubernetes.io/"}
+	fileK8sNodeConfig.K8sNodeConfig.AnnotationPrefixes = []string{"example.com/"}
+	fileK8sNodeConfig.K8sNodeConfig.ResourceAttributes.K8sNodeUID.Enabled = false
+
 	resourceAttributesConfig := detectorCreateDefaultConfig()
 	ec2ResourceAttributesConfig := ec2.CreateDefaultConfig()
 	ec2ResourceAttributesConfig.ResourceAttributes.HostName.Enabled = false
@@ -112,6 +127,15 @@ func TestLoadConfig(t *testing.T) {
 				DetectorConfig:     detectorCreateDefaultConfig(),
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "file_k8snode"),
+			expected: &Config{
+				Detectors:          []string{"file", "k8snode"},
+				HTTPClientSettings: cfg,
+				Override:           false,
+				DetectorConfig:     fileK8sNodeConfig,
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "lambda"),
 			expected: &Config{
@@ -130,6 +154,20 @@ func TestLoadConfig(t *testing.T) {
 				DetectorConfig:     resourceAttributesConfig,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "refresh"),
+			expected: &Config{
+				Detectors:          []string{"env", "consul"},
+				HTTPClientSettings: cfg,
+				Override:           false,
+				RefreshInterval:    5 * time.Minute,
+				DetectorConfig:     detectorCreateDefaultConfig(),
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_refresh"),
+			errorMessage: "refresh_interval must not be negative",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "invalid"),
 			errorMessage: "hostname_sources contains invalid value: \"invalid_source\"",
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/file"
+
+const defaultPath = "/etc/otel/resource.yaml"
+
+// Config defines user-specified configurations unique to the file detector
+type Config struct {
+	// Path is the path of the file the resource attributes are read from.
+	Path string `mapstructure:"path"`
+
+	// Format is the format of the file, either "yaml" or "keyvalue".
+	// If empty, files with a .yaml or .yml extension are read as YAML and all others as key/value pairs.
+	Format string `mapstructure:"format"`
+
+	// Watch enables re-detecting the resource when the file changes. Defaults to true.
+	Watch bool `mapstructure:"watch"`
+
+	// ResourceAttributes allows disabling attributes read from the file.
+	// As the attributes depend on the file contents, all of them are enabled by default.
+	ResourceAttributes map[string]ResourceAttributeConfig `mapstructure:"resource_attributes"`
+}
+
+// ResourceAttributeConfig provides config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		Path:  defaultPath,
+		Watch: true,
+	}
+}
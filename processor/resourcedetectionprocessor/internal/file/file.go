@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package file provides a detector that loads resource information from a file,
+// either as YAML or as `<key>=<value>` lines.
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/file"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "file"
+
+	formatYAML     = "yaml"
+	formatKeyValue = "keyvalue"
+)
+
+var _ internal.Detector = (*detector)(nil)
+var _ internal.Watcher = (*watchingDetector)(nil)
+
+type detector struct {
+	logger             *zap.Logger
+	path               string
+	format             string
+	resourceAttributes map[string]ResourceAttributeConfig
+}
+
+// watchingDetector is a detector that also reports changes of the file.
+type watchingDetector struct {
+	*detector
+}
+
+// NewDetector returns a detector which reads resource attributes from a file.
+func NewDetector(set processor.CreateSettings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	if cfg.Path == "" {
+		return nil, errors.New("path must be set")
+	}
+
+	format := cfg.Format
+	if format == "" {
+		format = formatKeyValue
+		if ext := filepath.Ext(cfg.Path); ext == ".yaml" || ext == ".yml" {
+			format = formatYAML
+		}
+	}
+	if format != formatYAML && format != formatKeyValue {
+		return nil, fmt.Errorf("invalid format %q, must be %q or %q", cfg.Format, formatYAML, formatKeyValue)
+	}
+
+	d := &detector{
+		logger:             set.Logger,
+		path:               filepath.Clean(cfg.Path),
+		format:             format,
+		resourceAttributes: cfg.ResourceAttributes,
+	}
+	if cfg.Watch {
+		return &watchingDetector{detector: d}, nil
+	}
+	return d, nil
+}
+
+// Detect reads the file and returns a resource with its attributes. A missing file
+// results in an empty resource, as the file may be created later.
+func (d *detector) Detect(context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	res := pcommon.NewResource()
+	content, err := os.ReadFile(d.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		d.logger.Debug("resource file does not exist", zap.String("path", d.path))
+		return res, "", nil
+	}
+	if err != nil {
+		return res, "", fmt.Errorf("failed to read resource file: %w", err)
+	}
+
+	attrs := res.Attributes()
+	switch d.format {
+	case formatYAML:
+		err = parseYAML(attrs, content)
+	default:
+		err = parseKeyValue(attrs, content)
+	}
+	if err != nil {
+		attrs.Clear()
+		return res, "", fmt.Errorf("failed to parse resource file %q: %w", d.path, err)
+	}
+
+	attrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+		cfg, ok := d.resourceAttributes[k]
+		return ok && !cfg.Enabled
+	})
+	return res, "", nil
+}
+
+// Watch watches the directory of the file, so that files replaced by a rename are
+// also picked up. The file is re-stated on every event of the directory, as the
+// files mounted from a Kubernetes ConfigMap are symbolic links through a `..data`
+// symbolic link that is swapped on update, without any event for the file itself.
+func (d *watchingDetector) Watch(ctx context.Context, notify func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(filepath.Dir(d.path)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	last, _ := os.Stat(d.path)
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) == d.path || targetChanged(d.path, &last) {
+					notify()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				d.logger.Warn("error watching resource file", zap.String("path", d.path), zap.Error(err))
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// targetChanged reports whether the file the path resolves to is not the one
+// described by last anymore, and updates last.
+func targetChanged(path string, last *os.FileInfo) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		changed := *last != nil
+		*last = nil
+		return changed
+	}
+	changed := *last == nil || !os.SameFile(*last, info) ||
+		!info.ModTime().Equal((*last).ModTime()) || info.Size() != (*last).Size()
+	*last = info
+	return changed
+}
+
+// parseYAML reads a YAML mapping. Nested mappings are flattened using dots,
+// so `host: {name: a}` results in the `host.name` attribute.
+func parseYAML(attrs pcommon.Map, content []byte) error {
+	var values map[string]any
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return err
+	}
+	return putFlattened(attrs, "", values)
+}
+
+func putFlattened(attrs pcommon.Map, prefix string, values map[string]any) error {
+	for k, v := range values {
+		key := prefix + k
+		if nested, ok := v.(map[string]any); ok {
+			if err := putFlattened(attrs, key+".", nested); err != nil {
+				return err
+			}
+			continue
+		}
+		if v == nil {
+			continue
+		}
+		if err := attrs.PutEmpty(key).FromRaw(v); err != nil {
+			return fmt.Errorf("attribute %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// parseKeyValue reads `<key>=<value>` lines. Empty lines and lines starting with '#' are ignored.
+func parseKeyValue(attrs pcommon.Map, content []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("invalid line %d: %q", line, text)
+		}
+		attrs.PutStr(key, strings.TrimSpace(value))
+	}
+	return scanner.Err()
+}
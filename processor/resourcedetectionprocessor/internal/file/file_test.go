@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+func newDetector(t *testing.T, cfg Config) internal.Detector {
+	d, err := NewDetector(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	return d
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopCreateSettings(), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.Implements(t, (*internal.Watcher)(nil), d)
+
+	cfg := CreateDefaultConfig()
+	cfg.Watch = false
+	d, err = NewDetector(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	_, ok := d.(internal.Watcher)
+	assert.False(t, ok)
+
+	cfg.Path = ""
+	_, err = NewDetector(processortest.NewNopCreateSettings(), cfg)
+	assert.EqualError(t, err, "path must be set")
+
+	cfg.Path = "resource.txt"
+	cfg.Format = "json"
+	_, err = NewDetector(processortest.NewNopCreateSettings(), cfg)
+	assert.EqualError(t, err, `invalid format "json", must be "yaml" or "keyvalue"`)
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		format   string
+		disabled []string
+		expected map[string]any
+		err      string
+	}{
+		{
+			name: "yaml",
+			file: "resource.yaml",
+			content: `
+deployment.environment: production
+host:
+  name: db-1
+  rack: 12
+  virtual: false
+datacenter.zones: [a, b]
+`,
+			expected: map[string]any{
+				"deployment.environment": "production",
+				"host.name":              "db-1",
+				"host.rack":              int64(12),
+				"host.virtual":           false,
+				"datacenter.zones":       []any{"a", "b"},
+			},
+		},
+		{
+			name: "key value",
+			file: "resource.properties",
+			content: `
+# on-prem metadata
+deployment.environment = production
+host.name=db-1
+empty=
+`,
+			expected: map[string]any{
+				"deployment.environment": "production",
+				"host.name":              "db-1",
+				"empty":                  "",
+			},
+		},
+		{
+			name:     "key value with explicit format",
+			file:     "resource.yaml",
+			format:   "keyvalue",
+			content:  "host.name=db-1\n",
+			expected: map[string]any{"host.name": "db-1"},
+		},
+		{
+			name:     "disabled attributes",
+			file:     "resource",
+			content:  "host.name=db-1\nhost.rack=12\n",
+			disabled: []string{"host.rack"},
+			expected: map[string]any{"host.name": "db-1"},
+		},
+		{
+			name:    "invalid key value",
+			file:    "resource",
+			content: "host.name\n",
+			err:     `invalid line 1: "host.name"`,
+		},
+		{
+			name:    "invalid yaml",
+			file:    "resource.yml",
+			content: "[not a mapping]",
+			err:     "cannot unmarshal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			cfg := CreateDefaultConfig()
+			cfg.Path = path
+			cfg.Format = tt.format
+			cfg.ResourceAttributes = map[string]ResourceAttributeConfig{}
+			for _, k := range tt.disabled {
+				cfg.ResourceAttributes[k] = ResourceAttributeConfig{Enabled: false}
+			}
+
+			res, schemaURL, err := newDetector(t, cfg).Detect(context.Background())
+			assert.Equal(t, "", schemaURL)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Equal(t, 0, res.Attributes().Len())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestDetectMissingFile(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Path = filepath.Join(t.TempDir(), "missing.yaml")
+	res, _, err := newDetector(t, cfg).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	cfg := CreateDefaultConfig()
+	cfg.Path = filepath.Join(dir, "resource.yaml")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notified := make(chan struct{}, 10)
+	d := newDetector(t, cfg).(internal.Watcher)
+	require.NoError(t, d.Watch(ctx, func() { notified <- struct{}{} }))
+
+	// changes to other files in the directory are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("a: b"), 0600))
+	require.NoError(t, os.WriteFile(cfg.Path, []byte("a: b"), 0600))
+
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notification")
+	}
+}
+
+func TestWatchConfigMapUpdate(t *testing.T) {
+	// Files mounted from a ConfigMap are linked through the ..data symbolic link,
+	// which is atomically swapped to a new directory on update.
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v1"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..v1", "resource.yaml"), []byte("a: b"), 0600))
+	require.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "resource.yaml"), filepath.Join(dir, "resource.yaml")))
+
+	cfg := CreateDefaultConfig()
+	cfg.Path = filepath.Join(dir, "resource.yaml")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notified := make(chan struct{}, 10)
+	d := newDetector(t, cfg)
+	require.NoError(t, d.(internal.Watcher).Watch(ctx, func() { notified <- struct{}{} }))
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v2"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..v2", "resource.yaml"), []byte("a: c"), 0600))
+	require.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+
+	select {
+	case <-notified:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notification")
+	}
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "c"}, res.Attributes().AsRaw())
+}
+
+func TestWatchMissingDirectory(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Path = filepath.Join(t.TempDir(), "missing", "resource.yaml")
+	d := newDetector(t, cfg).(internal.Watcher)
+	assert.Error(t, d.Watch(context.Background(), func() {}))
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8snode // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode/internal/metadata"
+)
+
+const defaultNodeFromEnvVar = "K8S_NODE_NAME"
+
+// Config defines user-specified configurations unique to the k8snode detector
+type Config struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
+	// NodeFromEnvVar is the name of the environment variable holding the name of the node
+	// the collector is running on, typically set using the Kubernetes downward API.
+	NodeFromEnvVar string `mapstructure:"node_from_env_var"`
+
+	// LabelPrefixes selects the node labels added as `k8s.node.label.<key>` attributes.
+	// Labels are only added if their key starts with one of the prefixes; an empty
+	// prefix selects all labels.
+	LabelPrefixes []string `mapstructure:"label_prefixes"`
+
+	// AnnotationPrefixes selects the node annotations added as `k8s.node.annotation.<key>`
+	// attributes, in the same way as LabelPrefixes.
+	AnnotationPrefixes []string `mapstructure:"annotation_prefixes"`
+
+	ResourceAttributes metadata.ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		APIConfig:          k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		NodeFromEnvVar:     defaultNodeFromEnvVar,
+		ResourceAttributes: metadata.DefaultResourceAttributesConfig(),
+	}
+}
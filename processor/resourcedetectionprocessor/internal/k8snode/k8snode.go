@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package k8snode provides a detector that loads resource information about
+// the Kubernetes node the collector is running on.
+package k8snode // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "k8snode"
+
+	labelPrefix      = "k8s.node.label."
+	annotationPrefix = "k8s.node.annotation."
+)
+
+var _ internal.Detector = (*detector)(nil)
+
+// makeClient is overridden in tests to use a fake clientset.
+var makeClient = k8sconfig.MakeClient
+
+type detector struct {
+	logger             *zap.Logger
+	client             k8s.Interface
+	nodeName           string
+	labelPrefixes      []string
+	annotationPrefixes []string
+	resourceAttributes metadata.ResourceAttributesConfig
+}
+
+// NewDetector returns a detector which can detect resource attributes of the Kubernetes node.
+func NewDetector(set processor.CreateSettings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	if err := cfg.APIConfig.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.NodeFromEnvVar == "" {
+		return nil, fmt.Errorf("node_from_env_var must be set")
+	}
+	nodeName := os.Getenv(cfg.NodeFromEnvVar)
+	if nodeName == "" {
+		return nil, fmt.Errorf("environment variable %q is not set or empty", cfg.NodeFromEnvVar)
+	}
+
+	client, err := makeClient(cfg.APIConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &detector{
+		logger:             set.Logger,
+		client:             client,
+		nodeName:           nodeName,
+		labelPrefixes:      cfg.LabelPrefixes,
+		annotationPrefixes: cfg.AnnotationPrefixes,
+		resourceAttributes: cfg.ResourceAttributes,
+	}, nil
+}
+
+// Detect returns a Resource describing the Kubernetes node the collector is running on.
+func (d *detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	res := pcommon.NewResource()
+
+	node, err := d.client.CoreV1().Nodes().Get(ctx, d.nodeName, metav1.GetOptions{})
+	if err != nil {
+		return res, "", fmt.Errorf("failed to get node %q: %w", d.nodeName, err)
+	}
+
+	attrs := res.Attributes()
+	if d.resourceAttributes.K8sNodeName.Enabled {
+		attrs.PutStr(conventions.AttributeK8SNodeName, node.Name)
+	}
+	if d.resourceAttributes.K8sNodeUID.Enabled {
+		attrs.PutStr(conventions.AttributeK8SNodeUID, string(node.UID))
+	}
+	putSelected(attrs, labelPrefix, node.Labels, d.labelPrefixes)
+	putSelected(attrs, annotationPrefix, node.Annotations, d.annotationPrefixes)
+
+	return res, conventions.SchemaURL, nil
+}
+
+// putSelected adds the values whose key starts with one of the prefixes as attributes.
+func putSelected(attrs pcommon.Map, attrPrefix string, values map[string]string, prefixes []string) {
+	for k, v := range values {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(k, prefix) {
+				attrs.PutStr(attrPrefix+k, v)
+				break
+			}
+		}
+	}
+}
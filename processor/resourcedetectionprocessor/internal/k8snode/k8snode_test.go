@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8snode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func setupFakeClient(t *testing.T, objects ...*corev1.Node) {
+	client := fake.NewSimpleClientset()
+	for _, node := range objects {
+		_, err := client.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	makeClient = func(k8sconfig.APIConfig) (k8s.Interface, error) {
+		return client, nil
+	}
+	t.Cleanup(func() { makeClient = k8sconfig.MakeClient })
+}
+
+func TestNewDetector(t *testing.T) {
+	setupFakeClient(t)
+
+	cfg := CreateDefaultConfig()
+	t.Setenv(defaultNodeFromEnvVar, "")
+	_, err := NewDetector(processortest.NewNopCreateSettings(), cfg)
+	assert.EqualError(t, err, `environment variable "K8S_NODE_NAME" is not set or empty`)
+
+	t.Setenv(defaultNodeFromEnvVar, "node-1")
+	d, err := NewDetector(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+
+	cfg.NodeFromEnvVar = ""
+	_, err = NewDetector(processortest.NewNopCreateSettings(), cfg)
+	assert.EqualError(t, err, "node_from_env_var must be set")
+
+	cfg = CreateDefaultConfig()
+	cfg.AuthType = "invalid"
+	_, err = NewDetector(processortest.NewNopCreateSettings(), cfg)
+	assert.Error(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	setupFakeClient(t, &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			UID:  "6f2c1c8e-6a1e-4b43-9c3e-2e0a2d4b1a10",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone": "us-east-1a",
+				"node.kubernetes.io/pool":     "batch",
+				"team":                        "storage",
+			},
+			Annotations: map[string]string{
+				"example.com/owner":   "infra",
+				"volumes.example.com": "true",
+			},
+		},
+	})
+	t.Setenv(defaultNodeFromEnvVar, "node-1")
+
+	cfg := CreateDefaultConfig()
+	cfg.LabelPrefixes = []string{"topology.kubernetes.io/", "team"}
+	cfg.AnnotationPrefixes = []string{"example.com/"}
+	d, err := NewDetector(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		conventions.AttributeK8SNodeName:             "node-1",
+		conventions.AttributeK8SNodeUID:              "6f2c1c8e-6a1e-4b43-9c3e-2e0a2d4b1a10",
+		"k8s.node.label.topology.kubernetes.io/zone": "us-east-1a",
+		"k8s.node.label.team":                        "storage",
+		"k8s.node.annotation.example.com/owner":      "infra",
+	}, res.Attributes().AsRaw())
+
+	cfg = CreateDefaultConfig()
+	cfg.ResourceAttributes.K8sNodeUID.Enabled = false
+	d, err = NewDetector(processortest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	res, _, err = d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{conventions.AttributeK8SNodeName: "node-1"}, res.Attributes().AsRaw())
+}
+
+func TestDetectNodeNotFound(t *testing.T) {
+	setupFakeClient(t)
+	t.Setenv(defaultNodeFromEnvVar, "node-1")
+
+	d, err := NewDetector(processortest.NewNopCreateSettings(), CreateDefaultConfig())
+	require.NoError(t, err)
+	res, _, err := d.Detect(context.Background())
+	assert.ErrorContains(t, err, `failed to get node "node-1"`)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
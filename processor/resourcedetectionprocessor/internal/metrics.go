@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/obsreport"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/metadata"
+)
+
+var (
+	registerMetricsOnce sync.Once
+	errRegisterMetrics  error
+
+	successTagKey = tag.MustNewKey("success")
+
+	mDetections        = stats.Int64("detections", "Number of resource detection runs", stats.UnitDimensionless)
+	mDetectionDuration = stats.Float64("detection_duration", "Duration of resource detection runs", stats.UnitMilliseconds)
+)
+
+// RegisterMetrics registers the views of the metrics of the resource detection once, returning
+// the registration error to every processor created afterwards.
+func RegisterMetrics() error {
+	registerMetricsOnce.Do(func() {
+		if err := view.Register(metricViews()...); err != nil {
+			errRegisterMetrics = fmt.Errorf("failed to register the metric views: %w", err)
+		}
+	})
+	return errRegisterMetrics
+}
+
+// metricViews returns the metrics views of the resource detection.
+func metricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(metadata.Type), mDetections.Name()),
+			Measure:     mDetections,
+			Description: mDetections.Description(),
+			TagKeys:     []tag.Key{successTagKey},
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(metadata.Type), mDetectionDuration.Name()),
+			Measure:     mDetectionDuration,
+			Description: mDetectionDuration.Description(),
+			TagKeys:     []tag.Key{successTagKey},
+			Aggregation: view.Distribution(1, 5, 10, 50, 100, 500, 1000, 5000, 10000),
+		},
+	}
+}
+
+// recordDetection records the outcome of a detection run. A run is successful
+// if none of the detectors failed.
+func recordDetection(success bool, duration time.Duration) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(successTagKey, strconv.FormatBool(success))},
+		mDetections.M(1),
+		mDetectionDuration.M(float64(duration)/float64(time.Millisecond)),
+	)
+}
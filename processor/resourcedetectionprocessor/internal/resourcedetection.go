@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -23,6 +24,13 @@ type Detector interface {
 	Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error)
 }
 
+// Watcher is implemented by detectors that can tell when the resource they detect
+// may have changed, e.g. because a file they read was modified.
+type Watcher interface {
+	// Watch calls notify whenever the detected resource may have changed, until ctx is done.
+	Watch(ctx context.Context, notify func()) error
+}
+
 type DetectorConfig interface{}
 
 type ResourceDetectorConfig interface {
@@ -85,9 +93,19 @@ type ResourceProvider struct {
 	logger           *zap.Logger
 	timeout          time.Duration
 	detectors        []Detector
-	detectedResource *resourceResult
+	detectedResource atomic.Pointer[resourceResult]
+	// lastDetected holds the last successful result of every detector,
+	// it is used in place of the result of a detector that fails.
+	lastDetected     []*resourceResult
 	once             sync.Once
 	attributesToKeep map[string]struct{}
+
+	refreshLock sync.Mutex
+	// refreshUsers counts the processors sharing the provider that called
+	// StartRefreshing without calling StopRefreshing yet.
+	refreshUsers int
+	stopRefresh  context.CancelFunc
+	refreshWG    sync.WaitGroup
 }
 
 type resourceResult struct {
@@ -101,38 +119,134 @@ func NewResourceProvider(logger *zap.Logger, timeout time.Duration, attributesTo
 		logger:           logger,
 		timeout:          timeout,
 		detectors:        detectors,
+		lastDetected:     make([]*resourceResult, len(detectors)),
 		attributesToKeep: attributesToKeep,
 	}
 }
 
 func (p *ResourceProvider) Get(ctx context.Context, client *http.Client) (resource pcommon.Resource, schemaURL string, err error) {
 	p.once.Do(func() {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
+		ctx, cancel := withClientTimeout(ctx, client)
 		defer cancel()
 		p.detectResource(ctx)
 	})
 
-	return p.detectedResource.resource, p.detectedResource.schemaURL, p.detectedResource.err
+	detected := p.detectedResource.Load()
+	return detected.resource, detected.schemaURL, detected.err
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context) {
-	p.detectedResource = &resourceResult{}
+// withClientTimeout bounds the detection by the timeout of the HTTP client, if it has one.
+func withClientTimeout(ctx context.Context, client *http.Client) (context.Context, context.CancelFunc) {
+	if client.Timeout > 0 {
+		return context.WithTimeout(ctx, client.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// StartRefreshing re-runs the detectors in the background until StopRefreshing is called,
+// every refreshInterval if it is positive and whenever a detector implementing Watcher
+// reports a change. The resource returned by Get is swapped once a detection completes.
+// The provider is shared by the processors of all the signals, so each call must be
+// paired with a call to StopRefreshing: only the first call starts the refresh, and
+// the refresh stops once every caller stopped it.
+func (p *ResourceProvider) StartRefreshing(refreshInterval time.Duration, client *http.Client) {
+	p.refreshLock.Lock()
+	defer p.refreshLock.Unlock()
+	p.refreshUsers++
+	if p.refreshUsers > 1 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ContextWithClient(context.Background(), client))
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+			// a refresh is already pending
+		}
+	}
+	watching := false
+	for _, detector := range p.detectors {
+		if w, ok := detector.(Watcher); ok {
+			if err := w.Watch(ctx, notify); err != nil {
+				p.logger.Warn("failed to watch for resource changes", zap.Error(err))
+				continue
+			}
+			watching = true
+		}
+	}
 
+	if refreshInterval <= 0 && !watching {
+		cancel()
+		return
+	}
+
+	p.stopRefresh = cancel
+	p.refreshWG.Add(1)
+	go func() {
+		defer p.refreshWG.Done()
+		var tick <-chan time.Time
+		if refreshInterval > 0 {
+			ticker := time.NewTicker(refreshInterval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		for {
+			select {
+			case <-tick:
+			case <-changed:
+			case <-ctx.Done():
+				return
+			}
+			detectCtx, detectCancel := withClientTimeout(ctx, client)
+			p.detectResource(detectCtx)
+			detectCancel()
+		}
+	}()
+}
+
+// StopRefreshing stops the background detection started by StartRefreshing once it
+// has been called as many times as StartRefreshing. The refresh can then be started again.
+func (p *ResourceProvider) StopRefreshing() {
+	p.refreshLock.Lock()
+	defer p.refreshLock.Unlock()
+	if p.refreshUsers == 0 {
+		return
+	}
+	p.refreshUsers--
+	if p.refreshUsers == 0 && p.stopRefresh != nil {
+		p.stopRefresh()
+		p.refreshWG.Wait()
+		p.stopRefresh = nil
+	}
+}
+
+func (p *ResourceProvider) detectResource(ctx context.Context) {
 	res := pcommon.NewResource()
 	mergedSchemaURL := ""
+	success := true
+	start := time.Now()
 
 	p.logger.Info("began detecting resource information")
 
-	for _, detector := range p.detectors {
+	for i, detector := range p.detectors {
 		r, schemaURL, err := detector.Detect(ctx)
 		if err != nil {
+			success = false
 			p.logger.Warn("failed to detect resource", zap.Error(err))
+			if p.lastDetected[i] == nil {
+				continue
+			}
+			// Keep the last known good value of the detector.
+			r, schemaURL = p.lastDetected[i].resource, p.lastDetected[i].schemaURL
 		} else {
-			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, schemaURL)
-			MergeResource(res, r, false)
+			p.lastDetected[i] = &resourceResult{resource: r, schemaURL: schemaURL}
 		}
+		mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, schemaURL)
+		MergeResource(res, r, false)
 	}
+	recordDetection(success, time.Since(start))
 
 	droppedAttributes := filterAttributes(res.Attributes(), p.attributesToKeep)
 
@@ -141,8 +255,7 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 		p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
 	}
 
-	p.detectedResource.resource = res
-	p.detectedResource.schemaURL = mergedSchemaURL
+	p.detectedResource.Store(&resourceResult{resource: res, schemaURL: mergedSchemaURL})
 }
 
 func MergeSchemaURL(currentSchemaURL string, newSchemaURL string) string {
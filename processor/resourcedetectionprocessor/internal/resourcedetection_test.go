@@ -241,6 +241,149 @@ func TestDetectResource_Parallel(t *testing.T) {
 	md3.AssertNumberOfCalls(t, "Detect", 1)
 }
 
+type sequenceDetector struct {
+	lock    sync.Mutex
+	results []map[string]any
+	calls   int
+}
+
+func (d *sequenceDetector) Detect(context.Context) (pcommon.Resource, string, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	attrs := d.results[d.calls%len(d.results)]
+	d.calls++
+	if attrs == nil {
+		return pcommon.NewResource(), "", errors.New("detection failed")
+	}
+	res := pcommon.NewResource()
+	err := res.Attributes().FromRaw(attrs)
+	return res, "", err
+}
+
+func TestDetectResource_Refresh(t *testing.T) {
+	md1 := &sequenceDetector{results: []map[string]any{{"a": "1"}, {"a": "2"}}}
+	md2 := &sequenceDetector{results: []map[string]any{{"b": "1"}, nil}}
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
+	detected, _, err := p.Get(context.Background(), &http.Client{Timeout: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1", "b": "1"}, detected.Attributes().AsRaw())
+
+	p.StartRefreshing(time.Millisecond, &http.Client{Timeout: time.Second})
+	defer p.StopRefreshing()
+
+	// md1 changes its value while md2 fails and keeps its last known good value.
+	assert.Eventually(t, func() bool {
+		detected, _, err = p.Get(context.Background(), http.DefaultClient)
+		require.NoError(t, err)
+		return detected.Attributes().AsRaw()["a"] == "2"
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, map[string]any{"a": "2", "b": "1"}, detected.Attributes().AsRaw())
+}
+
+func TestDetectResource_StopRefreshing(t *testing.T) {
+	md := &sequenceDetector{results: []map[string]any{{"a": "1"}}}
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	_, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+
+	callsAfter := func(d time.Duration) (before, after int) {
+		md.lock.Lock()
+		before = md.calls
+		md.lock.Unlock()
+		time.Sleep(d)
+		md.lock.Lock()
+		defer md.lock.Unlock()
+		return before, md.calls
+	}
+	refreshing := func() bool {
+		return assert.Eventually(t, func() bool {
+			before, after := callsAfter(5 * time.Millisecond)
+			return after > before
+		}, 5*time.Second, time.Millisecond)
+	}
+
+	// the provider is shared by the processors of the traces, metrics and logs pipelines
+	p.StartRefreshing(time.Millisecond, &http.Client{Timeout: time.Second})
+	p.StartRefreshing(time.Millisecond, &http.Client{Timeout: time.Second})
+	refreshing()
+
+	// the refresh goes on until the last processor shuts down
+	p.StopRefreshing()
+	refreshing()
+	p.StopRefreshing()
+	p.StopRefreshing()
+	before, after := callsAfter(10 * time.Millisecond)
+	assert.Equal(t, before, after)
+
+	// the refresh restarts with the processors, e.g. after a config reload
+	p.StartRefreshing(time.Millisecond, &http.Client{Timeout: time.Second})
+	refreshing()
+	p.StopRefreshing()
+	before, after = callsAfter(10 * time.Millisecond)
+	assert.Equal(t, before, after)
+}
+
+type watchingDetector struct {
+	*sequenceDetector
+	notify chan func()
+}
+
+func (d *watchingDetector) Watch(_ context.Context, notify func()) error {
+	d.notify <- notify
+	return nil
+}
+
+func TestDetectResource_Watch(t *testing.T) {
+	md := &watchingDetector{
+		sequenceDetector: &sequenceDetector{results: []map[string]any{{"a": "1"}, {"a": "2"}}},
+		notify:           make(chan func(), 1),
+	}
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	detected, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1"}, detected.Attributes().AsRaw())
+
+	// without a refresh interval, detection only re-runs when notified
+	p.StartRefreshing(0, &http.Client{Timeout: time.Second})
+	defer p.StopRefreshing()
+	notify := <-md.notify
+	notify()
+
+	assert.Eventually(t, func() bool {
+		detected, _, err = p.Get(context.Background(), http.DefaultClient)
+		require.NoError(t, err)
+		return detected.Attributes().AsRaw()["a"] == "2"
+	}, 5*time.Second, time.Millisecond)
+}
+
+type deadlineDetector struct {
+	hasDeadline chan bool
+}
+
+func (d *deadlineDetector) Detect(ctx context.Context) (pcommon.Resource, string, error) {
+	_, ok := ctx.Deadline()
+	select {
+	case d.hasDeadline <- ok:
+	default:
+	}
+	return pcommon.NewResource(), "", ctx.Err()
+}
+
+func TestDetectResource_ClientTimeout(t *testing.T) {
+	md := &deadlineDetector{hasDeadline: make(chan bool, 2)}
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+
+	// a client without a timeout doesn't bound the detection
+	_, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.False(t, <-md.hasDeadline)
+
+	p.StartRefreshing(time.Millisecond, &http.Client{Timeout: time.Minute})
+	defer p.StopRefreshing()
+	assert.True(t, <-md.hasDeadline)
+}
+
 func TestFilterAttributes_Match(t *testing.T) {
 	m := map[string]struct{}{
 		"host.name": {},
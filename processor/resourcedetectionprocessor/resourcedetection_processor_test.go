@@ -6,6 +6,7 @@ package resourcedetectionprocessor
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/env"
@@ -263,6 +265,40 @@ func TestResourceProcessor(t *testing.T) {
 	}
 }
 
+type countingDetector struct {
+	calls atomic.Int64
+}
+
+func (d *countingDetector) Detect(context.Context) (pcommon.Resource, string, error) {
+	d.calls.Add(1)
+	return pcommon.NewResource(), "", nil
+}
+
+func TestResourceProcessor_ShutdownWithoutStart(t *testing.T) {
+	md := &countingDetector{}
+	provider := internal.NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	newProcessor := func() *resourceDetectionProcessor {
+		return &resourceDetectionProcessor{
+			provider:           provider,
+			refreshInterval:    time.Millisecond,
+			httpClientSettings: defaultHTTPClientSettings(),
+			telemetrySettings:  componenttest.NewNopTelemetrySettings(),
+		}
+	}
+	started, notStarted := newProcessor(), newProcessor()
+	require.NoError(t, started.Start(context.Background(), componenttest.NewNopHost()))
+
+	// the processor that never started doesn't stop the refresh of the shared provider
+	require.NoError(t, notStarted.Shutdown(context.Background()))
+	calls := md.calls.Load()
+	assert.Eventually(t, func() bool {
+		return md.calls.Load() > calls
+	}, 5*time.Second, time.Millisecond)
+
+	require.NoError(t, started.Shutdown(context.Background()))
+	require.NoError(t, started.Shutdown(context.Background()))
+}
+
 func benchmarkConsumeTraces(b *testing.B, cfg *Config) {
 	factory := NewFactory()
 	sink := new(consumertest.TracesSink)
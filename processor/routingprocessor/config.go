@@ -54,6 +54,12 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// MatchOnce determines whether a signal is only routed to the first route of the table
+	// whose statement matches. When false (default), all statements are evaluated and the
+	// signal is routed once to each exporter of all matching routes.
+	// Optional.
+	MatchOnce bool `mapstructure:"match_once"`
+
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
@@ -82,6 +88,16 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("invalid route %s: %w", item.Value, errNoExporters)
 		}
 
+		switch item.Context {
+		case "", resourceContext:
+		case logContext, spanContext, dataPointContext:
+			if len(item.Statement) == 0 {
+				return fmt.Errorf("invalid route %s: the %q context requires a statement", item.Value, item.Context)
+			}
+		default:
+			return fmt.Errorf("invalid route %s: unsupported context %q", key(item), item.Context)
+		}
+
 		if item.Value != "" {
 			ottlRoutingOnly = false
 		}
@@ -114,6 +130,13 @@ const (
 	defaultAttributeSource = contextAttributeSource
 )
 
+const (
+	resourceContext  = "resource"
+	logContext       = "log"
+	spanContext      = "span"
+	dataPointContext = "datapoint"
+)
+
 // RoutingTableItem specifies how data should be routed to the different exporters
 type RoutingTableItem struct {
 	// Value represents a possible value for the field specified under FromAttribute.
@@ -124,6 +147,15 @@ type RoutingTableItem struct {
 	// Required when 'Value' isn't provided.
 	Statement string `mapstructure:"statement"`
 
+	// Context is the OTTL context the statement is evaluated in. The allowed values are:
+	// - "resource" - the statement is evaluated once for each resource
+	// - "log", "span" or "datapoint" - the statement is evaluated for each log record, span or
+	//   data point, so that the records of a resource can be routed to different exporters.
+	//   Routes with one of these contexts are only used by pipelines of the matching signal type.
+	// The default value is "resource".
+	// Optional.
+	Context string `mapstructure:"context"`
+
 	// Exporters contains the list of exporters to use when the value from the FromAttribute field matches this table item.
 	// When no exporters are specified, the ones specified under DefaultExporters are used, if any.
 	// The routing processor will fail upon the first failure from these exporters.
@@ -167,6 +199,8 @@ func rewriteRoutingEntriesToOTTL(cfg *Config) *Config {
 	}
 	return &Config{
 		DefaultExporters: cfg.DefaultExporters,
+		ErrorMode:        cfg.ErrorMode,
+		MatchOnce:        cfg.MatchOnce,
 		Table:            table,
 	}
 }
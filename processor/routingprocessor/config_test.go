@@ -116,6 +116,40 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configPath: "config.yaml",
+			id:         component.NewIDWithName(metadata.Type, "match_once"),
+			expected: &Config{
+				DefaultExporters: []string{"jaeger"},
+				ErrorMode:        ottl.PropagateError,
+				MatchOnce:        true,
+				Table: []RoutingTableItem{
+					{
+						Statement: "route() where resource.attributes[\"X-Tenant\"] == \"acme\"",
+						Exporters: []string{"jaeger/acme"},
+					},
+					{
+						Statement: "route() where resource.attributes[\"env\"] == \"prod\"",
+						Exporters: []string{"jaeger/prod"},
+					},
+				},
+			},
+		},
+		{
+			configPath: "config.yaml",
+			id:         component.NewIDWithName(metadata.Type, "record"),
+			expected: &Config{
+				DefaultExporters: []string{"otlp/cold"},
+				ErrorMode:        ottl.PropagateError,
+				Table: []RoutingTableItem{
+					{
+						Statement: "route() where severity_number >= SEVERITY_NUMBER_ERROR",
+						Context:   logContext,
+						Exporters: []string{"otlp/paging"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range testcases {
@@ -185,6 +219,34 @@ func TestValidateConfig(t *testing.T) {
 			},
 			error: "using a different attribute source than 'attribute' and drop_resource_routing_attribute is set to true",
 		},
+		{
+			name: "record context with value",
+			config: &Config{
+				FromAttribute:   "attr",
+				AttributeSource: resourceAttributeSource,
+				Table: []RoutingTableItem{
+					{
+						Exporters: []string{"otlp"},
+						Value:     "acme",
+						Context:   logContext,
+					},
+				},
+			},
+			error: "invalid route acme: the \"log\" context requires a statement",
+		},
+		{
+			name: "unsupported context",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Exporters: []string{"otlp"},
+						Statement: `route() where attributes["attr"] == "acme"`,
+						Context:   "scope",
+					},
+				},
+			},
+			error: "invalid route route() where attributes[\"attr\"] == \"acme\": unsupported context \"scope\"",
+		},
 	}
 
 	for _, tt := range tests {
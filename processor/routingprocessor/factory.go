@@ -23,6 +23,9 @@ const (
 
 	processorKey             = "processor"
 	metricSep                = "_"
+	routedSpansKey           = "routed_spans"
+	routedMetricPointsKey    = "routed_metric_points"
+	routedLogRecordsKey      = "routed_log_records"
 	nonRoutedSpansKey        = "non_routed_spans"
 	nonRoutedMetricPointsKey = "non_routed_metric_points"
 	nonRoutedLogRecordsKey   = "non_routed_log_records"
@@ -17,7 +17,6 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/metadata"
@@ -32,6 +31,7 @@ type logProcessor struct {
 	extractor extractor
 	router    router[exporter.Logs, ottllog.TransformContext]
 
+	routedLogRecordsCounter    metric.Int64Counter
 	nonRoutedLogRecordsCounter metric.Int64Counter
 }
 
@@ -44,6 +44,13 @@ func newLogProcessor(settings component.TelemetrySettings, config component.Conf
 	}
 
 	meter := settings.MeterProvider.Meter(scopeName + nameSep + "logs")
+	routedLogRecordsCounter, err := meter.Int64Counter(
+		metadata.Type+metricSep+processorKey+metricSep+routedLogRecordsKey,
+		metric.WithDescription("Number of log records that were routed to the exporters of a route"),
+	)
+	if err != nil {
+		return nil, err
+	}
 	nonRoutedLogRecordsCounter, err := meter.Int64Counter(
 		metadata.Type+metricSep+processorKey+metricSep+nonRoutedLogRecordsKey,
 		metric.WithDescription("Number of log records that were not routed to some or all exporters"),
@@ -56,12 +63,13 @@ func newLogProcessor(settings component.TelemetrySettings, config component.Conf
 		logger: settings.Logger,
 		config: cfg,
 		router: newRouter[exporter.Logs, ottllog.TransformContext](
-			cfg.Table,
-			cfg.DefaultExporters,
+			cfg,
+			logContext,
 			settings,
 			logParser,
 		),
 		extractor:                  newExtractor(cfg.FromAttribute, settings.Logger),
+		routedLogRecordsCounter:    routedLogRecordsCounter,
 		nonRoutedLogRecordsCounter: nonRoutedLogRecordsCounter,
 	}, nil
 }
@@ -100,6 +108,7 @@ func (p *logProcessor) route(ctx context.Context, l plog.Logs) error {
 	// This way we're not ending up with all the logs split up which would cause
 	// higher CPU usage.
 	groups := map[string]logsGroup{}
+	counts := newRoutingCounts()
 	var errs error
 
 	for i := 0; i < l.ResourceLogs().Len(); i++ {
@@ -110,30 +119,22 @@ func (p *logProcessor) route(ctx context.Context, l plog.Logs) error {
 			rlogs.Resource(),
 		)
 
-		matchCount := len(p.router.routes)
-		for key, route := range p.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, ltx)
-			if err != nil {
-				if p.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				p.group("", groups, p.router.defaultExporters, rlogs)
-				p.recordNonRoutedResourceLogs(ctx, key, rlogs)
-				continue
-			}
-			if !isMatch {
-				matchCount--
-				continue
-			}
-			p.group(key, groups, route.exporters, rlogs)
+		match, err := p.router.match(ctx, ltx)
+		if err != nil {
+			return err
 		}
-
-		if matchCount == 0 {
-			// no route conditions are matched, add resource logs to default exporters group
-			p.group("", groups, p.router.defaultExporters, rlogs)
-			p.recordNonRoutedResourceLogs(ctx, "", rlogs)
+		if p.router.hasRecordRoutes {
+			if err = p.routeLogRecords(ctx, rlogs, match, groups, counts); err != nil {
+				return err
+			}
+			continue
 		}
+		counts.add(match, int64(logRecordCount(rlogs)))
+		key, exporters := p.router.exportersFor(match)
+		p.group(key, groups, exporters, rlogs)
 	}
+	counts.record(ctx, p.routedLogRecordsCounter, p.nonRoutedLogRecordsCounter)
+
 	for _, g := range groups {
 		for _, e := range g.exporters {
 			errs = multierr.Append(errs, e.ConsumeLogs(ctx, g.logs))
@@ -157,26 +158,76 @@ func (p *logProcessor) group(
 	groups[key] = group
 }
 
-func (p *logProcessor) recordNonRoutedResourceLogs(ctx context.Context, routingKey string, rlogs plog.ResourceLogs) {
+// routeLogRecords routes each log record of the resource logs based on the
+// record-level routes and the match of the resource-level routes. The records
+// are copied to the groups along with their resource and scope.
+func (p *logProcessor) routeLogRecords(
+	ctx context.Context,
+	rlogs plog.ResourceLogs,
+	resourceMatch routeMatch,
+	groups map[string]logsGroup,
+	counts routingCounts,
+) error {
+	// resources holds the copy of the resource in each group, created on first use
+	resources := map[string]plog.ResourceLogs{}
+	for j := 0; j < rlogs.ScopeLogs().Len(); j++ {
+		sl := rlogs.ScopeLogs().At(j)
+		scopes := map[string]plog.ScopeLogs{}
+		for k := 0; k < sl.LogRecords().Len(); k++ {
+			lr := sl.LogRecords().At(k)
+			match, err := p.router.matchRecord(ctx, resourceMatch, ottllog.NewTransformContext(lr, sl.Scope(), rlogs.Resource()))
+			if err != nil {
+				return err
+			}
+			counts.add(match, 1)
+
+			key, exporters := p.router.exportersFor(match)
+			scope, ok := scopes[key]
+			if !ok {
+				rl, ok := resources[key]
+				if !ok {
+					group, ok := groups[key]
+					if !ok {
+						group = logsGroup{exporters: exporters, logs: plog.NewLogs()}
+						groups[key] = group
+					}
+					rl = group.logs.ResourceLogs().AppendEmpty()
+					rlogs.Resource().CopyTo(rl.Resource())
+					rl.SetSchemaUrl(rlogs.SchemaUrl())
+					resources[key] = rl
+				}
+				scope = rl.ScopeLogs().AppendEmpty()
+				sl.Scope().CopyTo(scope.Scope())
+				scope.SetSchemaUrl(sl.SchemaUrl())
+				scopes[key] = scope
+			}
+			lr.CopyTo(scope.LogRecords().AppendEmpty())
+		}
+	}
+	return nil
+}
+
+func logRecordCount(rlogs plog.ResourceLogs) int {
 	logRecordsCount := 0
 	sl := rlogs.ScopeLogs()
 	for j := 0; j < sl.Len(); j++ {
 		logRecordsCount += sl.At(j).LogRecords().Len()
 	}
-
-	p.nonRoutedLogRecordsCounter.Add(
-		ctx,
-		int64(logRecordsCount),
-		metric.WithAttributes(
-			attribute.String("routing_key", routingKey),
-		),
-	)
+	return logRecordsCount
 }
 
 func (p *logProcessor) routeForContext(ctx context.Context, l plog.Logs) error {
 	value := p.extractor.extractFromContext(ctx)
 	exporters := p.router.getExporters(value)
-	if value == "" { // "" is a  key for default exporters
+	if _, ok := p.router.routes[value]; ok {
+		p.routedLogRecordsCounter.Add(
+			ctx,
+			int64(l.LogRecordCount()),
+			metric.WithAttributes(
+				attribute.String("routing_key", value),
+			),
+		)
+	} else { // the logs are routed to the default exporters
 		p.nonRoutedLogRecordsCounter.Add(
 			ctx,
 			int64(l.LogRecordCount()),
@@ -12,6 +12,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -368,6 +370,264 @@ func TestLogsAreCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
 	})
 }
 
+func TestLogs_MatchOnce(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	firstExp := &mockLogsExporter{}
+	secondExp := &mockLogsExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {
+			component.NewID("otlp"):              defaultExp,
+			component.NewIDWithName("otlp", "1"): firstExp,
+			component.NewIDWithName("otlp", "2"): secondExp,
+		},
+	})
+
+	exp, err := newLogProcessor(noopTelemetrySettings, &Config{
+		DefaultExporters: []string{"otlp"},
+		MatchOnce:        true,
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where IsMatch(resource.attributes["X-Tenant"], ".*acme")`,
+				Exporters: []string{"otlp/1"},
+			},
+			{
+				Statement: `route() where IsMatch(resource.attributes["X-Tenant"], "_acme")`,
+				Exporters: []string{"otlp/2"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "_acme")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	// only the first matching route of the table is used
+	assert.Len(t, defaultExp.AllLogs(), 0)
+	assert.Len(t, firstExp.AllLogs(), 1)
+	assert.Len(t, secondExp.AllLogs(), 0)
+}
+
+func TestLogs_MergesExportersOfMatchingRoutes(t *testing.T) {
+	defaultExp := &mockLogsExporter{}
+	securityExp := &mockLogsExporter{}
+	appExp := &mockLogsExporter{}
+	archiveExp := &mockLogsExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {
+			component.NewID("otlp"):                     defaultExp,
+			component.NewIDWithName("otlp", "security"): securityExp,
+			component.NewIDWithName("otlp", "app"):      appExp,
+			component.NewIDWithName("otlp", "archive"):  archiveExp,
+		},
+	})
+
+	exp, err := newLogProcessor(noopTelemetrySettings, &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where resource.attributes["audit"] == "true"`,
+				Exporters: []string{"otlp/security", "otlp/archive"},
+			},
+			{
+				Statement: `route() where resource.attributes["team"] == "app"`,
+				Exporters: []string{"otlp/app", "otlp/archive"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("audit", "true")
+	rl.Resource().Attributes().PutStr("team", "app")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	rl = l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("team", "app")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	assert.Len(t, defaultExp.AllLogs(), 0)
+	assert.Equal(t, 1, securityExp.LogRecordCount())
+	assert.Equal(t, 2, appExp.LogRecordCount())
+	// the exporter shared by both routes receives the first resource only once
+	assert.Equal(t, 2, archiveExp.LogRecordCount())
+}
+
+func TestLogs_RoutingCounters(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	settings := noopTelemetrySettings
+	settings.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {
+			component.NewID("otlp"):              &mockLogsExporter{},
+			component.NewIDWithName("otlp", "1"): &mockLogsExporter{},
+		},
+	})
+
+	route := `route() where resource.attributes["X-Tenant"] == "acme"`
+	exp, err := newLogProcessor(settings, &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: route,
+				Exporters: []string{"otlp/1"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "acme")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	rl.ScopeLogs().At(0).LogRecords().AppendEmpty()
+	rl = l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "other")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	counts := map[string]map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			counts[m.Name] = map[string]int64{}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				key, _ := dp.Attributes.Value("routing_key")
+				counts[m.Name][key.AsString()] = dp.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]map[string]int64{
+		"routing_processor_routed_log_records":     {route: 2},
+		"routing_processor_non_routed_log_records": {"": 1},
+	}, counts)
+}
+
+func TestLogs_RecordLevelRouting(t *testing.T) {
+	coldExp := &mockLogsExporter{}
+	pagingExp := &mockLogsExporter{}
+	auditExp := &mockLogsExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {
+			component.NewIDWithName("otlp", "cold"):   coldExp,
+			component.NewIDWithName("otlp", "paging"): pagingExp,
+			component.NewIDWithName("otlp", "audit"):  auditExp,
+		},
+	})
+
+	exp, err := newLogProcessor(noopTelemetrySettings, &Config{
+		DefaultExporters: []string{"otlp/cold"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where severity_number >= SEVERITY_NUMBER_ERROR`,
+				Context:   logContext,
+				Exporters: []string{"otlp/paging"},
+			},
+			{
+				Statement: `route() where resource.attributes["service.name"] == "auth"`,
+				Exporters: []string{"otlp/audit"},
+			},
+			{
+				// routes for other signals are ignored by the logs pipeline
+				Statement: `route() where kind == SPAN_KIND_SERVER`,
+				Context:   spanContext,
+				Exporters: []string{"otlp/paging"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("checkout")
+	sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberInfo)
+	sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberError)
+	sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberDebug)
+	rl = l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "auth")
+	sl = rl.ScopeLogs().AppendEmpty()
+	sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberInfo)
+	sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberFatal)
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	// the error log of the checkout service is paged, the others go to the default exporter
+	require.Len(t, coldExp.AllLogs(), 1)
+	assert.Equal(t, 1, coldExp.AllLogs()[0].ResourceLogs().Len())
+
+	// the fatal log of the auth service matches both routes, the info log only the resource route
+	assert.Equal(t, 2, pagingExp.LogRecordCount())
+	assert.Equal(t, 2, auditExp.LogRecordCount())
+
+	// resource and scope of the split records are preserved
+	cold := coldExp.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"service.name": "checkout"}, cold.Resource().Attributes().AsRaw())
+	assert.Equal(t, "checkout", cold.ScopeLogs().At(0).Scope().Name())
+	records := cold.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	assert.Equal(t, plog.SeverityNumberInfo, records.At(0).SeverityNumber())
+	assert.Equal(t, plog.SeverityNumberDebug, records.At(1).SeverityNumber())
+}
+
+func TestLogs_RecordLevelRouting_MatchOnce(t *testing.T) {
+	firstExp := &mockLogsExporter{}
+	secondExp := &mockLogsExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeLogs: {
+			component.NewIDWithName("otlp", "1"): firstExp,
+			component.NewIDWithName("otlp", "2"): secondExp,
+		},
+	})
+
+	exp, err := newLogProcessor(noopTelemetrySettings, &Config{
+		MatchOnce: true,
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where severity_number >= SEVERITY_NUMBER_ERROR`,
+				Context:   logContext,
+				Exporters: []string{"otlp/1"},
+			},
+			{
+				Statement: `route() where resource.attributes["service.name"] == "auth"`,
+				Exporters: []string{"otlp/2"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "auth")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberError)
+	sl.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberInfo)
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), l))
+
+	// routes are evaluated in table order for each record
+	assert.Equal(t, 1, firstExp.LogRecordCount())
+	assert.Equal(t, 1, secondExp.LogRecordCount())
+}
+
 type mockLogsExporter struct {
 	mockComponent
 	consumertest.LogsSink
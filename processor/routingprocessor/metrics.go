@@ -17,7 +17,6 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/metadata"
@@ -32,6 +31,7 @@ type metricsProcessor struct {
 	extractor extractor
 	router    router[exporter.Metrics, ottldatapoint.TransformContext]
 
+	routedMetricPointsCounter    metric.Int64Counter
 	nonRoutedMetricPointsCounter metric.Int64Counter
 }
 
@@ -44,6 +44,13 @@ func newMetricProcessor(settings component.TelemetrySettings, config component.C
 	}
 
 	meter := settings.MeterProvider.Meter(scopeName + nameSep + "metrics")
+	routedMetricPointsCounter, err := meter.Int64Counter(
+		metadata.Type+metricSep+processorKey+metricSep+routedMetricPointsKey,
+		metric.WithDescription("Number of metric points that were routed to the exporters of a route."),
+	)
+	if err != nil {
+		return nil, err
+	}
 	nonRoutedMetricPointsCounter, err := meter.Int64Counter(
 		metadata.Type+metricSep+processorKey+metricSep+nonRoutedMetricPointsKey,
 		metric.WithDescription("Number of metric points that were not routed to some or all exporters."),
@@ -56,12 +63,13 @@ func newMetricProcessor(settings component.TelemetrySettings, config component.C
 		logger: settings.Logger,
 		config: cfg,
 		router: newRouter[exporter.Metrics](
-			cfg.Table,
-			cfg.DefaultExporters,
+			cfg,
+			dataPointContext,
 			settings,
 			dataPointParser,
 		),
 		extractor:                    newExtractor(cfg.FromAttribute, settings.Logger),
+		routedMetricPointsCounter:    routedMetricPointsCounter,
 		nonRoutedMetricPointsCounter: nonRoutedMetricPointsCounter,
 	}, nil
 }
@@ -99,6 +107,7 @@ func (p *metricsProcessor) route(ctx context.Context, tm pmetric.Metrics) error
 	// the same set of exporters. This way we're not ending up with all the
 	// metrics split up which would cause higher CPU usage.
 	groups := map[string]metricsGroup{}
+	counts := newRoutingCounts()
 
 	var errs error
 
@@ -112,30 +121,21 @@ func (p *metricsProcessor) route(ctx context.Context, tm pmetric.Metrics) error
 			rmetrics.Resource(),
 		)
 
-		matchCount := len(p.router.routes)
-		for key, route := range p.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, mtx)
-			if err != nil {
-				if p.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				p.group("", groups, p.router.defaultExporters, rmetrics)
-				p.recordNonRoutedForResourceMetrics(ctx, "", rmetrics)
-				continue
-			}
-			if !isMatch {
-				matchCount--
-				continue
-			}
-			p.group(key, groups, route.exporters, rmetrics)
+		match, err := p.router.match(ctx, mtx)
+		if err != nil {
+			return err
 		}
-
-		if matchCount == 0 {
-			// no route conditions are matched, add resource metrics to default exporters group
-			p.group("", groups, p.router.defaultExporters, rmetrics)
-			p.recordNonRoutedForResourceMetrics(ctx, "", rmetrics)
+		if p.router.hasRecordRoutes {
+			if err = p.routeDataPoints(ctx, rmetrics, match, groups, counts); err != nil {
+				return err
+			}
+			continue
 		}
+		counts.add(match, int64(dataPointCount(rmetrics)))
+		key, exporters := p.router.exportersFor(match)
+		p.group(key, groups, exporters, rmetrics)
 	}
+	counts.record(ctx, p.routedMetricPointsCounter, p.nonRoutedMetricPointsCounter)
 
 	for _, g := range groups {
 		for _, e := range g.exporters {
@@ -160,29 +160,188 @@ func (p *metricsProcessor) group(
 	groups[key] = group
 }
 
-func (p *metricsProcessor) recordNonRoutedForResourceMetrics(ctx context.Context, routingKey string, rm pmetric.ResourceMetrics) {
-	metricPointsCount := 0
+// routeDataPoints routes each data point of the resource metrics based on the
+// data point-level routes and the match of the resource-level routes. The data
+// points are copied to the groups along with their metric, resource and scope.
+func (p *metricsProcessor) routeDataPoints(
+	ctx context.Context,
+	rmetrics pmetric.ResourceMetrics,
+	resourceMatch routeMatch,
+	groups map[string]metricsGroup,
+	counts routingCounts,
+) error {
+	// resources holds the copy of the resource in each group, created on first use
+	resources := map[string]pmetric.ResourceMetrics{}
+	for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
+		sm := rmetrics.ScopeMetrics().At(j)
+		scopes := map[string]pmetric.ScopeMetrics{}
+		for k := 0; k < sm.Metrics().Len(); k++ {
+			m := sm.Metrics().At(k)
+			dests := map[string]pmetric.Metric{}
+
+			// destination returns the copy of the metric in the group of the given key.
+			destination := func(key string, exporters []exporter.Metrics) pmetric.Metric {
+				if dest, ok := dests[key]; ok {
+					return dest
+				}
+				scope, ok := scopes[key]
+				if !ok {
+					rm, ok := resources[key]
+					if !ok {
+						group, ok := groups[key]
+						if !ok {
+							group = metricsGroup{exporters: exporters, metrics: pmetric.NewMetrics()}
+							groups[key] = group
+						}
+						rm = group.metrics.ResourceMetrics().AppendEmpty()
+						rmetrics.Resource().CopyTo(rm.Resource())
+						rm.SetSchemaUrl(rmetrics.SchemaUrl())
+						resources[key] = rm
+					}
+					scope = rm.ScopeMetrics().AppendEmpty()
+					sm.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(sm.SchemaUrl())
+					scopes[key] = scope
+				}
+				dest := scope.Metrics().AppendEmpty()
+				copyMetricDescription(m, dest)
+				dests[key] = dest
+				return dest
+			}
+			matchDataPoint := func(dp any) (pmetric.Metric, error) {
+				match, err := p.router.matchRecord(ctx, resourceMatch, ottldatapoint.NewTransformContext(dp, m, sm.Metrics(), sm.Scope(), rmetrics.Resource()))
+				if err != nil {
+					return pmetric.Metric{}, err
+				}
+				counts.add(match, 1)
+				return destination(p.router.exportersFor(match)), nil
+			}
+
+			// metrics without data points follow the route of their resource
+			if metricDataPointCount(m) == 0 {
+				destination(p.router.exportersFor(resourceMatch))
+				continue
+			}
+
+			switch m.Type() {
+			case pmetric.MetricTypeGauge:
+				dps := m.Gauge().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dest, err := matchDataPoint(dps.At(l))
+					if err != nil {
+						return err
+					}
+					dps.At(l).CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+				}
+			case pmetric.MetricTypeSum:
+				dps := m.Sum().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dest, err := matchDataPoint(dps.At(l))
+					if err != nil {
+						return err
+					}
+					dps.At(l).CopyTo(dest.Sum().DataPoints().AppendEmpty())
+				}
+			case pmetric.MetricTypeHistogram:
+				dps := m.Histogram().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dest, err := matchDataPoint(dps.At(l))
+					if err != nil {
+						return err
+					}
+					dps.At(l).CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+				}
+			case pmetric.MetricTypeExponentialHistogram:
+				dps := m.ExponentialHistogram().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dest, err := matchDataPoint(dps.At(l))
+					if err != nil {
+						return err
+					}
+					dps.At(l).CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+				}
+			case pmetric.MetricTypeSummary:
+				dps := m.Summary().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					dest, err := matchDataPoint(dps.At(l))
+					if err != nil {
+						return err
+					}
+					dps.At(l).CopyTo(dest.Summary().DataPoints().AppendEmpty())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// copyMetricDescription copies the name, description, unit and type of the
+// metric, without its data points.
+func copyMetricDescription(src, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dest.SetEmptySummary()
+	}
+}
+
+// dataPointCount returns the number of data points of the resource metrics.
+func dataPointCount(rm pmetric.ResourceMetrics) int {
+	count := 0
 	sm := rm.ScopeMetrics()
 	for j := 0; j < sm.Len(); j++ {
-		metricPointsCount += sm.At(j).Metrics().Len()
+		ms := sm.At(j).Metrics()
+		for k := 0; k < ms.Len(); k++ {
+			count += metricDataPointCount(ms.At(k))
+		}
 	}
+	return count
+}
 
-	p.nonRoutedMetricPointsCounter.Add(
-		ctx,
-		int64(metricPointsCount),
-		metric.WithAttributes(
-			attribute.String("routing_key", routingKey),
-		),
-	)
+// metricDataPointCount returns the number of data points of the metric.
+func metricDataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
 }
 
 func (p *metricsProcessor) routeForContext(ctx context.Context, m pmetric.Metrics) error {
 	value := p.extractor.extractFromContext(ctx)
 	exporters := p.router.getExporters(value)
-	if value == "" { // "" is a  key for default exporters
+	if _, ok := p.router.routes[value]; ok {
+		p.routedMetricPointsCounter.Add(
+			ctx,
+			int64(m.DataPointCount()),
+			metric.WithAttributes(
+				attribute.String("routing_key", value),
+			),
+		)
+	} else { // the metrics are routed to the default exporters
 		p.nonRoutedMetricPointsCounter.Add(
 			ctx,
-			int64(m.MetricCount()),
+			int64(m.DataPointCount()),
 			metric.WithAttributes(
 				attribute.String("routing_key", p.extractor.fromAttr),
 			),
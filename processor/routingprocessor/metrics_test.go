@@ -254,6 +254,68 @@ func TestMetrics_RoutingWorks_ResourceAttribute_DropsRoutingAttribute(t *testing
 	assert.Equal(t, "acme", v.Str())
 }
 
+func TestMetrics_RecordLevelRouting(t *testing.T) {
+	defaultExp := &mockMetricsExporter{}
+	tenantExp := &mockMetricsExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeMetrics: {
+			component.NewID("otlp"):                   defaultExp,
+			component.NewIDWithName("otlp", "tenant"): tenantExp,
+		},
+	})
+
+	exp, err := newMetricProcessor(noopTelemetrySettings, &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where attributes["tenant"] == "acme"`,
+				Context:   dataPointContext,
+				Exporters: []string{"otlp/tenant"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	m := pmetric.NewMetrics()
+	rm := m.ResourceMetrics().AppendEmpty()
+	metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests")
+	metric.SetUnit("1")
+	sum := metric.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("tenant", "acme")
+	dp.SetIntValue(1)
+	dp = sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("tenant", "globex")
+	dp.SetIntValue(2)
+	// a metric without data points isn't dropped
+	rm.ScopeMetrics().At(0).Metrics().AppendEmpty().SetName("up")
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), m))
+
+	require.Len(t, tenantExp.AllMetrics(), 1)
+	require.Len(t, defaultExp.AllMetrics(), 1)
+
+	routed := tenantExp.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests", routed.Name())
+	assert.Equal(t, "1", routed.Unit())
+	assert.True(t, routed.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, routed.Sum().AggregationTemporality())
+	require.Equal(t, 1, routed.Sum().DataPoints().Len())
+	assert.Equal(t, int64(1), routed.Sum().DataPoints().At(0).IntValue())
+
+	others := defaultExp.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, others.Len())
+	other := others.At(0)
+	require.Equal(t, 1, other.Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), other.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, "up", others.At(1).Name())
+}
+
 type mockMetricsExporter struct {
 	mockComponent
 	consumertest.MetricsSink
@@ -4,10 +4,14 @@
 package routingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor"
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -22,18 +26,27 @@ type router[E component.Component, K any] struct {
 	logger *zap.Logger
 	parser ottl.Parser[K]
 
-	defaultExporterIDs []string
-	table              []RoutingTableItem
+	defaultExporterNames []string
+	table                []RoutingTableItem
+	matchOnce            bool
+	errorMode            ottl.ErrorMode
+	// recordContext is the record-level context of the signal type: log, span or datapoint.
+	recordContext string
 
-	defaultExporters []E
-	routes           map[string]routingItem[E, K]
+	defaultExporters   []E
+	defaultExporterIDs []component.ID
+	routes             map[string]routingItem[E, K]
+	// routeKeys contains the keys of the routes in the order of the routing table.
+	routeKeys []string
+	// hasRecordRoutes is set if any route is evaluated for each record.
+	hasRecordRoutes bool
 }
 
 // newRouter creates a new router instance with its type parameter constrained
 // to component.Component.
 func newRouter[E component.Component, K any](
-	table []RoutingTableItem,
-	defaultExporterIDs []string,
+	cfg *Config,
+	recordContext string,
 	settings component.TelemetrySettings,
 	parser ottl.Parser[K],
 
@@ -42,8 +55,11 @@ func newRouter[E component.Component, K any](
 		logger: settings.Logger,
 		parser: parser,
 
-		table:              table,
-		defaultExporterIDs: defaultExporterIDs,
+		table:                cfg.Table,
+		defaultExporterNames: cfg.DefaultExporters,
+		matchOnce:            cfg.MatchOnce,
+		errorMode:            cfg.ErrorMode,
+		recordContext:        recordContext,
 
 		routes: make(map[string]routingItem[E, K]),
 	}
@@ -51,7 +67,12 @@ func newRouter[E component.Component, K any](
 
 type routingItem[E component.Component, K any] struct {
 	exporters []E
-	statement *ottl.Statement[K]
+	// exporterIDs contains the IDs of the exporters, used to merge the exporters
+	// of several routes.
+	exporterIDs []component.ID
+	statement   *ottl.Statement[K]
+	// recordLevel is set for routes evaluated for each record rather than for each resource.
+	recordLevel bool
 }
 
 func (r *router[E, K]) registerExporters(available map[component.ID]component.Component) error {
@@ -73,8 +94,8 @@ func (r *router[E, K]) registerExporters(available map[component.ID]component.Co
 // registerDefaultExporters registers the configured default exporters
 // using the provided available exporters map.
 func (r *router[E, K]) registerDefaultExporters(available map[component.ID]component.Component) error {
-	for _, name := range r.defaultExporterIDs {
-		e, err := r.extractExporter(name, available)
+	for _, name := range r.defaultExporterNames {
+		e, id, err := r.extractExporter(name, available)
 		if errors.Is(err, errExporterNotFound) {
 			continue
 		}
@@ -82,6 +103,7 @@ func (r *router[E, K]) registerDefaultExporters(available map[component.ID]compo
 			return err
 		}
 		r.defaultExporters = append(r.defaultExporters, e)
+		r.defaultExporterIDs = append(r.defaultExporterIDs, id)
 	}
 
 	return nil
@@ -91,6 +113,12 @@ func (r *router[E, K]) registerDefaultExporters(available map[component.ID]compo
 // available exporters map to check if they were available.
 func (r *router[E, K]) registerRouteExporters(available map[component.ID]component.Component) error {
 	for _, item := range r.table {
+		recordLevel := item.Context != "" && item.Context != resourceContext
+		if recordLevel && item.Context != r.recordContext {
+			// the route is for another signal type
+			continue
+		}
+
 		statement, err := r.getStatementFrom(item)
 		if err != nil {
 			return err
@@ -99,10 +127,13 @@ func (r *router[E, K]) registerRouteExporters(available map[component.ID]compone
 		route, ok := r.routes[key(item)]
 		if !ok {
 			route.statement = statement
+			route.recordLevel = recordLevel
+			r.routeKeys = append(r.routeKeys, key(item))
+			r.hasRecordRoutes = r.hasRecordRoutes || recordLevel
 		}
 
 		for _, name := range item.Exporters {
-			e, err := r.extractExporter(name, available)
+			e, id, err := r.extractExporter(name, available)
 			if errors.Is(err, errExporterNotFound) {
 				continue
 			}
@@ -110,6 +141,7 @@ func (r *router[E, K]) registerRouteExporters(available map[component.ID]compone
 				return err
 			}
 			route.exporters = append(route.exporters, e)
+			route.exporterIDs = append(route.exporterIDs, id)
 		}
 		r.routes[key(item)] = route
 	}
@@ -138,14 +170,14 @@ func key(entry RoutingTableItem) string {
 	return entry.Statement
 }
 
-// extractExporter returns an exporter for the given name (type/name) and type
-// argument if it exists in the list of available exporters.
-func (r *router[E, K]) extractExporter(name string, available map[component.ID]component.Component) (E, error) {
+// extractExporter returns an exporter and its ID for the given name (type/name)
+// and type argument if it exists in the list of available exporters.
+func (r *router[E, K]) extractExporter(name string, available map[component.ID]component.Component) (E, component.ID, error) {
 	var exporter E
 
 	id := component.ID{}
 	if err := id.UnmarshalText([]byte(name)); err != nil {
-		return exporter, err
+		return exporter, id, err
 	}
 	v, ok := available[id]
 	if !ok {
@@ -160,14 +192,14 @@ func (r *router[E, K]) extractExporter(name string, available map[component.ID]c
 				),
 			),
 		)
-		return exporter, errExporterNotFound
+		return exporter, id, errExporterNotFound
 	}
 	exporter, ok = v.(E)
 	if !ok {
-		return exporter,
+		return exporter, id,
 			fmt.Errorf("the exporter %q isn't a %T exporter", id.String(), new(E))
 	}
-	return exporter, nil
+	return exporter, id, nil
 }
 
 func (r *router[E, K]) getExporters(key string) []E {
@@ -177,3 +209,165 @@ func (r *router[E, K]) getExporters(key string) []E {
 	}
 	return e.exporters
 }
+
+// routeMatch is the result of evaluating the routing table for a signal.
+type routeMatch struct {
+	// matched contains the keys of the matched routes, in the order of the routing table.
+	matched []string
+	// failed contains the keys of the routes whose statement returned an error,
+	// when errors are ignored.
+	failed []string
+}
+
+// useDefault returns whether the signal must be routed to the default exporters.
+func (m routeMatch) useDefault() bool {
+	return len(m.matched) == 0 || len(m.failed) > 0
+}
+
+// match evaluates the resource-level routing statements for the given transform
+// context in the order of the routing table. If matchOnce is set, the evaluation
+// stops at the first matching route, unless routes are also evaluated for each
+// record, in which case matchRecord selects the first matching route.
+func (r *router[E, K]) match(ctx context.Context, tCtx K) (routeMatch, error) {
+	var m routeMatch
+	for _, key := range r.routeKeys {
+		route := r.routes[key]
+		if route.recordLevel {
+			continue
+		}
+		isMatch, failed, err := r.execute(ctx, route, tCtx)
+		if err != nil {
+			return m, err
+		}
+		if failed {
+			m.failed = append(m.failed, key)
+			continue
+		}
+		if !isMatch {
+			continue
+		}
+		m.matched = append(m.matched, key)
+		if r.matchOnce && !r.hasRecordRoutes {
+			break
+		}
+	}
+	return m, nil
+}
+
+// matchRecord evaluates the routing table for a record in the order of the table,
+// using the results of the resource-level routes of the record's resource.
+func (r *router[E, K]) matchRecord(ctx context.Context, resourceMatch routeMatch, tCtx K) (routeMatch, error) {
+	var m routeMatch
+	for _, key := range r.routeKeys {
+		route := r.routes[key]
+		if !route.recordLevel {
+			switch {
+			case contains(resourceMatch.matched, key):
+				m.matched = append(m.matched, key)
+			case contains(resourceMatch.failed, key):
+				m.failed = append(m.failed, key)
+			}
+		} else {
+			isMatch, failed, err := r.execute(ctx, route, tCtx)
+			if err != nil {
+				return m, err
+			}
+			switch {
+			case failed:
+				m.failed = append(m.failed, key)
+			case isMatch:
+				m.matched = append(m.matched, key)
+			}
+		}
+		if r.matchOnce && len(m.matched) > 0 {
+			break
+		}
+	}
+	return m, nil
+}
+
+// execute executes the statement of the route. failed is set if the statement
+// returned an error and errors are ignored.
+func (r *router[E, K]) execute(ctx context.Context, route routingItem[E, K], tCtx K) (isMatch bool, failed bool, err error) {
+	_, isMatch, err = route.statement.Execute(ctx, tCtx)
+	if err != nil {
+		if r.errorMode == ottl.PropagateError {
+			return false, false, err
+		}
+		return false, true, nil
+	}
+	return isMatch, false, nil
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// exportersFor returns the exporters for the given match, merging the exporters of
+// all matched routes so that each exporter is only included once, along with a key
+// identifying that set of exporters.
+func (r *router[E, K]) exportersFor(m routeMatch) (string, []E) {
+	var (
+		keys      []string
+		exporters []E
+		seen      = map[component.ID]bool{}
+	)
+	add := func(ids []component.ID, es []E) {
+		for i, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			keys = append(keys, id.String())
+			exporters = append(exporters, es[i])
+		}
+	}
+	for _, key := range m.matched {
+		route := r.routes[key]
+		add(route.exporterIDs, route.exporters)
+	}
+	if m.useDefault() {
+		add(r.defaultExporterIDs, r.defaultExporters)
+	}
+	return strings.Join(keys, ","), exporters
+}
+
+// routingCounts accumulates the number of routed and non-routed items per
+// routing key, so that the counters are only updated once per batch.
+type routingCounts struct {
+	routed    map[string]int64
+	nonRouted map[string]int64
+}
+
+func newRoutingCounts() routingCounts {
+	return routingCounts{routed: map[string]int64{}, nonRouted: map[string]int64{}}
+}
+
+// add counts n items routed according to the given match.
+func (c routingCounts) add(m routeMatch, n int64) {
+	for _, key := range m.matched {
+		c.routed[key] += n
+	}
+	for _, key := range m.failed {
+		c.nonRouted[key] += n
+	}
+	if len(m.matched) == 0 && len(m.failed) == 0 {
+		// no route conditions are matched, items are routed to default exporters
+		c.nonRouted[""] += n
+	}
+}
+
+// record adds the accumulated counts to the counters.
+func (c routingCounts) record(ctx context.Context, routed metric.Int64Counter, nonRouted metric.Int64Counter) {
+	for key, n := range c.routed {
+		routed.Add(ctx, n, metric.WithAttributes(attribute.String("routing_key", key)))
+	}
+	for key, n := range c.nonRouted {
+		nonRouted.Add(ctx, n, metric.WithAttributes(attribute.String("routing_key", key)))
+	}
+}
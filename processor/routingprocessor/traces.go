@@ -17,7 +17,6 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/routingprocessor/internal/metadata"
@@ -32,6 +31,7 @@ type tracesProcessor struct {
 	extractor extractor
 	router    router[exporter.Traces, ottlspan.TransformContext]
 
+	routedSpansCounter    metric.Int64Counter
 	nonRoutedSpansCounter metric.Int64Counter
 }
 
@@ -44,6 +44,13 @@ func newTracesProcessor(settings component.TelemetrySettings, config component.C
 	}
 
 	meter := settings.MeterProvider.Meter(scopeName + nameSep + "traces")
+	routedSpansCounter, err := meter.Int64Counter(
+		metadata.Type+metricSep+processorKey+metricSep+routedSpansKey,
+		metric.WithDescription("Number of spans that were routed to the exporters of a route."),
+	)
+	if err != nil {
+		return nil, err
+	}
 	nonRoutedSpansCounter, err := meter.Int64Counter(
 		metadata.Type+metricSep+processorKey+metricSep+nonRoutedSpansKey,
 		metric.WithDescription("Number of spans that were not routed to some or all exporters."),
@@ -56,13 +63,14 @@ func newTracesProcessor(settings component.TelemetrySettings, config component.C
 		logger: settings.Logger,
 		config: cfg,
 		router: newRouter[exporter.Traces, ottlspan.TransformContext](
-			cfg.Table,
-			cfg.DefaultExporters,
+			cfg,
+			spanContext,
 			settings,
 			spanParser,
 		),
 		extractor: newExtractor(cfg.FromAttribute, settings.Logger),
 
+		routedSpansCounter:    routedSpansCounter,
 		nonRoutedSpansCounter: nonRoutedSpansCounter,
 	}, nil
 }
@@ -101,6 +109,7 @@ func (p *tracesProcessor) route(ctx context.Context, t ptrace.Traces) error {
 	// the same set of exporters. This way we're not ending up with all the
 	// logs split up which would cause higher CPU usage.
 	groups := map[string]spanGroup{}
+	counts := newRoutingCounts()
 
 	var errs error
 	for i := 0; i < t.ResourceSpans().Len(); i++ {
@@ -111,30 +120,21 @@ func (p *tracesProcessor) route(ctx context.Context, t ptrace.Traces) error {
 			rspans.Resource(),
 		)
 
-		matchCount := len(p.router.routes)
-		for key, route := range p.router.routes {
-			_, isMatch, err := route.statement.Execute(ctx, stx)
-			if err != nil {
-				if p.config.ErrorMode == ottl.PropagateError {
-					return err
-				}
-				p.group("", groups, p.router.defaultExporters, rspans)
-				p.recordNonRoutedResourceSpans(ctx, key, rspans)
-				continue
-			}
-			if !isMatch {
-				matchCount--
-				continue
-			}
-			p.group(key, groups, route.exporters, rspans)
+		match, err := p.router.match(ctx, stx)
+		if err != nil {
+			return err
 		}
-
-		if matchCount == 0 {
-			// no route conditions are matched, add resource spans to default exporters group
-			p.group("", groups, p.router.defaultExporters, rspans)
-			p.recordNonRoutedResourceSpans(ctx, "", rspans)
+		if p.router.hasRecordRoutes {
+			if err = p.routeSpans(ctx, rspans, match, groups, counts); err != nil {
+				return err
+			}
+			continue
 		}
+		counts.add(match, int64(spanCount(rspans)))
+		key, exporters := p.router.exportersFor(match)
+		p.group(key, groups, exporters, rspans)
 	}
+	counts.record(ctx, p.routedSpansCounter, p.nonRoutedSpansCounter)
 
 	for _, g := range groups {
 		for _, e := range g.exporters {
@@ -154,26 +154,76 @@ func (p *tracesProcessor) group(key string, groups map[string]spanGroup, exporte
 	groups[key] = group
 }
 
-func (p *tracesProcessor) recordNonRoutedResourceSpans(ctx context.Context, routingKey string, rspans ptrace.ResourceSpans) {
+// routeSpans routes each span of the resource spans based on the span-level
+// routes and the match of the resource-level routes. The spans are copied to
+// the groups along with their resource and scope.
+func (p *tracesProcessor) routeSpans(
+	ctx context.Context,
+	rspans ptrace.ResourceSpans,
+	resourceMatch routeMatch,
+	groups map[string]spanGroup,
+	counts routingCounts,
+) error {
+	// resources holds the copy of the resource in each group, created on first use
+	resources := map[string]ptrace.ResourceSpans{}
+	for j := 0; j < rspans.ScopeSpans().Len(); j++ {
+		ss := rspans.ScopeSpans().At(j)
+		scopes := map[string]ptrace.ScopeSpans{}
+		for k := 0; k < ss.Spans().Len(); k++ {
+			span := ss.Spans().At(k)
+			match, err := p.router.matchRecord(ctx, resourceMatch, ottlspan.NewTransformContext(span, ss.Scope(), rspans.Resource()))
+			if err != nil {
+				return err
+			}
+			counts.add(match, 1)
+
+			key, exporters := p.router.exportersFor(match)
+			scope, ok := scopes[key]
+			if !ok {
+				rs, ok := resources[key]
+				if !ok {
+					group, ok := groups[key]
+					if !ok {
+						group = spanGroup{exporters: exporters, traces: ptrace.NewTraces()}
+						groups[key] = group
+					}
+					rs = group.traces.ResourceSpans().AppendEmpty()
+					rspans.Resource().CopyTo(rs.Resource())
+					rs.SetSchemaUrl(rspans.SchemaUrl())
+					resources[key] = rs
+				}
+				scope = rs.ScopeSpans().AppendEmpty()
+				ss.Scope().CopyTo(scope.Scope())
+				scope.SetSchemaUrl(ss.SchemaUrl())
+				scopes[key] = scope
+			}
+			span.CopyTo(scope.Spans().AppendEmpty())
+		}
+	}
+	return nil
+}
+
+func spanCount(rspans ptrace.ResourceSpans) int {
 	spanCount := 0
 	ilss := rspans.ScopeSpans()
 	for j := 0; j < ilss.Len(); j++ {
 		spanCount += ilss.At(j).Spans().Len()
 	}
-
-	p.nonRoutedSpansCounter.Add(
-		ctx,
-		int64(spanCount),
-		metric.WithAttributes(
-			attribute.String("routing_key", routingKey),
-		),
-	)
+	return spanCount
 }
 
 func (p *tracesProcessor) routeForContext(ctx context.Context, t ptrace.Traces) error {
 	value := p.extractor.extractFromContext(ctx)
 	exporters := p.router.getExporters(value)
-	if value == "" { // "" is a  key for default exporters
+	if _, ok := p.router.routes[value]; ok {
+		p.routedSpansCounter.Add(
+			ctx,
+			int64(t.SpanCount()),
+			metric.WithAttributes(
+				attribute.String("routing_key", value),
+			),
+		)
+	} else { // the spans are routed to the default exporters
 		p.nonRoutedSpansCounter.Add(
 			ctx,
 			int64(t.SpanCount()),
@@ -424,6 +424,58 @@ func TestTracesAreCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
 	})
 }
 
+func TestTraces_RecordLevelRouting(t *testing.T) {
+	defaultExp := &mockTracesExporter{}
+	serverExp := &mockTracesExporter{}
+
+	host := newMockHost(map[component.DataType]map[component.ID]component.Component{
+		component.DataTypeTraces: {
+			component.NewID("otlp"):                   defaultExp,
+			component.NewIDWithName("otlp", "server"): serverExp,
+		},
+	})
+
+	exp, err := newTracesProcessor(noopTelemetrySettings, &Config{
+		DefaultExporters: []string{"otlp"},
+		Table: []RoutingTableItem{
+			{
+				Statement: `route() where kind == SPAN_KIND_SERVER`,
+				Context:   spanContext,
+				Exporters: []string{"otlp/server"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	tr := ptrace.NewTraces()
+	rs := tr.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("http")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span = ss.Spans().AppendEmpty()
+	span.SetName("SELECT cart")
+	span.SetKind(ptrace.SpanKindClient)
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), tr))
+
+	require.Len(t, serverExp.AllTraces(), 1)
+	require.Len(t, defaultExp.AllTraces(), 1)
+
+	server := serverExp.AllTraces()[0].ResourceSpans().At(0)
+	assert.Equal(t, map[string]any{"service.name": "checkout"}, server.Resource().Attributes().AsRaw())
+	assert.Equal(t, "http", server.ScopeSpans().At(0).Scope().Name())
+	require.Equal(t, 1, server.ScopeSpans().At(0).Spans().Len())
+	assert.Equal(t, "GET /cart", server.ScopeSpans().At(0).Spans().At(0).Name())
+
+	other := defaultExp.AllTraces()[0].ResourceSpans().At(0)
+	require.Equal(t, 1, other.ScopeSpans().At(0).Spans().Len())
+	assert.Equal(t, "SELECT cart", other.ScopeSpans().At(0).Spans().At(0).Name())
+}
+
 func TestTraceProcessorCapabilities(t *testing.T) {
 	// prepare
 	config := &Config{
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errMissingExtension = errors.New("the extension recording the sampling throughput must be specified")
+
+// Config defines the configuration for the sampling throughput processor.
+type Config struct {
+	// Extension is the ID of the jaegerremotesampling extension computing the adaptive strategies
+	// from the throughput reported by this processor.
+	Extension component.ID `mapstructure:"extension"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Extension == (component.ID{}) {
+		return errMissingExtension
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package samplingthroughputprocessor counts the root spans of each operation of each service
+// passing through a traces pipeline, and reports them to the jaegerremotesampling extension
+// computing adaptive sampling strategies.
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestDefaultConfiguration(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	assert.Equal(t, component.NewID("jaegerremotesampling"), c.Extension)
+	assert.NoError(t, componenttest.CheckConfigStruct(c))
+}
+
+func TestCreateTracesProcessor(t *testing.T) {
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, tp)
+	assert.False(t, tp.Capabilities().MutatesData)
+}
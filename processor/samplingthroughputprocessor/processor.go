@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+// throughputRecorder is implemented by the jaegerremotesampling extension computing adaptive strategies.
+// It is declared here so that this processor does not depend on the extension.
+type throughputRecorder interface {
+	RecordThroughput(service, operation string, count int64)
+}
+
+type operationKey struct {
+	service   string
+	operation string
+}
+
+type samplingThroughputProcessor struct {
+	cfg      *Config
+	logger   *zap.Logger
+	recorder throughputRecorder
+}
+
+func newSamplingThroughputProcessor(cfg *Config, logger *zap.Logger) *samplingThroughputProcessor {
+	return &samplingThroughputProcessor{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// start looks up the extension the throughput is reported to
+func (stp *samplingThroughputProcessor) start(_ context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[stp.cfg.Extension]
+	if !ok {
+		return fmt.Errorf("extension %q not found", stp.cfg.Extension)
+	}
+	recorder, ok := ext.(throughputRecorder)
+	if !ok {
+		return fmt.Errorf("extension %q does not record the sampling throughput", stp.cfg.Extension)
+	}
+	stp.recorder = recorder
+	return nil
+}
+
+// processTraces counts the root spans of each operation of each service, and reports them to the extension.
+// The spans of resources without a service name are not counted.
+func (stp *samplingThroughputProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	counts := map[operationKey]int64{}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		service, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+		if !ok || service.Str() == "" {
+			continue
+		}
+
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if !span.ParentSpanID().IsEmpty() {
+					continue
+				}
+				counts[operationKey{service: service.Str(), operation: span.Name()}]++
+			}
+		}
+	}
+
+	for key, count := range counts {
+		stp.recorder.RecordThroughput(key.service, key.operation, count)
+	}
+	return td, nil
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestProcessTraces(t *testing.T) {
+	recorder := &recordingExtension{counts: map[operationKey]int64{}}
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{component.NewID("jaegerremotesampling"): recorder},
+	}
+
+	sink := new(consumertest.TracesSink)
+	tp, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), host))
+
+	td := ptrace.NewTraces()
+	frontend := td.ResourceSpans().AppendEmpty()
+	frontend.Resource().Attributes().PutStr("service.name", "frontend")
+	spans := frontend.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("GET /")
+	spans.AppendEmpty().SetName("GET /")
+	spans.AppendEmpty().SetName("POST /cart")
+	child := spans.AppendEmpty()
+	child.SetName("SELECT")
+	child.SetParentSpanID(pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	anonymous := td.ResourceSpans().AppendEmpty()
+	anonymous.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /")
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	assert.Equal(t, map[operationKey]int64{
+		{service: "frontend", operation: "GET /"}:      2,
+		{service: "frontend", operation: "POST /cart"}: 1,
+	}, recorder.counts)
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, 5, sink.AllTraces()[0].SpanCount())
+}
+
+func TestStartExtensionNotFound(t *testing.T) {
+	tp, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, tp.Start(context.Background(), componenttest.NewNopHost()), `extension "jaegerremotesampling" not found`)
+}
+
+func TestStartExtensionNotRecording(t *testing.T) {
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{component.NewID("jaegerremotesampling"): &nopExtension{}},
+	}
+
+	tp, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), createDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, tp.Start(context.Background(), host), `extension "jaegerremotesampling" does not record the sampling throughput`)
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type recordingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	counts map[operationKey]int64
+}
+
+func (e *recordingExtension) RecordThroughput(service, operation string, count int64) {
+	e.counts[operationKey{service: service, operation: operation}] += count
+}
@@ -34,6 +34,12 @@ type Config struct {
 	StoreExpirationLoop time.Duration `mapstructure:"store_expiration_loop"`
 	// VirtualNodePeerAttributes the list of attributes need to match, the higher the front, the higher the priority.
 	VirtualNodePeerAttributes []string `mapstructure:"virtual_node_peer_attributes"`
+	// DatabaseNameAttributes the list of attributes used to name the database node of a client span, the higher the front, the higher the priority.
+	// A client span with none of these attributes is not considered as a database request.
+	DatabaseNameAttributes []string `mapstructure:"database_name_attributes"`
+	// MessagingDestinationAttributes the list of attributes used to name the messaging destination node of producer and consumer spans,
+	// the higher the front, the higher the priority.
+	MessagingDestinationAttributes []string `mapstructure:"messaging_destination_attributes"`
 }
 
 type StoreConfig struct {
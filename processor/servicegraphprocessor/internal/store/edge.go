@@ -38,6 +38,10 @@ type Edge struct {
 	expiration time.Time
 
 	Peer map[string]string
+
+	// MessagingDestination is the destination of the messages exchanged over
+	// the edge, used as node when either side of a messaging edge is missing.
+	MessagingDestination string
 }
 
 func newEdge(key Key, ttl time.Duration) *Edge {
@@ -30,6 +30,10 @@ const (
 	metricKeySeparator = string(byte(0))
 	clientKind         = "client"
 	serverKind         = "server"
+
+	// attributeMessagingDestinationName is the name of the messaging destination
+	// in the semantic conventions v1.17.0 and later.
+	attributeMessagingDestinationName = "messaging.destination.name"
 )
 
 var (
@@ -39,6 +43,12 @@ var (
 	defaultPeerAttributes = []string{
 		semconv.AttributeDBName, semconv.AttributeNetSockPeerAddr, semconv.AttributeNetPeerName, semconv.AttributeRPCService, semconv.AttributeNetSockPeerName, semconv.AttributeNetPeerName, semconv.AttributeHTTPURL, semconv.AttributeHTTPTarget,
 	}
+	defaultDatabaseNameAttributes = []string{
+		semconv.AttributeDBName, semconv.AttributeDBSystem,
+	}
+	defaultMessagingDestinationAttributes = []string{
+		attributeMessagingDestinationName, semconv.AttributeMessagingDestination,
+	}
 )
 
 type metricSeries struct {
@@ -95,6 +105,14 @@ func newProcessor(logger *zap.Logger, config component.Config) *serviceGraphProc
 		pConfig.VirtualNodePeerAttributes = defaultPeerAttributes
 	}
 
+	if pConfig.DatabaseNameAttributes == nil {
+		pConfig.DatabaseNameAttributes = defaultDatabaseNameAttributes
+	}
+
+	if pConfig.MessagingDestinationAttributes == nil {
+		pConfig.MessagingDestinationAttributes = defaultMessagingDestinationAttributes
+	}
+
 	return &serviceGraphProcessor{
 		config:                               pConfig,
 		logger:                               logger,
@@ -237,29 +255,37 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 							p.upsertPeerAttributes(p.config.VirtualNodePeerAttributes, e.Peer, span.Attributes())
 						}
 
+						if connectionType == store.MessagingSystem {
+							p.upsertMessagingDestination(e, span.Attributes())
+							return
+						}
+
 						// A database request will only have one span, we don't wait for the server
 						// span but just copy details from the client span
-						if dbName, ok := findAttributeValue(semconv.AttributeDBName, rAttributes, span.Attributes()); ok {
+						if dbName, ok := findFirstAttributeValue(p.config.DatabaseNameAttributes, rAttributes, span.Attributes()); ok {
 							e.ConnectionType = store.Database
 							e.ServerService = dbName
 							e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
 						}
 					})
 				case ptrace.SpanKindConsumer:
-					// override connection type and continue processing as span kind server
-					connectionType = store.MessagingSystem
-					fallthrough
+					// A consumer span is paired with the producer spans it links to, since a message
+					// is often received in another trace than the one it was produced in. Without
+					// links, it is paired with its parent span as a server span.
+					if span.Links().Len() == 0 {
+						connectionType = store.MessagingSystem
+						isNew, err = p.upsertServerEdge(span, span.TraceID(), span.ParentSpanID(), serviceName, connectionType, rAttributes)
+						break
+					}
+					for l := 0; l < span.Links().Len(); l++ {
+						link := span.Links().At(l)
+						isNew, err = p.upsertServerEdge(span, link.TraceID(), link.SpanID(), serviceName, store.MessagingSystem, rAttributes)
+						if err != nil {
+							break
+						}
+					}
 				case ptrace.SpanKindServer:
-					traceID := span.TraceID()
-					key := store.NewKey(traceID, span.ParentSpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
-						e.TraceID = traceID
-						e.ConnectionType = connectionType
-						e.ServerService = serviceName
-						e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
-						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
-						p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
-					})
+					isNew, err = p.upsertServerEdge(span, span.TraceID(), span.ParentSpanID(), serviceName, connectionType, rAttributes)
 				default:
 					// this span is not part of an edge
 					continue
@@ -285,6 +311,29 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 	return nil
 }
 
+// upsertServerEdge updates the edge between the span with the given trace and span ID and the server or consumer span.
+func (p *serviceGraphProcessor) upsertServerEdge(span ptrace.Span, traceID pcommon.TraceID, clientSpanID pcommon.SpanID, serviceName string, connectionType store.ConnectionType, rAttributes pcommon.Map) (bool, error) {
+	key := store.NewKey(traceID, clientSpanID)
+	return p.store.UpsertEdge(key, func(e *store.Edge) {
+		e.TraceID = traceID
+		e.ConnectionType = connectionType
+		e.ServerService = serviceName
+		e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
+		e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
+		p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
+
+		if connectionType == store.MessagingSystem {
+			p.upsertMessagingDestination(e, span.Attributes())
+		}
+	})
+}
+
+func (p *serviceGraphProcessor) upsertMessagingDestination(e *store.Edge, spanAttr pcommon.Map) {
+	if destination, ok := findFirstAttributeValue(p.config.MessagingDestinationAttributes, spanAttr); ok {
+		e.MessagingDestination = destination
+	}
+}
+
 func (p *serviceGraphProcessor) upsertDimensions(kind string, m map[string]string, resourceAttr pcommon.Map, spanAttr pcommon.Map) {
 	for _, dim := range p.config.Dimensions {
 		if v, ok := findAttributeValue(dim, resourceAttr, spanAttr); ok {
@@ -324,6 +373,19 @@ func (p *serviceGraphProcessor) onExpire(e *store.Edge) {
 
 	stats.Record(context.Background(), statExpiredEdges.M(1))
 
+	// The messaging destination stands in for the producer or consumer that was not seen,
+	// e.g. because it is not instrumented.
+	if e.ConnectionType == store.MessagingSystem && e.MessagingDestination != "" {
+		if len(e.ClientService) == 0 {
+			e.ClientService = e.MessagingDestination
+		}
+		if len(e.ServerService) == 0 {
+			e.ServerService = e.MessagingDestination
+		}
+		p.onComplete(e)
+		return
+	}
+
 	if virtualNodeFeatureGate.IsEnabled() {
 		e.ConnectionType = store.VirtualNode
 		if len(e.ClientService) == 0 && e.Key.SpanIDIsEmpty() {
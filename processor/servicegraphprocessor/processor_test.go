@@ -415,6 +415,125 @@ func incompleteServerTraces(withParentSpan bool) ptrace.Traces {
 	return traces
 }
 
+func TestMessagingAndDatabaseEdges(t *testing.T) {
+	producerTraceID := pcommon.TraceID([16]byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+	consumerTraceID := pcommon.TraceID([16]byte{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2})
+	producerSpanID := pcommon.SpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1})
+
+	for _, tc := range []struct {
+		name     string
+		traces   func() ptrace.Traces
+		expected []map[string]any
+	}{
+		{
+			name: "consumer linked to producer",
+			traces: func() ptrace.Traces {
+				td := ptrace.NewTraces()
+				producer := appendSpan(td, "producer", ptrace.SpanKindProducer, producerTraceID, producerSpanID)
+				producer.Attributes().PutStr("messaging.destination", "orders")
+				consumer := appendSpan(td, "consumer", ptrace.SpanKindConsumer, consumerTraceID, pcommon.SpanID([8]byte{2, 2, 2, 2, 2, 2, 2, 2}))
+				link := consumer.Links().AppendEmpty()
+				link.SetTraceID(producerTraceID)
+				link.SetSpanID(producerSpanID)
+				return td
+			},
+			expected: []map[string]any{
+				{"client": "producer", "server": "consumer", "connection_type": "messaging_system", "failed": false},
+			},
+		},
+		{
+			name: "producer without consumer",
+			traces: func() ptrace.Traces {
+				td := ptrace.NewTraces()
+				producer := appendSpan(td, "producer", ptrace.SpanKindProducer, producerTraceID, producerSpanID)
+				producer.Attributes().PutStr("messaging.destination.name", "orders")
+				return td
+			},
+			expected: []map[string]any{
+				{"client": "producer", "server": "orders", "connection_type": "messaging_system", "failed": false},
+			},
+		},
+		{
+			name: "consumer without producer",
+			traces: func() ptrace.Traces {
+				td := ptrace.NewTraces()
+				consumer := appendSpan(td, "consumer", ptrace.SpanKindConsumer, consumerTraceID, pcommon.SpanID([8]byte{2, 2, 2, 2, 2, 2, 2, 2}))
+				consumer.Attributes().PutStr("messaging.destination", "orders")
+				return td
+			},
+			expected: []map[string]any{
+				{"client": "orders", "server": "consumer", "connection_type": "messaging_system", "failed": false},
+			},
+		},
+		{
+			name: "database named by system",
+			traces: func() ptrace.Traces {
+				td := ptrace.NewTraces()
+				client := appendSpan(td, "app", ptrace.SpanKindClient, producerTraceID, producerSpanID)
+				client.Attributes().PutStr("db.system", "postgresql")
+				return td
+			},
+			expected: []map[string]any{
+				{"client": "app", "server": "postgresql", "connection_type": "database", "failed": false},
+			},
+		},
+		{
+			name: "database named by name",
+			traces: func() ptrace.Traces {
+				td := ptrace.NewTraces()
+				client := appendSpan(td, "app", ptrace.SpanKindClient, producerTraceID, producerSpanID)
+				client.Attributes().PutStr("db.system", "postgresql")
+				client.Attributes().PutStr("db.name", "customers")
+				return td
+			},
+			expected: []map[string]any{
+				{"client": "app", "server": "customers", "connection_type": "database", "failed": false},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newProcessor(zaptest.NewLogger(t), &Config{
+				Store: StoreConfig{MaxItems: 10, TTL: time.Nanosecond},
+			})
+			p.metricsConsumer = newMockMetricsExporter()
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, p.Shutdown(context.Background())) }()
+
+			require.NoError(t, p.ConsumeTraces(context.Background(), tc.traces()))
+			time.Sleep(time.Millisecond)
+			p.store.Expire()
+
+			md, err := p.buildMetrics()
+			require.NoError(t, err)
+
+			var actual []map[string]any
+			metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < metrics.Len(); i++ {
+				if metrics.At(i).Name() == "traces_service_graph_request_total" {
+					actual = append(actual, metrics.At(i).Sum().DataPoints().At(0).Attributes().AsRaw())
+				}
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func appendSpan(td ptrace.Traces, serviceName string, kind ptrace.SpanKind, traceID pcommon.TraceID, spanID pcommon.SpanID) ptrace.Span {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
+
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(semconv.AttributeServiceName, serviceName)
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName(serviceName + " span")
+	span.SetKind(kind)
+	span.SetTraceID(traceID)
+	span.SetSpanID(spanID)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+	return span
+}
+
 func newOTLPExporters(t *testing.T) (component.ID, exporter.Metrics, exporter.Traces) {
 	otlpExpFactory := otlpexporter.NewFactory()
 	otlpID := component.NewID("otlp")
@@ -17,6 +17,16 @@ func findAttributeValue(key string, attributes ...pcommon.Map) (string, bool) {
 	return "", false
 }
 
+// findFirstAttributeValue returns the value of the first of the given keys found in the attributes.
+func findFirstAttributeValue(keys []string, attributes ...pcommon.Map) (string, bool) {
+	for _, key := range keys {
+		if v, ok := findAttributeValue(key, attributes...); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
 func findServiceName(attributes pcommon.Map) (string, bool) {
 	return findAttributeValue(semconv.AttributeServiceName, attributes)
 }
@@ -114,6 +114,8 @@ func TestLoadConfig(t *testing.T) {
 					observer.PortType:      {"port.key": "port.value"},
 					observer.HostPortType:  {"hostport.key": "hostport.value"},
 					observer.K8sNodeType:   {"k8s.node.key": "k8s.node.value"},
+					observer.ProcessType:   {"process.key": "process.value"},
+					observer.FileType:      {"file.key": "file.value"},
 				},
 			},
 		},
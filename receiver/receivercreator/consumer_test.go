@@ -30,6 +30,8 @@ func TestNewEnhancingConsumer(t *testing.T) {
 	require.NoError(t, err)
 	cntrEnv, err := containerEndpoint.Env()
 	require.NoError(t, err)
+	procEnv, err := processEndpoint.Env()
+	require.NoError(t, err)
 
 	cfg := createDefaultConfig().(*Config)
 	type args struct {
@@ -109,6 +111,29 @@ func TestNewEnhancingConsumer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "process endpoint",
+			args: args{
+				resources:   cfg.ResourceAttributes,
+				env:         procEnv,
+				endpoint:    processEndpoint,
+				nextLogs:    &consumertest.LogsSink{},
+				nextMetrics: nil,
+				nextTraces:  nil,
+			},
+			want: &enhancingConsumer{
+				logs:    &consumertest.LogsSink{},
+				metrics: nil,
+				traces:  nil,
+				attrs: map[string]string{
+					"process.pid":             "4321",
+					"process.executable.name": "java",
+					"process.executable.path": "/usr/bin/java",
+					"process.command_line":    "java -jar app.jar",
+					"process.owner":           "app",
+				},
+			},
+		},
 		{
 			// If the configured attribute value is empty it should not touch that
 			// attribute.
@@ -52,6 +52,13 @@ func createDefaultConfig() component.Config {
 				conventions.AttributeK8SNodeName: "`name`",
 				conventions.AttributeK8SNodeUID:  "`uid`",
 			},
+			observer.ProcessType: map[string]string{
+				conventions.AttributeProcessPID:            "`pid`",
+				conventions.AttributeProcessExecutableName: "`process_name`",
+				conventions.AttributeProcessExecutablePath: "`executable`",
+				conventions.AttributeProcessCommandLine:    "`command`",
+				conventions.AttributeProcessOwner:          "`user`",
+			},
 		},
 		receiverTemplates: map[string]receiverTemplate{},
 	}
@@ -92,6 +92,26 @@ var k8sNodeEndpoint = observer.Endpoint{
 	},
 }
 
+var processEndpoint = observer.Endpoint{
+	ID: "process-1",
+	Details: &observer.Process{
+		PID:        4321,
+		Name:       "java",
+		Command:    "java -jar app.jar",
+		Executable: "/usr/bin/java",
+		User:       "app",
+		Ports:      []uint16{8080, 9999},
+	},
+}
+
+var fileEndpoint = observer.Endpoint{
+	ID:     "file-1",
+	Target: "/var/log/app/app.log",
+	Details: &observer.File{
+		Path: "/var/log/app/app.log",
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",